@@ -0,0 +1,39 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/flexer2006/t-t-ogen-go/internal/adapters/auth"
+)
+
+const keyBytes = 32
+
+func main() {
+	label := flag.String("label", "", "label identifying the key owner")
+	ttl := flag.Duration("ttl", 0, "key lifetime; zero means the key never expires")
+	flag.Parse()
+
+	if *label == "" {
+		log.Fatal("apikey: -label is required")
+	}
+
+	secret := make([]byte, keyBytes)
+	if _, err := rand.Read(secret); err != nil {
+		log.Fatalf("apikey: %v", err)
+	}
+
+	key := base64.RawURLEncoding.EncodeToString(secret)
+
+	fmt.Fprintf(os.Stderr, "key: %s\n", key)
+	fmt.Printf("- label: %q\n  hash: %q\n", *label, auth.HashAPIKey(key))
+
+	if *ttl > 0 {
+		fmt.Printf("  expires_at: %q\n", time.Now().Add(*ttl).UTC().Format(time.RFC3339))
+	}
+}
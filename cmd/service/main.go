@@ -2,27 +2,74 @@ package main
 
 import (
 	"context"
+	"errors"
+	"flag"
 	"log"
+	"log/slog"
 	"os"
 	"os/signal"
 	"syscall"
 
 	"github.com/flexer2006/t-t-ogen-go/internal/app"
+	"github.com/flexer2006/t-t-ogen-go/internal/config"
 )
 
 func main() {
+	loaded, err := config.Load(os.Args[1:], os.LookupEnv, os.Stderr)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return
+		}
+
+		log.Fatalf("config: %v", err)
+	}
+
+	if loaded.PrintConfig {
+		if err := loaded.Config.Write(os.Stdout); err != nil {
+			log.Fatalf("print config: %v", err)
+		}
+
+		if err := loaded.Config.Validate(); err != nil {
+			log.Fatalf("config: %v", err)
+		}
+
+		return
+	}
+
+	logger := app.NewLogger(loaded.Config.Log, os.Stderr)
+	slog.SetDefault(logger)
+
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer stop()
 
-	application, err := app.NewApplication("")
+	application, err := app.NewApplication(loaded.Config, app.WithLogger(logger))
 	if err != nil {
-		log.Printf("setup: %v", err)
+		logger.Error("setup", slog.Any("error", err))
 
 		return
 	}
 
+	// SIGHUP restarts the service without closing its socket: a new process
+	// takes over the listener and this one shuts down gracefully.
+	hangup := make(chan os.Signal, 1)
+	signal.Notify(hangup, syscall.SIGHUP)
+
+	go func() {
+		for range hangup {
+			if _, err := application.Handoff(ctx); err != nil {
+				logger.Error("handoff", slog.Any("error", err))
+
+				continue
+			}
+
+			stop()
+
+			return
+		}
+	}()
+
 	err = application.Run(ctx)
 	if err != nil {
-		log.Printf("application: %v", err)
+		logger.Error("application", slog.Any("error", err))
 	}
 }
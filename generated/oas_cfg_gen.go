@@ -8,6 +8,7 @@ import (
 	ht "github.com/ogen-go/ogen/http"
 	"github.com/ogen-go/ogen/middleware"
 	"github.com/ogen-go/ogen/ogenerrors"
+	"github.com/ogen-go/ogen/ogenregex"
 	"github.com/ogen-go/ogen/otelogen"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
@@ -15,6 +16,11 @@ import (
 	"go.opentelemetry.io/otel/trace"
 )
 
+var regexMap = map[string]ogenregex.Regexp{
+	"^[a-z0-9][a-z0-9-]{0,62}$": ogenregex.MustCompile("^[a-z0-9][a-z0-9-]{0,62}$"),
+	"^[a-z][a-z0-9_]{0,62}$":    ogenregex.MustCompile("^[a-z][a-z0-9_]{0,62}$"),
+	"^[a-z][a-z0-9_]{0,62}:":    ogenregex.MustCompile("^[a-z][a-z0-9_]{0,62}:"),
+}
 var (
 	// Allocate option closure once.
 	clientSpanKind = trace.WithSpanKind(trace.SpanKindClient)
@@ -1,14 +1,98 @@
 // Code generated by ogen, DO NOT EDIT.
 package api
 
+type AddGroupMemberRes interface {
+	addGroupMemberRes()
+}
+
+type ChangeUserPasswordRes interface {
+	changeUserPasswordRes()
+}
+
+type CreateGroupRes interface {
+	createGroupRes()
+}
+
+type CreateSessionRes interface {
+	createSessionRes()
+}
+
+type CreateTenantRes interface {
+	createTenantRes()
+}
+
+type CreateUserRes interface {
+	createUserRes()
+}
+
+type DeleteGroupRes interface {
+	deleteGroupRes()
+}
+
+type DeleteTenantRes interface {
+	deleteTenantRes()
+}
+
 type DeleteUserRes interface {
 	deleteUserRes()
 }
 
+type GetGroupRes interface {
+	getGroupRes()
+}
+
+type GetTenantRes interface {
+	getTenantRes()
+}
+
 type GetUserRes interface {
 	getUserRes()
 }
 
+type ListGroupMembersRes interface {
+	listGroupMembersRes()
+}
+
+type ListUserGroupsRes interface {
+	listUserGroupsRes()
+}
+
+type ListUserSessionsRes interface {
+	listUserSessionsRes()
+}
+
+type PutAttributeSchemaRes interface {
+	putAttributeSchemaRes()
+}
+
+type RemoveGroupMemberRes interface {
+	removeGroupMemberRes()
+}
+
+type RequestEmailVerificationRes interface {
+	requestEmailVerificationRes()
+}
+
+type RevokeUserSessionRes interface {
+	revokeUserSessionRes()
+}
+
+type RevokeUserSessionsRes interface {
+	revokeUserSessionsRes()
+}
+
+type SetUserPasswordRes interface {
+	setUserPasswordRes()
+}
+
+type UpdateGroupRes interface {
+	updateGroupRes()
+}
+
 type UpdateUserRes interface {
 	updateUserRes()
 }
+
+type VerifyUserEmailRes interface {
+	verifyUserEmailRes()
+}
@@ -6,9 +6,32 @@ package api
 type OperationName = string
 
 const (
-	CreateUserOperation OperationName = "CreateUser"
-	DeleteUserOperation OperationName = "DeleteUser"
-	GetUserOperation    OperationName = "GetUser"
-	ListUsersOperation  OperationName = "ListUsers"
-	UpdateUserOperation OperationName = "UpdateUser"
+	AddGroupMemberOperation           OperationName = "AddGroupMember"
+	ChangeUserPasswordOperation       OperationName = "ChangeUserPassword"
+	CreateGroupOperation              OperationName = "CreateGroup"
+	CreateSessionOperation            OperationName = "CreateSession"
+	CreateTenantOperation             OperationName = "CreateTenant"
+	CreateUserOperation               OperationName = "CreateUser"
+	DeleteGroupOperation              OperationName = "DeleteGroup"
+	DeleteTenantOperation             OperationName = "DeleteTenant"
+	DeleteUserOperation               OperationName = "DeleteUser"
+	GetAttributeSchemaOperation       OperationName = "GetAttributeSchema"
+	GetGroupOperation                 OperationName = "GetGroup"
+	GetTenantOperation                OperationName = "GetTenant"
+	GetUserOperation                  OperationName = "GetUser"
+	ListGroupMembersOperation         OperationName = "ListGroupMembers"
+	ListGroupsOperation               OperationName = "ListGroups"
+	ListTenantsOperation              OperationName = "ListTenants"
+	ListUserGroupsOperation           OperationName = "ListUserGroups"
+	ListUserSessionsOperation         OperationName = "ListUserSessions"
+	ListUsersOperation                OperationName = "ListUsers"
+	PutAttributeSchemaOperation       OperationName = "PutAttributeSchema"
+	RemoveGroupMemberOperation        OperationName = "RemoveGroupMember"
+	RequestEmailVerificationOperation OperationName = "RequestEmailVerification"
+	RevokeUserSessionOperation        OperationName = "RevokeUserSession"
+	RevokeUserSessionsOperation       OperationName = "RevokeUserSessions"
+	SetUserPasswordOperation          OperationName = "SetUserPassword"
+	UpdateGroupOperation              OperationName = "UpdateGroup"
+	UpdateUserOperation               OperationName = "UpdateUser"
+	VerifyUserEmailOperation          OperationName = "VerifyUserEmail"
 )
@@ -3,6 +3,7 @@
 package api
 
 import (
+	"fmt"
 	"net/http"
 	"net/url"
 
@@ -15,13 +16,3034 @@ import (
 	"github.com/ogen-go/ogen/validate"
 )
 
+// AddGroupMemberParams is parameters of addGroupMember operation.
+type AddGroupMemberParams struct {
+	// Tenant of the request. Principals bound to a tenant may omit it or must repeat their own tenant;
+	// other principals must set it when tenancy is enabled.
+	XTenantID OptTenantID `json:",omitempty,omitzero"`
+	// Unique group identifier.
+	ID uuid.UUID
+	// Unique user identifier.
+	UserId uuid.UUID
+}
+
+func unpackAddGroupMemberParams(packed middleware.Parameters) (params AddGroupMemberParams) {
+	{
+		key := middleware.ParameterKey{
+			Name: "X-Tenant-ID",
+			In:   "header",
+		}
+		if v, ok := packed[key]; ok {
+			params.XTenantID = v.(OptTenantID)
+		}
+	}
+	{
+		key := middleware.ParameterKey{
+			Name: "id",
+			In:   "path",
+		}
+		params.ID = packed[key].(uuid.UUID)
+	}
+	{
+		key := middleware.ParameterKey{
+			Name: "userId",
+			In:   "path",
+		}
+		params.UserId = packed[key].(uuid.UUID)
+	}
+	return params
+}
+
+func decodeAddGroupMemberParams(args [2]string, argsEscaped bool, r *http.Request) (params AddGroupMemberParams, _ error) {
+	h := uri.NewHeaderDecoder(r.Header)
+	// Decode header: X-Tenant-ID.
+	if err := func() error {
+		cfg := uri.HeaderParameterDecodingConfig{
+			Name:    "X-Tenant-ID",
+			Explode: false,
+		}
+		if err := h.HasParam(cfg); err == nil {
+			if err := h.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotXTenantIDVal TenantID
+				if err := func() error {
+					var paramsDotXTenantIDValVal string
+					if err := func() error {
+						val, err := d.DecodeValue()
+						if err != nil {
+							return err
+						}
+
+						c, err := conv.ToString(val)
+						if err != nil {
+							return err
+						}
+
+						paramsDotXTenantIDValVal = c
+						return nil
+					}(); err != nil {
+						return err
+					}
+					paramsDotXTenantIDVal = TenantID(paramsDotXTenantIDValVal)
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.XTenantID.SetTo(paramsDotXTenantIDVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+			if err := func() error {
+				if value, ok := params.XTenantID.Get(); ok {
+					if err := func() error {
+						if err := value.Validate(); err != nil {
+							return err
+						}
+						return nil
+					}(); err != nil {
+						return err
+					}
+				}
+				return nil
+			}(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "X-Tenant-ID",
+			In:   "header",
+			Err:  err,
+		}
+	}
+	// Decode path: id.
+	if err := func() error {
+		param := args[0]
+		if argsEscaped {
+			unescaped, err := url.PathUnescape(args[0])
+			if err != nil {
+				return errors.Wrap(err, "unescape path")
+			}
+			param = unescaped
+		}
+		if len(param) > 0 {
+			d := uri.NewPathDecoder(uri.PathDecoderConfig{
+				Param:   "id",
+				Value:   param,
+				Style:   uri.PathStyleSimple,
+				Explode: false,
+			})
+
+			if err := func() error {
+				val, err := d.DecodeValue()
+				if err != nil {
+					return err
+				}
+
+				c, err := conv.ToUUID(val)
+				if err != nil {
+					return err
+				}
+
+				params.ID = c
+				return nil
+			}(); err != nil {
+				return err
+			}
+		} else {
+			return validate.ErrFieldRequired
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "id",
+			In:   "path",
+			Err:  err,
+		}
+	}
+	// Decode path: userId.
+	if err := func() error {
+		param := args[1]
+		if argsEscaped {
+			unescaped, err := url.PathUnescape(args[1])
+			if err != nil {
+				return errors.Wrap(err, "unescape path")
+			}
+			param = unescaped
+		}
+		if len(param) > 0 {
+			d := uri.NewPathDecoder(uri.PathDecoderConfig{
+				Param:   "userId",
+				Value:   param,
+				Style:   uri.PathStyleSimple,
+				Explode: false,
+			})
+
+			if err := func() error {
+				val, err := d.DecodeValue()
+				if err != nil {
+					return err
+				}
+
+				c, err := conv.ToUUID(val)
+				if err != nil {
+					return err
+				}
+
+				params.UserId = c
+				return nil
+			}(); err != nil {
+				return err
+			}
+		} else {
+			return validate.ErrFieldRequired
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "userId",
+			In:   "path",
+			Err:  err,
+		}
+	}
+	return params, nil
+}
+
+// ChangeUserPasswordParams is parameters of changeUserPassword operation.
+type ChangeUserPasswordParams struct {
+	// Tenant of the request. Principals bound to a tenant may omit it or must repeat their own tenant;
+	// other principals must set it when tenancy is enabled.
+	XTenantID OptTenantID `json:",omitempty,omitzero"`
+	// Unique user identifier.
+	ID uuid.UUID
+}
+
+func unpackChangeUserPasswordParams(packed middleware.Parameters) (params ChangeUserPasswordParams) {
+	{
+		key := middleware.ParameterKey{
+			Name: "X-Tenant-ID",
+			In:   "header",
+		}
+		if v, ok := packed[key]; ok {
+			params.XTenantID = v.(OptTenantID)
+		}
+	}
+	{
+		key := middleware.ParameterKey{
+			Name: "id",
+			In:   "path",
+		}
+		params.ID = packed[key].(uuid.UUID)
+	}
+	return params
+}
+
+func decodeChangeUserPasswordParams(args [1]string, argsEscaped bool, r *http.Request) (params ChangeUserPasswordParams, _ error) {
+	h := uri.NewHeaderDecoder(r.Header)
+	// Decode header: X-Tenant-ID.
+	if err := func() error {
+		cfg := uri.HeaderParameterDecodingConfig{
+			Name:    "X-Tenant-ID",
+			Explode: false,
+		}
+		if err := h.HasParam(cfg); err == nil {
+			if err := h.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotXTenantIDVal TenantID
+				if err := func() error {
+					var paramsDotXTenantIDValVal string
+					if err := func() error {
+						val, err := d.DecodeValue()
+						if err != nil {
+							return err
+						}
+
+						c, err := conv.ToString(val)
+						if err != nil {
+							return err
+						}
+
+						paramsDotXTenantIDValVal = c
+						return nil
+					}(); err != nil {
+						return err
+					}
+					paramsDotXTenantIDVal = TenantID(paramsDotXTenantIDValVal)
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.XTenantID.SetTo(paramsDotXTenantIDVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+			if err := func() error {
+				if value, ok := params.XTenantID.Get(); ok {
+					if err := func() error {
+						if err := value.Validate(); err != nil {
+							return err
+						}
+						return nil
+					}(); err != nil {
+						return err
+					}
+				}
+				return nil
+			}(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "X-Tenant-ID",
+			In:   "header",
+			Err:  err,
+		}
+	}
+	// Decode path: id.
+	if err := func() error {
+		param := args[0]
+		if argsEscaped {
+			unescaped, err := url.PathUnescape(args[0])
+			if err != nil {
+				return errors.Wrap(err, "unescape path")
+			}
+			param = unescaped
+		}
+		if len(param) > 0 {
+			d := uri.NewPathDecoder(uri.PathDecoderConfig{
+				Param:   "id",
+				Value:   param,
+				Style:   uri.PathStyleSimple,
+				Explode: false,
+			})
+
+			if err := func() error {
+				val, err := d.DecodeValue()
+				if err != nil {
+					return err
+				}
+
+				c, err := conv.ToUUID(val)
+				if err != nil {
+					return err
+				}
+
+				params.ID = c
+				return nil
+			}(); err != nil {
+				return err
+			}
+		} else {
+			return validate.ErrFieldRequired
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "id",
+			In:   "path",
+			Err:  err,
+		}
+	}
+	return params, nil
+}
+
+// CreateGroupParams is parameters of createGroup operation.
+type CreateGroupParams struct {
+	// Tenant of the request. Principals bound to a tenant may omit it or must repeat their own tenant;
+	// other principals must set it when tenancy is enabled.
+	XTenantID OptTenantID `json:",omitempty,omitzero"`
+}
+
+func unpackCreateGroupParams(packed middleware.Parameters) (params CreateGroupParams) {
+	{
+		key := middleware.ParameterKey{
+			Name: "X-Tenant-ID",
+			In:   "header",
+		}
+		if v, ok := packed[key]; ok {
+			params.XTenantID = v.(OptTenantID)
+		}
+	}
+	return params
+}
+
+func decodeCreateGroupParams(args [0]string, argsEscaped bool, r *http.Request) (params CreateGroupParams, _ error) {
+	h := uri.NewHeaderDecoder(r.Header)
+	// Decode header: X-Tenant-ID.
+	if err := func() error {
+		cfg := uri.HeaderParameterDecodingConfig{
+			Name:    "X-Tenant-ID",
+			Explode: false,
+		}
+		if err := h.HasParam(cfg); err == nil {
+			if err := h.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotXTenantIDVal TenantID
+				if err := func() error {
+					var paramsDotXTenantIDValVal string
+					if err := func() error {
+						val, err := d.DecodeValue()
+						if err != nil {
+							return err
+						}
+
+						c, err := conv.ToString(val)
+						if err != nil {
+							return err
+						}
+
+						paramsDotXTenantIDValVal = c
+						return nil
+					}(); err != nil {
+						return err
+					}
+					paramsDotXTenantIDVal = TenantID(paramsDotXTenantIDValVal)
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.XTenantID.SetTo(paramsDotXTenantIDVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+			if err := func() error {
+				if value, ok := params.XTenantID.Get(); ok {
+					if err := func() error {
+						if err := value.Validate(); err != nil {
+							return err
+						}
+						return nil
+					}(); err != nil {
+						return err
+					}
+				}
+				return nil
+			}(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "X-Tenant-ID",
+			In:   "header",
+			Err:  err,
+		}
+	}
+	return params, nil
+}
+
+// CreateSessionParams is parameters of createSession operation.
+type CreateSessionParams struct {
+	// Tenant of the request. Principals bound to a tenant may omit it or must repeat their own tenant;
+	// other principals must set it when tenancy is enabled.
+	XTenantID OptTenantID `json:",omitempty,omitzero"`
+}
+
+func unpackCreateSessionParams(packed middleware.Parameters) (params CreateSessionParams) {
+	{
+		key := middleware.ParameterKey{
+			Name: "X-Tenant-ID",
+			In:   "header",
+		}
+		if v, ok := packed[key]; ok {
+			params.XTenantID = v.(OptTenantID)
+		}
+	}
+	return params
+}
+
+func decodeCreateSessionParams(args [0]string, argsEscaped bool, r *http.Request) (params CreateSessionParams, _ error) {
+	h := uri.NewHeaderDecoder(r.Header)
+	// Decode header: X-Tenant-ID.
+	if err := func() error {
+		cfg := uri.HeaderParameterDecodingConfig{
+			Name:    "X-Tenant-ID",
+			Explode: false,
+		}
+		if err := h.HasParam(cfg); err == nil {
+			if err := h.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotXTenantIDVal TenantID
+				if err := func() error {
+					var paramsDotXTenantIDValVal string
+					if err := func() error {
+						val, err := d.DecodeValue()
+						if err != nil {
+							return err
+						}
+
+						c, err := conv.ToString(val)
+						if err != nil {
+							return err
+						}
+
+						paramsDotXTenantIDValVal = c
+						return nil
+					}(); err != nil {
+						return err
+					}
+					paramsDotXTenantIDVal = TenantID(paramsDotXTenantIDValVal)
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.XTenantID.SetTo(paramsDotXTenantIDVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+			if err := func() error {
+				if value, ok := params.XTenantID.Get(); ok {
+					if err := func() error {
+						if err := value.Validate(); err != nil {
+							return err
+						}
+						return nil
+					}(); err != nil {
+						return err
+					}
+				}
+				return nil
+			}(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "X-Tenant-ID",
+			In:   "header",
+			Err:  err,
+		}
+	}
+	return params, nil
+}
+
+// CreateUserParams is parameters of createUser operation.
+type CreateUserParams struct {
+	// Tenant of the request. Principals bound to a tenant may omit it or must repeat their own tenant;
+	// other principals must set it when tenancy is enabled.
+	XTenantID OptTenantID `json:",omitempty,omitzero"`
+}
+
+func unpackCreateUserParams(packed middleware.Parameters) (params CreateUserParams) {
+	{
+		key := middleware.ParameterKey{
+			Name: "X-Tenant-ID",
+			In:   "header",
+		}
+		if v, ok := packed[key]; ok {
+			params.XTenantID = v.(OptTenantID)
+		}
+	}
+	return params
+}
+
+func decodeCreateUserParams(args [0]string, argsEscaped bool, r *http.Request) (params CreateUserParams, _ error) {
+	h := uri.NewHeaderDecoder(r.Header)
+	// Decode header: X-Tenant-ID.
+	if err := func() error {
+		cfg := uri.HeaderParameterDecodingConfig{
+			Name:    "X-Tenant-ID",
+			Explode: false,
+		}
+		if err := h.HasParam(cfg); err == nil {
+			if err := h.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotXTenantIDVal TenantID
+				if err := func() error {
+					var paramsDotXTenantIDValVal string
+					if err := func() error {
+						val, err := d.DecodeValue()
+						if err != nil {
+							return err
+						}
+
+						c, err := conv.ToString(val)
+						if err != nil {
+							return err
+						}
+
+						paramsDotXTenantIDValVal = c
+						return nil
+					}(); err != nil {
+						return err
+					}
+					paramsDotXTenantIDVal = TenantID(paramsDotXTenantIDValVal)
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.XTenantID.SetTo(paramsDotXTenantIDVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+			if err := func() error {
+				if value, ok := params.XTenantID.Get(); ok {
+					if err := func() error {
+						if err := value.Validate(); err != nil {
+							return err
+						}
+						return nil
+					}(); err != nil {
+						return err
+					}
+				}
+				return nil
+			}(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "X-Tenant-ID",
+			In:   "header",
+			Err:  err,
+		}
+	}
+	return params, nil
+}
+
+// DeleteGroupParams is parameters of deleteGroup operation.
+type DeleteGroupParams struct {
+	// Tenant of the request. Principals bound to a tenant may omit it or must repeat their own tenant;
+	// other principals must set it when tenancy is enabled.
+	XTenantID OptTenantID `json:",omitempty,omitzero"`
+	// Unique group identifier.
+	ID uuid.UUID
+}
+
+func unpackDeleteGroupParams(packed middleware.Parameters) (params DeleteGroupParams) {
+	{
+		key := middleware.ParameterKey{
+			Name: "X-Tenant-ID",
+			In:   "header",
+		}
+		if v, ok := packed[key]; ok {
+			params.XTenantID = v.(OptTenantID)
+		}
+	}
+	{
+		key := middleware.ParameterKey{
+			Name: "id",
+			In:   "path",
+		}
+		params.ID = packed[key].(uuid.UUID)
+	}
+	return params
+}
+
+func decodeDeleteGroupParams(args [1]string, argsEscaped bool, r *http.Request) (params DeleteGroupParams, _ error) {
+	h := uri.NewHeaderDecoder(r.Header)
+	// Decode header: X-Tenant-ID.
+	if err := func() error {
+		cfg := uri.HeaderParameterDecodingConfig{
+			Name:    "X-Tenant-ID",
+			Explode: false,
+		}
+		if err := h.HasParam(cfg); err == nil {
+			if err := h.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotXTenantIDVal TenantID
+				if err := func() error {
+					var paramsDotXTenantIDValVal string
+					if err := func() error {
+						val, err := d.DecodeValue()
+						if err != nil {
+							return err
+						}
+
+						c, err := conv.ToString(val)
+						if err != nil {
+							return err
+						}
+
+						paramsDotXTenantIDValVal = c
+						return nil
+					}(); err != nil {
+						return err
+					}
+					paramsDotXTenantIDVal = TenantID(paramsDotXTenantIDValVal)
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.XTenantID.SetTo(paramsDotXTenantIDVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+			if err := func() error {
+				if value, ok := params.XTenantID.Get(); ok {
+					if err := func() error {
+						if err := value.Validate(); err != nil {
+							return err
+						}
+						return nil
+					}(); err != nil {
+						return err
+					}
+				}
+				return nil
+			}(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "X-Tenant-ID",
+			In:   "header",
+			Err:  err,
+		}
+	}
+	// Decode path: id.
+	if err := func() error {
+		param := args[0]
+		if argsEscaped {
+			unescaped, err := url.PathUnescape(args[0])
+			if err != nil {
+				return errors.Wrap(err, "unescape path")
+			}
+			param = unescaped
+		}
+		if len(param) > 0 {
+			d := uri.NewPathDecoder(uri.PathDecoderConfig{
+				Param:   "id",
+				Value:   param,
+				Style:   uri.PathStyleSimple,
+				Explode: false,
+			})
+
+			if err := func() error {
+				val, err := d.DecodeValue()
+				if err != nil {
+					return err
+				}
+
+				c, err := conv.ToUUID(val)
+				if err != nil {
+					return err
+				}
+
+				params.ID = c
+				return nil
+			}(); err != nil {
+				return err
+			}
+		} else {
+			return validate.ErrFieldRequired
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "id",
+			In:   "path",
+			Err:  err,
+		}
+	}
+	return params, nil
+}
+
+// DeleteTenantParams is parameters of deleteTenant operation.
+type DeleteTenantParams struct {
+	// Tenant identifier.
+	ID TenantID
+}
+
+func unpackDeleteTenantParams(packed middleware.Parameters) (params DeleteTenantParams) {
+	{
+		key := middleware.ParameterKey{
+			Name: "id",
+			In:   "path",
+		}
+		params.ID = packed[key].(TenantID)
+	}
+	return params
+}
+
+func decodeDeleteTenantParams(args [1]string, argsEscaped bool, r *http.Request) (params DeleteTenantParams, _ error) {
+	// Decode path: id.
+	if err := func() error {
+		param := args[0]
+		if argsEscaped {
+			unescaped, err := url.PathUnescape(args[0])
+			if err != nil {
+				return errors.Wrap(err, "unescape path")
+			}
+			param = unescaped
+		}
+		if len(param) > 0 {
+			d := uri.NewPathDecoder(uri.PathDecoderConfig{
+				Param:   "id",
+				Value:   param,
+				Style:   uri.PathStyleSimple,
+				Explode: false,
+			})
+
+			if err := func() error {
+				var paramsDotIDVal string
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToString(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotIDVal = c
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.ID = TenantID(paramsDotIDVal)
+				return nil
+			}(); err != nil {
+				return err
+			}
+			if err := func() error {
+				if err := params.ID.Validate(); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return err
+			}
+		} else {
+			return validate.ErrFieldRequired
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "id",
+			In:   "path",
+			Err:  err,
+		}
+	}
+	return params, nil
+}
+
 // DeleteUserParams is parameters of deleteUser operation.
 type DeleteUserParams struct {
+	// Tenant of the request. Principals bound to a tenant may omit it or must repeat their own tenant;
+	// other principals must set it when tenancy is enabled.
+	XTenantID OptTenantID `json:",omitempty,omitzero"`
+	// Unique user identifier.
+	ID uuid.UUID
+}
+
+func unpackDeleteUserParams(packed middleware.Parameters) (params DeleteUserParams) {
+	{
+		key := middleware.ParameterKey{
+			Name: "X-Tenant-ID",
+			In:   "header",
+		}
+		if v, ok := packed[key]; ok {
+			params.XTenantID = v.(OptTenantID)
+		}
+	}
+	{
+		key := middleware.ParameterKey{
+			Name: "id",
+			In:   "path",
+		}
+		params.ID = packed[key].(uuid.UUID)
+	}
+	return params
+}
+
+func decodeDeleteUserParams(args [1]string, argsEscaped bool, r *http.Request) (params DeleteUserParams, _ error) {
+	h := uri.NewHeaderDecoder(r.Header)
+	// Decode header: X-Tenant-ID.
+	if err := func() error {
+		cfg := uri.HeaderParameterDecodingConfig{
+			Name:    "X-Tenant-ID",
+			Explode: false,
+		}
+		if err := h.HasParam(cfg); err == nil {
+			if err := h.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotXTenantIDVal TenantID
+				if err := func() error {
+					var paramsDotXTenantIDValVal string
+					if err := func() error {
+						val, err := d.DecodeValue()
+						if err != nil {
+							return err
+						}
+
+						c, err := conv.ToString(val)
+						if err != nil {
+							return err
+						}
+
+						paramsDotXTenantIDValVal = c
+						return nil
+					}(); err != nil {
+						return err
+					}
+					paramsDotXTenantIDVal = TenantID(paramsDotXTenantIDValVal)
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.XTenantID.SetTo(paramsDotXTenantIDVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+			if err := func() error {
+				if value, ok := params.XTenantID.Get(); ok {
+					if err := func() error {
+						if err := value.Validate(); err != nil {
+							return err
+						}
+						return nil
+					}(); err != nil {
+						return err
+					}
+				}
+				return nil
+			}(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "X-Tenant-ID",
+			In:   "header",
+			Err:  err,
+		}
+	}
+	// Decode path: id.
+	if err := func() error {
+		param := args[0]
+		if argsEscaped {
+			unescaped, err := url.PathUnescape(args[0])
+			if err != nil {
+				return errors.Wrap(err, "unescape path")
+			}
+			param = unescaped
+		}
+		if len(param) > 0 {
+			d := uri.NewPathDecoder(uri.PathDecoderConfig{
+				Param:   "id",
+				Value:   param,
+				Style:   uri.PathStyleSimple,
+				Explode: false,
+			})
+
+			if err := func() error {
+				val, err := d.DecodeValue()
+				if err != nil {
+					return err
+				}
+
+				c, err := conv.ToUUID(val)
+				if err != nil {
+					return err
+				}
+
+				params.ID = c
+				return nil
+			}(); err != nil {
+				return err
+			}
+		} else {
+			return validate.ErrFieldRequired
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "id",
+			In:   "path",
+			Err:  err,
+		}
+	}
+	return params, nil
+}
+
+// GetAttributeSchemaParams is parameters of getAttributeSchema operation.
+type GetAttributeSchemaParams struct {
+	// Tenant of the request. Principals bound to a tenant may omit it or must repeat their own tenant;
+	// other principals must set it when tenancy is enabled.
+	XTenantID OptTenantID `json:",omitempty,omitzero"`
+}
+
+func unpackGetAttributeSchemaParams(packed middleware.Parameters) (params GetAttributeSchemaParams) {
+	{
+		key := middleware.ParameterKey{
+			Name: "X-Tenant-ID",
+			In:   "header",
+		}
+		if v, ok := packed[key]; ok {
+			params.XTenantID = v.(OptTenantID)
+		}
+	}
+	return params
+}
+
+func decodeGetAttributeSchemaParams(args [0]string, argsEscaped bool, r *http.Request) (params GetAttributeSchemaParams, _ error) {
+	h := uri.NewHeaderDecoder(r.Header)
+	// Decode header: X-Tenant-ID.
+	if err := func() error {
+		cfg := uri.HeaderParameterDecodingConfig{
+			Name:    "X-Tenant-ID",
+			Explode: false,
+		}
+		if err := h.HasParam(cfg); err == nil {
+			if err := h.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotXTenantIDVal TenantID
+				if err := func() error {
+					var paramsDotXTenantIDValVal string
+					if err := func() error {
+						val, err := d.DecodeValue()
+						if err != nil {
+							return err
+						}
+
+						c, err := conv.ToString(val)
+						if err != nil {
+							return err
+						}
+
+						paramsDotXTenantIDValVal = c
+						return nil
+					}(); err != nil {
+						return err
+					}
+					paramsDotXTenantIDVal = TenantID(paramsDotXTenantIDValVal)
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.XTenantID.SetTo(paramsDotXTenantIDVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+			if err := func() error {
+				if value, ok := params.XTenantID.Get(); ok {
+					if err := func() error {
+						if err := value.Validate(); err != nil {
+							return err
+						}
+						return nil
+					}(); err != nil {
+						return err
+					}
+				}
+				return nil
+			}(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "X-Tenant-ID",
+			In:   "header",
+			Err:  err,
+		}
+	}
+	return params, nil
+}
+
+// GetGroupParams is parameters of getGroup operation.
+type GetGroupParams struct {
+	// Tenant of the request. Principals bound to a tenant may omit it or must repeat their own tenant;
+	// other principals must set it when tenancy is enabled.
+	XTenantID OptTenantID `json:",omitempty,omitzero"`
+	// Unique group identifier.
+	ID uuid.UUID
+}
+
+func unpackGetGroupParams(packed middleware.Parameters) (params GetGroupParams) {
+	{
+		key := middleware.ParameterKey{
+			Name: "X-Tenant-ID",
+			In:   "header",
+		}
+		if v, ok := packed[key]; ok {
+			params.XTenantID = v.(OptTenantID)
+		}
+	}
+	{
+		key := middleware.ParameterKey{
+			Name: "id",
+			In:   "path",
+		}
+		params.ID = packed[key].(uuid.UUID)
+	}
+	return params
+}
+
+func decodeGetGroupParams(args [1]string, argsEscaped bool, r *http.Request) (params GetGroupParams, _ error) {
+	h := uri.NewHeaderDecoder(r.Header)
+	// Decode header: X-Tenant-ID.
+	if err := func() error {
+		cfg := uri.HeaderParameterDecodingConfig{
+			Name:    "X-Tenant-ID",
+			Explode: false,
+		}
+		if err := h.HasParam(cfg); err == nil {
+			if err := h.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotXTenantIDVal TenantID
+				if err := func() error {
+					var paramsDotXTenantIDValVal string
+					if err := func() error {
+						val, err := d.DecodeValue()
+						if err != nil {
+							return err
+						}
+
+						c, err := conv.ToString(val)
+						if err != nil {
+							return err
+						}
+
+						paramsDotXTenantIDValVal = c
+						return nil
+					}(); err != nil {
+						return err
+					}
+					paramsDotXTenantIDVal = TenantID(paramsDotXTenantIDValVal)
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.XTenantID.SetTo(paramsDotXTenantIDVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+			if err := func() error {
+				if value, ok := params.XTenantID.Get(); ok {
+					if err := func() error {
+						if err := value.Validate(); err != nil {
+							return err
+						}
+						return nil
+					}(); err != nil {
+						return err
+					}
+				}
+				return nil
+			}(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "X-Tenant-ID",
+			In:   "header",
+			Err:  err,
+		}
+	}
+	// Decode path: id.
+	if err := func() error {
+		param := args[0]
+		if argsEscaped {
+			unescaped, err := url.PathUnescape(args[0])
+			if err != nil {
+				return errors.Wrap(err, "unescape path")
+			}
+			param = unescaped
+		}
+		if len(param) > 0 {
+			d := uri.NewPathDecoder(uri.PathDecoderConfig{
+				Param:   "id",
+				Value:   param,
+				Style:   uri.PathStyleSimple,
+				Explode: false,
+			})
+
+			if err := func() error {
+				val, err := d.DecodeValue()
+				if err != nil {
+					return err
+				}
+
+				c, err := conv.ToUUID(val)
+				if err != nil {
+					return err
+				}
+
+				params.ID = c
+				return nil
+			}(); err != nil {
+				return err
+			}
+		} else {
+			return validate.ErrFieldRequired
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "id",
+			In:   "path",
+			Err:  err,
+		}
+	}
+	return params, nil
+}
+
+// GetTenantParams is parameters of getTenant operation.
+type GetTenantParams struct {
+	// Tenant identifier.
+	ID TenantID
+}
+
+func unpackGetTenantParams(packed middleware.Parameters) (params GetTenantParams) {
+	{
+		key := middleware.ParameterKey{
+			Name: "id",
+			In:   "path",
+		}
+		params.ID = packed[key].(TenantID)
+	}
+	return params
+}
+
+func decodeGetTenantParams(args [1]string, argsEscaped bool, r *http.Request) (params GetTenantParams, _ error) {
+	// Decode path: id.
+	if err := func() error {
+		param := args[0]
+		if argsEscaped {
+			unescaped, err := url.PathUnescape(args[0])
+			if err != nil {
+				return errors.Wrap(err, "unescape path")
+			}
+			param = unescaped
+		}
+		if len(param) > 0 {
+			d := uri.NewPathDecoder(uri.PathDecoderConfig{
+				Param:   "id",
+				Value:   param,
+				Style:   uri.PathStyleSimple,
+				Explode: false,
+			})
+
+			if err := func() error {
+				var paramsDotIDVal string
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToString(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotIDVal = c
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.ID = TenantID(paramsDotIDVal)
+				return nil
+			}(); err != nil {
+				return err
+			}
+			if err := func() error {
+				if err := params.ID.Validate(); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return err
+			}
+		} else {
+			return validate.ErrFieldRequired
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "id",
+			In:   "path",
+			Err:  err,
+		}
+	}
+	return params, nil
+}
+
+// GetUserParams is parameters of getUser operation.
+type GetUserParams struct {
+	// Comma-separated User properties to return, such as id,username. Every property is returned when
+	// omitted.
+	Fields []UserField `json:",omitempty"`
+	// Tenant of the request. Principals bound to a tenant may omit it or must repeat their own tenant;
+	// other principals must set it when tenancy is enabled.
+	XTenantID OptTenantID `json:",omitempty,omitzero"`
+	// Unique user identifier.
+	ID uuid.UUID
+}
+
+func unpackGetUserParams(packed middleware.Parameters) (params GetUserParams) {
+	{
+		key := middleware.ParameterKey{
+			Name: "fields",
+			In:   "query",
+		}
+		if v, ok := packed[key]; ok {
+			params.Fields = v.([]UserField)
+		}
+	}
+	{
+		key := middleware.ParameterKey{
+			Name: "X-Tenant-ID",
+			In:   "header",
+		}
+		if v, ok := packed[key]; ok {
+			params.XTenantID = v.(OptTenantID)
+		}
+	}
+	{
+		key := middleware.ParameterKey{
+			Name: "id",
+			In:   "path",
+		}
+		params.ID = packed[key].(uuid.UUID)
+	}
+	return params
+}
+
+func decodeGetUserParams(args [1]string, argsEscaped bool, r *http.Request) (params GetUserParams, _ error) {
+	q := uri.NewQueryDecoder(r.URL.Query())
+	h := uri.NewHeaderDecoder(r.Header)
+	// Decode query: fields.
+	if err := func() error {
+		cfg := uri.QueryParameterDecodingConfig{
+			Name:    "fields",
+			Style:   uri.QueryStyleForm,
+			Explode: false,
+		}
+
+		if err := q.HasParam(cfg); err == nil {
+			if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+				return d.DecodeArray(func(d uri.Decoder) error {
+					var paramsDotFieldsVal UserField
+					if err := func() error {
+						val, err := d.DecodeValue()
+						if err != nil {
+							return err
+						}
+
+						c, err := conv.ToString(val)
+						if err != nil {
+							return err
+						}
+
+						paramsDotFieldsVal = UserField(c)
+						return nil
+					}(); err != nil {
+						return err
+					}
+					params.Fields = append(params.Fields, paramsDotFieldsVal)
+					return nil
+				})
+			}); err != nil {
+				return err
+			}
+			if err := func() error {
+				if params.Fields == nil {
+					return nil // optional
+				}
+				if err := (validate.Array{
+					MinLength:    1,
+					MinLengthSet: true,
+					MaxLength:    0,
+					MaxLengthSet: false,
+				}).ValidateLength(len(params.Fields)); err != nil {
+					return errors.Wrap(err, "array")
+				}
+				var failures []validate.FieldError
+				for i, elem := range params.Fields {
+					if err := func() error {
+						if err := elem.Validate(); err != nil {
+							return err
+						}
+						return nil
+					}(); err != nil {
+						failures = append(failures, validate.FieldError{
+							Name:  fmt.Sprintf("[%d]", i),
+							Error: err,
+						})
+					}
+				}
+				if len(failures) > 0 {
+					return &validate.Error{Fields: failures}
+				}
+				return nil
+			}(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "fields",
+			In:   "query",
+			Err:  err,
+		}
+	}
+	// Decode header: X-Tenant-ID.
+	if err := func() error {
+		cfg := uri.HeaderParameterDecodingConfig{
+			Name:    "X-Tenant-ID",
+			Explode: false,
+		}
+		if err := h.HasParam(cfg); err == nil {
+			if err := h.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotXTenantIDVal TenantID
+				if err := func() error {
+					var paramsDotXTenantIDValVal string
+					if err := func() error {
+						val, err := d.DecodeValue()
+						if err != nil {
+							return err
+						}
+
+						c, err := conv.ToString(val)
+						if err != nil {
+							return err
+						}
+
+						paramsDotXTenantIDValVal = c
+						return nil
+					}(); err != nil {
+						return err
+					}
+					paramsDotXTenantIDVal = TenantID(paramsDotXTenantIDValVal)
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.XTenantID.SetTo(paramsDotXTenantIDVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+			if err := func() error {
+				if value, ok := params.XTenantID.Get(); ok {
+					if err := func() error {
+						if err := value.Validate(); err != nil {
+							return err
+						}
+						return nil
+					}(); err != nil {
+						return err
+					}
+				}
+				return nil
+			}(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "X-Tenant-ID",
+			In:   "header",
+			Err:  err,
+		}
+	}
+	// Decode path: id.
+	if err := func() error {
+		param := args[0]
+		if argsEscaped {
+			unescaped, err := url.PathUnescape(args[0])
+			if err != nil {
+				return errors.Wrap(err, "unescape path")
+			}
+			param = unescaped
+		}
+		if len(param) > 0 {
+			d := uri.NewPathDecoder(uri.PathDecoderConfig{
+				Param:   "id",
+				Value:   param,
+				Style:   uri.PathStyleSimple,
+				Explode: false,
+			})
+
+			if err := func() error {
+				val, err := d.DecodeValue()
+				if err != nil {
+					return err
+				}
+
+				c, err := conv.ToUUID(val)
+				if err != nil {
+					return err
+				}
+
+				params.ID = c
+				return nil
+			}(); err != nil {
+				return err
+			}
+		} else {
+			return validate.ErrFieldRequired
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "id",
+			In:   "path",
+			Err:  err,
+		}
+	}
+	return params, nil
+}
+
+// ListGroupMembersParams is parameters of listGroupMembers operation.
+type ListGroupMembersParams struct {
+	// Tenant of the request. Principals bound to a tenant may omit it or must repeat their own tenant;
+	// other principals must set it when tenancy is enabled.
+	XTenantID OptTenantID `json:",omitempty,omitzero"`
+	// Unique group identifier.
+	ID uuid.UUID
+}
+
+func unpackListGroupMembersParams(packed middleware.Parameters) (params ListGroupMembersParams) {
+	{
+		key := middleware.ParameterKey{
+			Name: "X-Tenant-ID",
+			In:   "header",
+		}
+		if v, ok := packed[key]; ok {
+			params.XTenantID = v.(OptTenantID)
+		}
+	}
+	{
+		key := middleware.ParameterKey{
+			Name: "id",
+			In:   "path",
+		}
+		params.ID = packed[key].(uuid.UUID)
+	}
+	return params
+}
+
+func decodeListGroupMembersParams(args [1]string, argsEscaped bool, r *http.Request) (params ListGroupMembersParams, _ error) {
+	h := uri.NewHeaderDecoder(r.Header)
+	// Decode header: X-Tenant-ID.
+	if err := func() error {
+		cfg := uri.HeaderParameterDecodingConfig{
+			Name:    "X-Tenant-ID",
+			Explode: false,
+		}
+		if err := h.HasParam(cfg); err == nil {
+			if err := h.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotXTenantIDVal TenantID
+				if err := func() error {
+					var paramsDotXTenantIDValVal string
+					if err := func() error {
+						val, err := d.DecodeValue()
+						if err != nil {
+							return err
+						}
+
+						c, err := conv.ToString(val)
+						if err != nil {
+							return err
+						}
+
+						paramsDotXTenantIDValVal = c
+						return nil
+					}(); err != nil {
+						return err
+					}
+					paramsDotXTenantIDVal = TenantID(paramsDotXTenantIDValVal)
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.XTenantID.SetTo(paramsDotXTenantIDVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+			if err := func() error {
+				if value, ok := params.XTenantID.Get(); ok {
+					if err := func() error {
+						if err := value.Validate(); err != nil {
+							return err
+						}
+						return nil
+					}(); err != nil {
+						return err
+					}
+				}
+				return nil
+			}(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "X-Tenant-ID",
+			In:   "header",
+			Err:  err,
+		}
+	}
+	// Decode path: id.
+	if err := func() error {
+		param := args[0]
+		if argsEscaped {
+			unescaped, err := url.PathUnescape(args[0])
+			if err != nil {
+				return errors.Wrap(err, "unescape path")
+			}
+			param = unescaped
+		}
+		if len(param) > 0 {
+			d := uri.NewPathDecoder(uri.PathDecoderConfig{
+				Param:   "id",
+				Value:   param,
+				Style:   uri.PathStyleSimple,
+				Explode: false,
+			})
+
+			if err := func() error {
+				val, err := d.DecodeValue()
+				if err != nil {
+					return err
+				}
+
+				c, err := conv.ToUUID(val)
+				if err != nil {
+					return err
+				}
+
+				params.ID = c
+				return nil
+			}(); err != nil {
+				return err
+			}
+		} else {
+			return validate.ErrFieldRequired
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "id",
+			In:   "path",
+			Err:  err,
+		}
+	}
+	return params, nil
+}
+
+// ListGroupsParams is parameters of listGroups operation.
+type ListGroupsParams struct {
+	// Tenant of the request. Principals bound to a tenant may omit it or must repeat their own tenant;
+	// other principals must set it when tenancy is enabled.
+	XTenantID OptTenantID `json:",omitempty,omitzero"`
+}
+
+func unpackListGroupsParams(packed middleware.Parameters) (params ListGroupsParams) {
+	{
+		key := middleware.ParameterKey{
+			Name: "X-Tenant-ID",
+			In:   "header",
+		}
+		if v, ok := packed[key]; ok {
+			params.XTenantID = v.(OptTenantID)
+		}
+	}
+	return params
+}
+
+func decodeListGroupsParams(args [0]string, argsEscaped bool, r *http.Request) (params ListGroupsParams, _ error) {
+	h := uri.NewHeaderDecoder(r.Header)
+	// Decode header: X-Tenant-ID.
+	if err := func() error {
+		cfg := uri.HeaderParameterDecodingConfig{
+			Name:    "X-Tenant-ID",
+			Explode: false,
+		}
+		if err := h.HasParam(cfg); err == nil {
+			if err := h.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotXTenantIDVal TenantID
+				if err := func() error {
+					var paramsDotXTenantIDValVal string
+					if err := func() error {
+						val, err := d.DecodeValue()
+						if err != nil {
+							return err
+						}
+
+						c, err := conv.ToString(val)
+						if err != nil {
+							return err
+						}
+
+						paramsDotXTenantIDValVal = c
+						return nil
+					}(); err != nil {
+						return err
+					}
+					paramsDotXTenantIDVal = TenantID(paramsDotXTenantIDValVal)
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.XTenantID.SetTo(paramsDotXTenantIDVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+			if err := func() error {
+				if value, ok := params.XTenantID.Get(); ok {
+					if err := func() error {
+						if err := value.Validate(); err != nil {
+							return err
+						}
+						return nil
+					}(); err != nil {
+						return err
+					}
+				}
+				return nil
+			}(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "X-Tenant-ID",
+			In:   "header",
+			Err:  err,
+		}
+	}
+	return params, nil
+}
+
+// ListUserGroupsParams is parameters of listUserGroups operation.
+type ListUserGroupsParams struct {
+	// Tenant of the request. Principals bound to a tenant may omit it or must repeat their own tenant;
+	// other principals must set it when tenancy is enabled.
+	XTenantID OptTenantID `json:",omitempty,omitzero"`
+	// Unique user identifier.
+	ID uuid.UUID
+}
+
+func unpackListUserGroupsParams(packed middleware.Parameters) (params ListUserGroupsParams) {
+	{
+		key := middleware.ParameterKey{
+			Name: "X-Tenant-ID",
+			In:   "header",
+		}
+		if v, ok := packed[key]; ok {
+			params.XTenantID = v.(OptTenantID)
+		}
+	}
+	{
+		key := middleware.ParameterKey{
+			Name: "id",
+			In:   "path",
+		}
+		params.ID = packed[key].(uuid.UUID)
+	}
+	return params
+}
+
+func decodeListUserGroupsParams(args [1]string, argsEscaped bool, r *http.Request) (params ListUserGroupsParams, _ error) {
+	h := uri.NewHeaderDecoder(r.Header)
+	// Decode header: X-Tenant-ID.
+	if err := func() error {
+		cfg := uri.HeaderParameterDecodingConfig{
+			Name:    "X-Tenant-ID",
+			Explode: false,
+		}
+		if err := h.HasParam(cfg); err == nil {
+			if err := h.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotXTenantIDVal TenantID
+				if err := func() error {
+					var paramsDotXTenantIDValVal string
+					if err := func() error {
+						val, err := d.DecodeValue()
+						if err != nil {
+							return err
+						}
+
+						c, err := conv.ToString(val)
+						if err != nil {
+							return err
+						}
+
+						paramsDotXTenantIDValVal = c
+						return nil
+					}(); err != nil {
+						return err
+					}
+					paramsDotXTenantIDVal = TenantID(paramsDotXTenantIDValVal)
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.XTenantID.SetTo(paramsDotXTenantIDVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+			if err := func() error {
+				if value, ok := params.XTenantID.Get(); ok {
+					if err := func() error {
+						if err := value.Validate(); err != nil {
+							return err
+						}
+						return nil
+					}(); err != nil {
+						return err
+					}
+				}
+				return nil
+			}(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "X-Tenant-ID",
+			In:   "header",
+			Err:  err,
+		}
+	}
+	// Decode path: id.
+	if err := func() error {
+		param := args[0]
+		if argsEscaped {
+			unescaped, err := url.PathUnescape(args[0])
+			if err != nil {
+				return errors.Wrap(err, "unescape path")
+			}
+			param = unescaped
+		}
+		if len(param) > 0 {
+			d := uri.NewPathDecoder(uri.PathDecoderConfig{
+				Param:   "id",
+				Value:   param,
+				Style:   uri.PathStyleSimple,
+				Explode: false,
+			})
+
+			if err := func() error {
+				val, err := d.DecodeValue()
+				if err != nil {
+					return err
+				}
+
+				c, err := conv.ToUUID(val)
+				if err != nil {
+					return err
+				}
+
+				params.ID = c
+				return nil
+			}(); err != nil {
+				return err
+			}
+		} else {
+			return validate.ErrFieldRequired
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "id",
+			In:   "path",
+			Err:  err,
+		}
+	}
+	return params, nil
+}
+
+// ListUserSessionsParams is parameters of listUserSessions operation.
+type ListUserSessionsParams struct {
+	// Tenant of the request. Principals bound to a tenant may omit it or must repeat their own tenant;
+	// other principals must set it when tenancy is enabled.
+	XTenantID OptTenantID `json:",omitempty,omitzero"`
+	// Unique user identifier.
+	ID uuid.UUID
+}
+
+func unpackListUserSessionsParams(packed middleware.Parameters) (params ListUserSessionsParams) {
+	{
+		key := middleware.ParameterKey{
+			Name: "X-Tenant-ID",
+			In:   "header",
+		}
+		if v, ok := packed[key]; ok {
+			params.XTenantID = v.(OptTenantID)
+		}
+	}
+	{
+		key := middleware.ParameterKey{
+			Name: "id",
+			In:   "path",
+		}
+		params.ID = packed[key].(uuid.UUID)
+	}
+	return params
+}
+
+func decodeListUserSessionsParams(args [1]string, argsEscaped bool, r *http.Request) (params ListUserSessionsParams, _ error) {
+	h := uri.NewHeaderDecoder(r.Header)
+	// Decode header: X-Tenant-ID.
+	if err := func() error {
+		cfg := uri.HeaderParameterDecodingConfig{
+			Name:    "X-Tenant-ID",
+			Explode: false,
+		}
+		if err := h.HasParam(cfg); err == nil {
+			if err := h.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotXTenantIDVal TenantID
+				if err := func() error {
+					var paramsDotXTenantIDValVal string
+					if err := func() error {
+						val, err := d.DecodeValue()
+						if err != nil {
+							return err
+						}
+
+						c, err := conv.ToString(val)
+						if err != nil {
+							return err
+						}
+
+						paramsDotXTenantIDValVal = c
+						return nil
+					}(); err != nil {
+						return err
+					}
+					paramsDotXTenantIDVal = TenantID(paramsDotXTenantIDValVal)
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.XTenantID.SetTo(paramsDotXTenantIDVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+			if err := func() error {
+				if value, ok := params.XTenantID.Get(); ok {
+					if err := func() error {
+						if err := value.Validate(); err != nil {
+							return err
+						}
+						return nil
+					}(); err != nil {
+						return err
+					}
+				}
+				return nil
+			}(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "X-Tenant-ID",
+			In:   "header",
+			Err:  err,
+		}
+	}
+	// Decode path: id.
+	if err := func() error {
+		param := args[0]
+		if argsEscaped {
+			unescaped, err := url.PathUnescape(args[0])
+			if err != nil {
+				return errors.Wrap(err, "unescape path")
+			}
+			param = unescaped
+		}
+		if len(param) > 0 {
+			d := uri.NewPathDecoder(uri.PathDecoderConfig{
+				Param:   "id",
+				Value:   param,
+				Style:   uri.PathStyleSimple,
+				Explode: false,
+			})
+
+			if err := func() error {
+				val, err := d.DecodeValue()
+				if err != nil {
+					return err
+				}
+
+				c, err := conv.ToUUID(val)
+				if err != nil {
+					return err
+				}
+
+				params.ID = c
+				return nil
+			}(); err != nil {
+				return err
+			}
+		} else {
+			return validate.ErrFieldRequired
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "id",
+			In:   "path",
+			Err:  err,
+		}
+	}
+	return params, nil
+}
+
+// ListUsersParams is parameters of listUsers operation.
+type ListUsersParams struct {
+	// Comma-separated User properties to return, such as id,username. Every property is returned when
+	// omitted.
+	Fields []UserField `json:",omitempty"`
+	// Attribute filter of the form name:value, such as cost_center:CC-42. The value is read as the type
+	// the attribute schema defines. Repeat the parameter to require several matches.
+	Attribute []string `json:",omitempty"`
+	// Tenant of the request. Principals bound to a tenant may omit it or must repeat their own tenant;
+	// other principals must set it when tenancy is enabled.
+	XTenantID OptTenantID `json:",omitempty,omitzero"`
+}
+
+func unpackListUsersParams(packed middleware.Parameters) (params ListUsersParams) {
+	{
+		key := middleware.ParameterKey{
+			Name: "fields",
+			In:   "query",
+		}
+		if v, ok := packed[key]; ok {
+			params.Fields = v.([]UserField)
+		}
+	}
+	{
+		key := middleware.ParameterKey{
+			Name: "attribute",
+			In:   "query",
+		}
+		if v, ok := packed[key]; ok {
+			params.Attribute = v.([]string)
+		}
+	}
+	{
+		key := middleware.ParameterKey{
+			Name: "X-Tenant-ID",
+			In:   "header",
+		}
+		if v, ok := packed[key]; ok {
+			params.XTenantID = v.(OptTenantID)
+		}
+	}
+	return params
+}
+
+func decodeListUsersParams(args [0]string, argsEscaped bool, r *http.Request) (params ListUsersParams, _ error) {
+	q := uri.NewQueryDecoder(r.URL.Query())
+	h := uri.NewHeaderDecoder(r.Header)
+	// Decode query: fields.
+	if err := func() error {
+		cfg := uri.QueryParameterDecodingConfig{
+			Name:    "fields",
+			Style:   uri.QueryStyleForm,
+			Explode: false,
+		}
+
+		if err := q.HasParam(cfg); err == nil {
+			if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+				return d.DecodeArray(func(d uri.Decoder) error {
+					var paramsDotFieldsVal UserField
+					if err := func() error {
+						val, err := d.DecodeValue()
+						if err != nil {
+							return err
+						}
+
+						c, err := conv.ToString(val)
+						if err != nil {
+							return err
+						}
+
+						paramsDotFieldsVal = UserField(c)
+						return nil
+					}(); err != nil {
+						return err
+					}
+					params.Fields = append(params.Fields, paramsDotFieldsVal)
+					return nil
+				})
+			}); err != nil {
+				return err
+			}
+			if err := func() error {
+				if params.Fields == nil {
+					return nil // optional
+				}
+				if err := (validate.Array{
+					MinLength:    1,
+					MinLengthSet: true,
+					MaxLength:    0,
+					MaxLengthSet: false,
+				}).ValidateLength(len(params.Fields)); err != nil {
+					return errors.Wrap(err, "array")
+				}
+				var failures []validate.FieldError
+				for i, elem := range params.Fields {
+					if err := func() error {
+						if err := elem.Validate(); err != nil {
+							return err
+						}
+						return nil
+					}(); err != nil {
+						failures = append(failures, validate.FieldError{
+							Name:  fmt.Sprintf("[%d]", i),
+							Error: err,
+						})
+					}
+				}
+				if len(failures) > 0 {
+					return &validate.Error{Fields: failures}
+				}
+				return nil
+			}(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "fields",
+			In:   "query",
+			Err:  err,
+		}
+	}
+	// Decode query: attribute.
+	if err := func() error {
+		cfg := uri.QueryParameterDecodingConfig{
+			Name:    "attribute",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.HasParam(cfg); err == nil {
+			if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+				return d.DecodeArray(func(d uri.Decoder) error {
+					var paramsDotAttributeVal string
+					if err := func() error {
+						val, err := d.DecodeValue()
+						if err != nil {
+							return err
+						}
+
+						c, err := conv.ToString(val)
+						if err != nil {
+							return err
+						}
+
+						paramsDotAttributeVal = c
+						return nil
+					}(); err != nil {
+						return err
+					}
+					params.Attribute = append(params.Attribute, paramsDotAttributeVal)
+					return nil
+				})
+			}); err != nil {
+				return err
+			}
+			if err := func() error {
+				var failures []validate.FieldError
+				for i, elem := range params.Attribute {
+					if err := func() error {
+						if err := (validate.String{
+							MinLength:    0,
+							MinLengthSet: false,
+							MaxLength:    0,
+							MaxLengthSet: false,
+							Email:        false,
+							Hostname:     false,
+							Regex:        regexMap["^[a-z][a-z0-9_]{0,62}:"],
+						}).Validate(string(elem)); err != nil {
+							return errors.Wrap(err, "string")
+						}
+						return nil
+					}(); err != nil {
+						failures = append(failures, validate.FieldError{
+							Name:  fmt.Sprintf("[%d]", i),
+							Error: err,
+						})
+					}
+				}
+				if len(failures) > 0 {
+					return &validate.Error{Fields: failures}
+				}
+				return nil
+			}(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "attribute",
+			In:   "query",
+			Err:  err,
+		}
+	}
+	// Decode header: X-Tenant-ID.
+	if err := func() error {
+		cfg := uri.HeaderParameterDecodingConfig{
+			Name:    "X-Tenant-ID",
+			Explode: false,
+		}
+		if err := h.HasParam(cfg); err == nil {
+			if err := h.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotXTenantIDVal TenantID
+				if err := func() error {
+					var paramsDotXTenantIDValVal string
+					if err := func() error {
+						val, err := d.DecodeValue()
+						if err != nil {
+							return err
+						}
+
+						c, err := conv.ToString(val)
+						if err != nil {
+							return err
+						}
+
+						paramsDotXTenantIDValVal = c
+						return nil
+					}(); err != nil {
+						return err
+					}
+					paramsDotXTenantIDVal = TenantID(paramsDotXTenantIDValVal)
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.XTenantID.SetTo(paramsDotXTenantIDVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+			if err := func() error {
+				if value, ok := params.XTenantID.Get(); ok {
+					if err := func() error {
+						if err := value.Validate(); err != nil {
+							return err
+						}
+						return nil
+					}(); err != nil {
+						return err
+					}
+				}
+				return nil
+			}(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "X-Tenant-ID",
+			In:   "header",
+			Err:  err,
+		}
+	}
+	return params, nil
+}
+
+// PutAttributeSchemaParams is parameters of putAttributeSchema operation.
+type PutAttributeSchemaParams struct {
+	// Tenant of the request. Principals bound to a tenant may omit it or must repeat their own tenant;
+	// other principals must set it when tenancy is enabled.
+	XTenantID OptTenantID `json:",omitempty,omitzero"`
+}
+
+func unpackPutAttributeSchemaParams(packed middleware.Parameters) (params PutAttributeSchemaParams) {
+	{
+		key := middleware.ParameterKey{
+			Name: "X-Tenant-ID",
+			In:   "header",
+		}
+		if v, ok := packed[key]; ok {
+			params.XTenantID = v.(OptTenantID)
+		}
+	}
+	return params
+}
+
+func decodePutAttributeSchemaParams(args [0]string, argsEscaped bool, r *http.Request) (params PutAttributeSchemaParams, _ error) {
+	h := uri.NewHeaderDecoder(r.Header)
+	// Decode header: X-Tenant-ID.
+	if err := func() error {
+		cfg := uri.HeaderParameterDecodingConfig{
+			Name:    "X-Tenant-ID",
+			Explode: false,
+		}
+		if err := h.HasParam(cfg); err == nil {
+			if err := h.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotXTenantIDVal TenantID
+				if err := func() error {
+					var paramsDotXTenantIDValVal string
+					if err := func() error {
+						val, err := d.DecodeValue()
+						if err != nil {
+							return err
+						}
+
+						c, err := conv.ToString(val)
+						if err != nil {
+							return err
+						}
+
+						paramsDotXTenantIDValVal = c
+						return nil
+					}(); err != nil {
+						return err
+					}
+					paramsDotXTenantIDVal = TenantID(paramsDotXTenantIDValVal)
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.XTenantID.SetTo(paramsDotXTenantIDVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+			if err := func() error {
+				if value, ok := params.XTenantID.Get(); ok {
+					if err := func() error {
+						if err := value.Validate(); err != nil {
+							return err
+						}
+						return nil
+					}(); err != nil {
+						return err
+					}
+				}
+				return nil
+			}(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "X-Tenant-ID",
+			In:   "header",
+			Err:  err,
+		}
+	}
+	return params, nil
+}
+
+// RemoveGroupMemberParams is parameters of removeGroupMember operation.
+type RemoveGroupMemberParams struct {
+	// Tenant of the request. Principals bound to a tenant may omit it or must repeat their own tenant;
+	// other principals must set it when tenancy is enabled.
+	XTenantID OptTenantID `json:",omitempty,omitzero"`
+	// Unique group identifier.
+	ID uuid.UUID
+	// Unique user identifier.
+	UserId uuid.UUID
+}
+
+func unpackRemoveGroupMemberParams(packed middleware.Parameters) (params RemoveGroupMemberParams) {
+	{
+		key := middleware.ParameterKey{
+			Name: "X-Tenant-ID",
+			In:   "header",
+		}
+		if v, ok := packed[key]; ok {
+			params.XTenantID = v.(OptTenantID)
+		}
+	}
+	{
+		key := middleware.ParameterKey{
+			Name: "id",
+			In:   "path",
+		}
+		params.ID = packed[key].(uuid.UUID)
+	}
+	{
+		key := middleware.ParameterKey{
+			Name: "userId",
+			In:   "path",
+		}
+		params.UserId = packed[key].(uuid.UUID)
+	}
+	return params
+}
+
+func decodeRemoveGroupMemberParams(args [2]string, argsEscaped bool, r *http.Request) (params RemoveGroupMemberParams, _ error) {
+	h := uri.NewHeaderDecoder(r.Header)
+	// Decode header: X-Tenant-ID.
+	if err := func() error {
+		cfg := uri.HeaderParameterDecodingConfig{
+			Name:    "X-Tenant-ID",
+			Explode: false,
+		}
+		if err := h.HasParam(cfg); err == nil {
+			if err := h.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotXTenantIDVal TenantID
+				if err := func() error {
+					var paramsDotXTenantIDValVal string
+					if err := func() error {
+						val, err := d.DecodeValue()
+						if err != nil {
+							return err
+						}
+
+						c, err := conv.ToString(val)
+						if err != nil {
+							return err
+						}
+
+						paramsDotXTenantIDValVal = c
+						return nil
+					}(); err != nil {
+						return err
+					}
+					paramsDotXTenantIDVal = TenantID(paramsDotXTenantIDValVal)
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.XTenantID.SetTo(paramsDotXTenantIDVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+			if err := func() error {
+				if value, ok := params.XTenantID.Get(); ok {
+					if err := func() error {
+						if err := value.Validate(); err != nil {
+							return err
+						}
+						return nil
+					}(); err != nil {
+						return err
+					}
+				}
+				return nil
+			}(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "X-Tenant-ID",
+			In:   "header",
+			Err:  err,
+		}
+	}
+	// Decode path: id.
+	if err := func() error {
+		param := args[0]
+		if argsEscaped {
+			unescaped, err := url.PathUnescape(args[0])
+			if err != nil {
+				return errors.Wrap(err, "unescape path")
+			}
+			param = unescaped
+		}
+		if len(param) > 0 {
+			d := uri.NewPathDecoder(uri.PathDecoderConfig{
+				Param:   "id",
+				Value:   param,
+				Style:   uri.PathStyleSimple,
+				Explode: false,
+			})
+
+			if err := func() error {
+				val, err := d.DecodeValue()
+				if err != nil {
+					return err
+				}
+
+				c, err := conv.ToUUID(val)
+				if err != nil {
+					return err
+				}
+
+				params.ID = c
+				return nil
+			}(); err != nil {
+				return err
+			}
+		} else {
+			return validate.ErrFieldRequired
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "id",
+			In:   "path",
+			Err:  err,
+		}
+	}
+	// Decode path: userId.
+	if err := func() error {
+		param := args[1]
+		if argsEscaped {
+			unescaped, err := url.PathUnescape(args[1])
+			if err != nil {
+				return errors.Wrap(err, "unescape path")
+			}
+			param = unescaped
+		}
+		if len(param) > 0 {
+			d := uri.NewPathDecoder(uri.PathDecoderConfig{
+				Param:   "userId",
+				Value:   param,
+				Style:   uri.PathStyleSimple,
+				Explode: false,
+			})
+
+			if err := func() error {
+				val, err := d.DecodeValue()
+				if err != nil {
+					return err
+				}
+
+				c, err := conv.ToUUID(val)
+				if err != nil {
+					return err
+				}
+
+				params.UserId = c
+				return nil
+			}(); err != nil {
+				return err
+			}
+		} else {
+			return validate.ErrFieldRequired
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "userId",
+			In:   "path",
+			Err:  err,
+		}
+	}
+	return params, nil
+}
+
+// RequestEmailVerificationParams is parameters of requestEmailVerification operation.
+type RequestEmailVerificationParams struct {
+	// Tenant of the request. Principals bound to a tenant may omit it or must repeat their own tenant;
+	// other principals must set it when tenancy is enabled.
+	XTenantID OptTenantID `json:",omitempty,omitzero"`
+	// Unique user identifier.
+	ID uuid.UUID
+}
+
+func unpackRequestEmailVerificationParams(packed middleware.Parameters) (params RequestEmailVerificationParams) {
+	{
+		key := middleware.ParameterKey{
+			Name: "X-Tenant-ID",
+			In:   "header",
+		}
+		if v, ok := packed[key]; ok {
+			params.XTenantID = v.(OptTenantID)
+		}
+	}
+	{
+		key := middleware.ParameterKey{
+			Name: "id",
+			In:   "path",
+		}
+		params.ID = packed[key].(uuid.UUID)
+	}
+	return params
+}
+
+func decodeRequestEmailVerificationParams(args [1]string, argsEscaped bool, r *http.Request) (params RequestEmailVerificationParams, _ error) {
+	h := uri.NewHeaderDecoder(r.Header)
+	// Decode header: X-Tenant-ID.
+	if err := func() error {
+		cfg := uri.HeaderParameterDecodingConfig{
+			Name:    "X-Tenant-ID",
+			Explode: false,
+		}
+		if err := h.HasParam(cfg); err == nil {
+			if err := h.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotXTenantIDVal TenantID
+				if err := func() error {
+					var paramsDotXTenantIDValVal string
+					if err := func() error {
+						val, err := d.DecodeValue()
+						if err != nil {
+							return err
+						}
+
+						c, err := conv.ToString(val)
+						if err != nil {
+							return err
+						}
+
+						paramsDotXTenantIDValVal = c
+						return nil
+					}(); err != nil {
+						return err
+					}
+					paramsDotXTenantIDVal = TenantID(paramsDotXTenantIDValVal)
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.XTenantID.SetTo(paramsDotXTenantIDVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+			if err := func() error {
+				if value, ok := params.XTenantID.Get(); ok {
+					if err := func() error {
+						if err := value.Validate(); err != nil {
+							return err
+						}
+						return nil
+					}(); err != nil {
+						return err
+					}
+				}
+				return nil
+			}(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "X-Tenant-ID",
+			In:   "header",
+			Err:  err,
+		}
+	}
+	// Decode path: id.
+	if err := func() error {
+		param := args[0]
+		if argsEscaped {
+			unescaped, err := url.PathUnescape(args[0])
+			if err != nil {
+				return errors.Wrap(err, "unescape path")
+			}
+			param = unescaped
+		}
+		if len(param) > 0 {
+			d := uri.NewPathDecoder(uri.PathDecoderConfig{
+				Param:   "id",
+				Value:   param,
+				Style:   uri.PathStyleSimple,
+				Explode: false,
+			})
+
+			if err := func() error {
+				val, err := d.DecodeValue()
+				if err != nil {
+					return err
+				}
+
+				c, err := conv.ToUUID(val)
+				if err != nil {
+					return err
+				}
+
+				params.ID = c
+				return nil
+			}(); err != nil {
+				return err
+			}
+		} else {
+			return validate.ErrFieldRequired
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "id",
+			In:   "path",
+			Err:  err,
+		}
+	}
+	return params, nil
+}
+
+// RevokeUserSessionParams is parameters of revokeUserSession operation.
+type RevokeUserSessionParams struct {
+	// Tenant of the request. Principals bound to a tenant may omit it or must repeat their own tenant;
+	// other principals must set it when tenancy is enabled.
+	XTenantID OptTenantID `json:",omitempty,omitzero"`
+	// Unique user identifier.
+	ID uuid.UUID
+	// Unique session identifier.
+	SessionId uuid.UUID
+}
+
+func unpackRevokeUserSessionParams(packed middleware.Parameters) (params RevokeUserSessionParams) {
+	{
+		key := middleware.ParameterKey{
+			Name: "X-Tenant-ID",
+			In:   "header",
+		}
+		if v, ok := packed[key]; ok {
+			params.XTenantID = v.(OptTenantID)
+		}
+	}
+	{
+		key := middleware.ParameterKey{
+			Name: "id",
+			In:   "path",
+		}
+		params.ID = packed[key].(uuid.UUID)
+	}
+	{
+		key := middleware.ParameterKey{
+			Name: "sessionId",
+			In:   "path",
+		}
+		params.SessionId = packed[key].(uuid.UUID)
+	}
+	return params
+}
+
+func decodeRevokeUserSessionParams(args [2]string, argsEscaped bool, r *http.Request) (params RevokeUserSessionParams, _ error) {
+	h := uri.NewHeaderDecoder(r.Header)
+	// Decode header: X-Tenant-ID.
+	if err := func() error {
+		cfg := uri.HeaderParameterDecodingConfig{
+			Name:    "X-Tenant-ID",
+			Explode: false,
+		}
+		if err := h.HasParam(cfg); err == nil {
+			if err := h.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotXTenantIDVal TenantID
+				if err := func() error {
+					var paramsDotXTenantIDValVal string
+					if err := func() error {
+						val, err := d.DecodeValue()
+						if err != nil {
+							return err
+						}
+
+						c, err := conv.ToString(val)
+						if err != nil {
+							return err
+						}
+
+						paramsDotXTenantIDValVal = c
+						return nil
+					}(); err != nil {
+						return err
+					}
+					paramsDotXTenantIDVal = TenantID(paramsDotXTenantIDValVal)
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.XTenantID.SetTo(paramsDotXTenantIDVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+			if err := func() error {
+				if value, ok := params.XTenantID.Get(); ok {
+					if err := func() error {
+						if err := value.Validate(); err != nil {
+							return err
+						}
+						return nil
+					}(); err != nil {
+						return err
+					}
+				}
+				return nil
+			}(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "X-Tenant-ID",
+			In:   "header",
+			Err:  err,
+		}
+	}
+	// Decode path: id.
+	if err := func() error {
+		param := args[0]
+		if argsEscaped {
+			unescaped, err := url.PathUnescape(args[0])
+			if err != nil {
+				return errors.Wrap(err, "unescape path")
+			}
+			param = unescaped
+		}
+		if len(param) > 0 {
+			d := uri.NewPathDecoder(uri.PathDecoderConfig{
+				Param:   "id",
+				Value:   param,
+				Style:   uri.PathStyleSimple,
+				Explode: false,
+			})
+
+			if err := func() error {
+				val, err := d.DecodeValue()
+				if err != nil {
+					return err
+				}
+
+				c, err := conv.ToUUID(val)
+				if err != nil {
+					return err
+				}
+
+				params.ID = c
+				return nil
+			}(); err != nil {
+				return err
+			}
+		} else {
+			return validate.ErrFieldRequired
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "id",
+			In:   "path",
+			Err:  err,
+		}
+	}
+	// Decode path: sessionId.
+	if err := func() error {
+		param := args[1]
+		if argsEscaped {
+			unescaped, err := url.PathUnescape(args[1])
+			if err != nil {
+				return errors.Wrap(err, "unescape path")
+			}
+			param = unescaped
+		}
+		if len(param) > 0 {
+			d := uri.NewPathDecoder(uri.PathDecoderConfig{
+				Param:   "sessionId",
+				Value:   param,
+				Style:   uri.PathStyleSimple,
+				Explode: false,
+			})
+
+			if err := func() error {
+				val, err := d.DecodeValue()
+				if err != nil {
+					return err
+				}
+
+				c, err := conv.ToUUID(val)
+				if err != nil {
+					return err
+				}
+
+				params.SessionId = c
+				return nil
+			}(); err != nil {
+				return err
+			}
+		} else {
+			return validate.ErrFieldRequired
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "sessionId",
+			In:   "path",
+			Err:  err,
+		}
+	}
+	return params, nil
+}
+
+// RevokeUserSessionsParams is parameters of revokeUserSessions operation.
+type RevokeUserSessionsParams struct {
+	// Tenant of the request. Principals bound to a tenant may omit it or must repeat their own tenant;
+	// other principals must set it when tenancy is enabled.
+	XTenantID OptTenantID `json:",omitempty,omitzero"`
+	// Unique user identifier.
+	ID uuid.UUID
+}
+
+func unpackRevokeUserSessionsParams(packed middleware.Parameters) (params RevokeUserSessionsParams) {
+	{
+		key := middleware.ParameterKey{
+			Name: "X-Tenant-ID",
+			In:   "header",
+		}
+		if v, ok := packed[key]; ok {
+			params.XTenantID = v.(OptTenantID)
+		}
+	}
+	{
+		key := middleware.ParameterKey{
+			Name: "id",
+			In:   "path",
+		}
+		params.ID = packed[key].(uuid.UUID)
+	}
+	return params
+}
+
+func decodeRevokeUserSessionsParams(args [1]string, argsEscaped bool, r *http.Request) (params RevokeUserSessionsParams, _ error) {
+	h := uri.NewHeaderDecoder(r.Header)
+	// Decode header: X-Tenant-ID.
+	if err := func() error {
+		cfg := uri.HeaderParameterDecodingConfig{
+			Name:    "X-Tenant-ID",
+			Explode: false,
+		}
+		if err := h.HasParam(cfg); err == nil {
+			if err := h.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotXTenantIDVal TenantID
+				if err := func() error {
+					var paramsDotXTenantIDValVal string
+					if err := func() error {
+						val, err := d.DecodeValue()
+						if err != nil {
+							return err
+						}
+
+						c, err := conv.ToString(val)
+						if err != nil {
+							return err
+						}
+
+						paramsDotXTenantIDValVal = c
+						return nil
+					}(); err != nil {
+						return err
+					}
+					paramsDotXTenantIDVal = TenantID(paramsDotXTenantIDValVal)
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.XTenantID.SetTo(paramsDotXTenantIDVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+			if err := func() error {
+				if value, ok := params.XTenantID.Get(); ok {
+					if err := func() error {
+						if err := value.Validate(); err != nil {
+							return err
+						}
+						return nil
+					}(); err != nil {
+						return err
+					}
+				}
+				return nil
+			}(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "X-Tenant-ID",
+			In:   "header",
+			Err:  err,
+		}
+	}
+	// Decode path: id.
+	if err := func() error {
+		param := args[0]
+		if argsEscaped {
+			unescaped, err := url.PathUnescape(args[0])
+			if err != nil {
+				return errors.Wrap(err, "unescape path")
+			}
+			param = unescaped
+		}
+		if len(param) > 0 {
+			d := uri.NewPathDecoder(uri.PathDecoderConfig{
+				Param:   "id",
+				Value:   param,
+				Style:   uri.PathStyleSimple,
+				Explode: false,
+			})
+
+			if err := func() error {
+				val, err := d.DecodeValue()
+				if err != nil {
+					return err
+				}
+
+				c, err := conv.ToUUID(val)
+				if err != nil {
+					return err
+				}
+
+				params.ID = c
+				return nil
+			}(); err != nil {
+				return err
+			}
+		} else {
+			return validate.ErrFieldRequired
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "id",
+			In:   "path",
+			Err:  err,
+		}
+	}
+	return params, nil
+}
+
+// SetUserPasswordParams is parameters of setUserPassword operation.
+type SetUserPasswordParams struct {
+	// Tenant of the request. Principals bound to a tenant may omit it or must repeat their own tenant;
+	// other principals must set it when tenancy is enabled.
+	XTenantID OptTenantID `json:",omitempty,omitzero"`
 	// Unique user identifier.
 	ID uuid.UUID
 }
 
-func unpackDeleteUserParams(packed middleware.Parameters) (params DeleteUserParams) {
+func unpackSetUserPasswordParams(packed middleware.Parameters) (params SetUserPasswordParams) {
+	{
+		key := middleware.ParameterKey{
+			Name: "X-Tenant-ID",
+			In:   "header",
+		}
+		if v, ok := packed[key]; ok {
+			params.XTenantID = v.(OptTenantID)
+		}
+	}
 	{
 		key := middleware.ParameterKey{
 			Name: "id",
@@ -32,7 +3054,69 @@ func unpackDeleteUserParams(packed middleware.Parameters) (params DeleteUserPara
 	return params
 }
 
-func decodeDeleteUserParams(args [1]string, argsEscaped bool, r *http.Request) (params DeleteUserParams, _ error) {
+func decodeSetUserPasswordParams(args [1]string, argsEscaped bool, r *http.Request) (params SetUserPasswordParams, _ error) {
+	h := uri.NewHeaderDecoder(r.Header)
+	// Decode header: X-Tenant-ID.
+	if err := func() error {
+		cfg := uri.HeaderParameterDecodingConfig{
+			Name:    "X-Tenant-ID",
+			Explode: false,
+		}
+		if err := h.HasParam(cfg); err == nil {
+			if err := h.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotXTenantIDVal TenantID
+				if err := func() error {
+					var paramsDotXTenantIDValVal string
+					if err := func() error {
+						val, err := d.DecodeValue()
+						if err != nil {
+							return err
+						}
+
+						c, err := conv.ToString(val)
+						if err != nil {
+							return err
+						}
+
+						paramsDotXTenantIDValVal = c
+						return nil
+					}(); err != nil {
+						return err
+					}
+					paramsDotXTenantIDVal = TenantID(paramsDotXTenantIDValVal)
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.XTenantID.SetTo(paramsDotXTenantIDVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+			if err := func() error {
+				if value, ok := params.XTenantID.Get(); ok {
+					if err := func() error {
+						if err := value.Validate(); err != nil {
+							return err
+						}
+						return nil
+					}(); err != nil {
+						return err
+					}
+				}
+				return nil
+			}(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "X-Tenant-ID",
+			In:   "header",
+			Err:  err,
+		}
+	}
 	// Decode path: id.
 	if err := func() error {
 		param := args[0]
@@ -81,13 +3165,25 @@ func decodeDeleteUserParams(args [1]string, argsEscaped bool, r *http.Request) (
 	return params, nil
 }
 
-// GetUserParams is parameters of getUser operation.
-type GetUserParams struct {
-	// Unique user identifier.
+// UpdateGroupParams is parameters of updateGroup operation.
+type UpdateGroupParams struct {
+	// Tenant of the request. Principals bound to a tenant may omit it or must repeat their own tenant;
+	// other principals must set it when tenancy is enabled.
+	XTenantID OptTenantID `json:",omitempty,omitzero"`
+	// Unique group identifier.
 	ID uuid.UUID
 }
 
-func unpackGetUserParams(packed middleware.Parameters) (params GetUserParams) {
+func unpackUpdateGroupParams(packed middleware.Parameters) (params UpdateGroupParams) {
+	{
+		key := middleware.ParameterKey{
+			Name: "X-Tenant-ID",
+			In:   "header",
+		}
+		if v, ok := packed[key]; ok {
+			params.XTenantID = v.(OptTenantID)
+		}
+	}
 	{
 		key := middleware.ParameterKey{
 			Name: "id",
@@ -98,7 +3194,69 @@ func unpackGetUserParams(packed middleware.Parameters) (params GetUserParams) {
 	return params
 }
 
-func decodeGetUserParams(args [1]string, argsEscaped bool, r *http.Request) (params GetUserParams, _ error) {
+func decodeUpdateGroupParams(args [1]string, argsEscaped bool, r *http.Request) (params UpdateGroupParams, _ error) {
+	h := uri.NewHeaderDecoder(r.Header)
+	// Decode header: X-Tenant-ID.
+	if err := func() error {
+		cfg := uri.HeaderParameterDecodingConfig{
+			Name:    "X-Tenant-ID",
+			Explode: false,
+		}
+		if err := h.HasParam(cfg); err == nil {
+			if err := h.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotXTenantIDVal TenantID
+				if err := func() error {
+					var paramsDotXTenantIDValVal string
+					if err := func() error {
+						val, err := d.DecodeValue()
+						if err != nil {
+							return err
+						}
+
+						c, err := conv.ToString(val)
+						if err != nil {
+							return err
+						}
+
+						paramsDotXTenantIDValVal = c
+						return nil
+					}(); err != nil {
+						return err
+					}
+					paramsDotXTenantIDVal = TenantID(paramsDotXTenantIDValVal)
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.XTenantID.SetTo(paramsDotXTenantIDVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+			if err := func() error {
+				if value, ok := params.XTenantID.Get(); ok {
+					if err := func() error {
+						if err := value.Validate(); err != nil {
+							return err
+						}
+						return nil
+					}(); err != nil {
+						return err
+					}
+				}
+				return nil
+			}(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "X-Tenant-ID",
+			In:   "header",
+			Err:  err,
+		}
+	}
 	// Decode path: id.
 	if err := func() error {
 		param := args[0]
@@ -149,11 +3307,23 @@ func decodeGetUserParams(args [1]string, argsEscaped bool, r *http.Request) (par
 
 // UpdateUserParams is parameters of updateUser operation.
 type UpdateUserParams struct {
+	// Tenant of the request. Principals bound to a tenant may omit it or must repeat their own tenant;
+	// other principals must set it when tenancy is enabled.
+	XTenantID OptTenantID `json:",omitempty,omitzero"`
 	// Unique user identifier.
 	ID uuid.UUID
 }
 
 func unpackUpdateUserParams(packed middleware.Parameters) (params UpdateUserParams) {
+	{
+		key := middleware.ParameterKey{
+			Name: "X-Tenant-ID",
+			In:   "header",
+		}
+		if v, ok := packed[key]; ok {
+			params.XTenantID = v.(OptTenantID)
+		}
+	}
 	{
 		key := middleware.ParameterKey{
 			Name: "id",
@@ -165,6 +3335,208 @@ func unpackUpdateUserParams(packed middleware.Parameters) (params UpdateUserPara
 }
 
 func decodeUpdateUserParams(args [1]string, argsEscaped bool, r *http.Request) (params UpdateUserParams, _ error) {
+	h := uri.NewHeaderDecoder(r.Header)
+	// Decode header: X-Tenant-ID.
+	if err := func() error {
+		cfg := uri.HeaderParameterDecodingConfig{
+			Name:    "X-Tenant-ID",
+			Explode: false,
+		}
+		if err := h.HasParam(cfg); err == nil {
+			if err := h.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotXTenantIDVal TenantID
+				if err := func() error {
+					var paramsDotXTenantIDValVal string
+					if err := func() error {
+						val, err := d.DecodeValue()
+						if err != nil {
+							return err
+						}
+
+						c, err := conv.ToString(val)
+						if err != nil {
+							return err
+						}
+
+						paramsDotXTenantIDValVal = c
+						return nil
+					}(); err != nil {
+						return err
+					}
+					paramsDotXTenantIDVal = TenantID(paramsDotXTenantIDValVal)
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.XTenantID.SetTo(paramsDotXTenantIDVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+			if err := func() error {
+				if value, ok := params.XTenantID.Get(); ok {
+					if err := func() error {
+						if err := value.Validate(); err != nil {
+							return err
+						}
+						return nil
+					}(); err != nil {
+						return err
+					}
+				}
+				return nil
+			}(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "X-Tenant-ID",
+			In:   "header",
+			Err:  err,
+		}
+	}
+	// Decode path: id.
+	if err := func() error {
+		param := args[0]
+		if argsEscaped {
+			unescaped, err := url.PathUnescape(args[0])
+			if err != nil {
+				return errors.Wrap(err, "unescape path")
+			}
+			param = unescaped
+		}
+		if len(param) > 0 {
+			d := uri.NewPathDecoder(uri.PathDecoderConfig{
+				Param:   "id",
+				Value:   param,
+				Style:   uri.PathStyleSimple,
+				Explode: false,
+			})
+
+			if err := func() error {
+				val, err := d.DecodeValue()
+				if err != nil {
+					return err
+				}
+
+				c, err := conv.ToUUID(val)
+				if err != nil {
+					return err
+				}
+
+				params.ID = c
+				return nil
+			}(); err != nil {
+				return err
+			}
+		} else {
+			return validate.ErrFieldRequired
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "id",
+			In:   "path",
+			Err:  err,
+		}
+	}
+	return params, nil
+}
+
+// VerifyUserEmailParams is parameters of verifyUserEmail operation.
+type VerifyUserEmailParams struct {
+	// Tenant of the request. Principals bound to a tenant may omit it or must repeat their own tenant;
+	// other principals must set it when tenancy is enabled.
+	XTenantID OptTenantID `json:",omitempty,omitzero"`
+	// Unique user identifier.
+	ID uuid.UUID
+}
+
+func unpackVerifyUserEmailParams(packed middleware.Parameters) (params VerifyUserEmailParams) {
+	{
+		key := middleware.ParameterKey{
+			Name: "X-Tenant-ID",
+			In:   "header",
+		}
+		if v, ok := packed[key]; ok {
+			params.XTenantID = v.(OptTenantID)
+		}
+	}
+	{
+		key := middleware.ParameterKey{
+			Name: "id",
+			In:   "path",
+		}
+		params.ID = packed[key].(uuid.UUID)
+	}
+	return params
+}
+
+func decodeVerifyUserEmailParams(args [1]string, argsEscaped bool, r *http.Request) (params VerifyUserEmailParams, _ error) {
+	h := uri.NewHeaderDecoder(r.Header)
+	// Decode header: X-Tenant-ID.
+	if err := func() error {
+		cfg := uri.HeaderParameterDecodingConfig{
+			Name:    "X-Tenant-ID",
+			Explode: false,
+		}
+		if err := h.HasParam(cfg); err == nil {
+			if err := h.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotXTenantIDVal TenantID
+				if err := func() error {
+					var paramsDotXTenantIDValVal string
+					if err := func() error {
+						val, err := d.DecodeValue()
+						if err != nil {
+							return err
+						}
+
+						c, err := conv.ToString(val)
+						if err != nil {
+							return err
+						}
+
+						paramsDotXTenantIDValVal = c
+						return nil
+					}(); err != nil {
+						return err
+					}
+					paramsDotXTenantIDVal = TenantID(paramsDotXTenantIDValVal)
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.XTenantID.SetTo(paramsDotXTenantIDVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+			if err := func() error {
+				if value, ok := params.XTenantID.Get(); ok {
+					if err := func() error {
+						if err := value.Validate(); err != nil {
+							return err
+						}
+						return nil
+					}(); err != nil {
+						return err
+					}
+				}
+				return nil
+			}(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "X-Tenant-ID",
+			In:   "header",
+			Err:  err,
+		}
+	}
 	// Decode path: id.
 	if err := func() error {
 		param := args[0]
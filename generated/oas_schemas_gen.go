@@ -3,9 +3,429 @@
 package api
 
 import (
+	"net/http"
+	"time"
+
+	"github.com/go-faster/errors"
 	"github.com/google/uuid"
 )
 
+// AddGroupMemberNoContent is response for AddGroupMember operation.
+type AddGroupMemberNoContent struct{}
+
+func (*AddGroupMemberNoContent) addGroupMemberRes() {}
+
+// AddGroupMemberNotFound is response for AddGroupMember operation.
+type AddGroupMemberNotFound struct{}
+
+func (*AddGroupMemberNotFound) addGroupMemberRes() {}
+
+type ApiKeyAuth struct {
+	APIKey string
+	Roles  []string
+}
+
+// GetAPIKey returns the value of APIKey.
+func (s *ApiKeyAuth) GetAPIKey() string {
+	return s.APIKey
+}
+
+// GetRoles returns the value of Roles.
+func (s *ApiKeyAuth) GetRoles() []string {
+	return s.Roles
+}
+
+// SetAPIKey sets the value of APIKey.
+func (s *ApiKeyAuth) SetAPIKey(val string) {
+	s.APIKey = val
+}
+
+// SetRoles sets the value of Roles.
+func (s *ApiKeyAuth) SetRoles(val []string) {
+	s.Roles = val
+}
+
+// Ref: #/components/schemas/AttributeDefinition
+type AttributeDefinition struct {
+	// Attribute name of lowercase letters, digits and underscores.
+	Name string `json:"name"`
+	// Type every value must have.
+	Type AttributeDefinitionType `json:"type"`
+	// Whether every user must have the attribute.
+	Required OptBool `json:"required"`
+	// Whether no two users of the tenant may share a value.
+	Unique OptBool `json:"unique"`
+	// Allowed values; any value of the type when omitted.
+	Enum []AttributeValue `json:"enum"`
+	// Regular expression (RE2) that string values must match.
+	Pattern OptString `json:"pattern"`
+	// What the attribute holds.
+	Description OptString `json:"description"`
+}
+
+// GetName returns the value of Name.
+func (s *AttributeDefinition) GetName() string {
+	return s.Name
+}
+
+// GetType returns the value of Type.
+func (s *AttributeDefinition) GetType() AttributeDefinitionType {
+	return s.Type
+}
+
+// GetRequired returns the value of Required.
+func (s *AttributeDefinition) GetRequired() OptBool {
+	return s.Required
+}
+
+// GetUnique returns the value of Unique.
+func (s *AttributeDefinition) GetUnique() OptBool {
+	return s.Unique
+}
+
+// GetEnum returns the value of Enum.
+func (s *AttributeDefinition) GetEnum() []AttributeValue {
+	return s.Enum
+}
+
+// GetPattern returns the value of Pattern.
+func (s *AttributeDefinition) GetPattern() OptString {
+	return s.Pattern
+}
+
+// GetDescription returns the value of Description.
+func (s *AttributeDefinition) GetDescription() OptString {
+	return s.Description
+}
+
+// SetName sets the value of Name.
+func (s *AttributeDefinition) SetName(val string) {
+	s.Name = val
+}
+
+// SetType sets the value of Type.
+func (s *AttributeDefinition) SetType(val AttributeDefinitionType) {
+	s.Type = val
+}
+
+// SetRequired sets the value of Required.
+func (s *AttributeDefinition) SetRequired(val OptBool) {
+	s.Required = val
+}
+
+// SetUnique sets the value of Unique.
+func (s *AttributeDefinition) SetUnique(val OptBool) {
+	s.Unique = val
+}
+
+// SetEnum sets the value of Enum.
+func (s *AttributeDefinition) SetEnum(val []AttributeValue) {
+	s.Enum = val
+}
+
+// SetPattern sets the value of Pattern.
+func (s *AttributeDefinition) SetPattern(val OptString) {
+	s.Pattern = val
+}
+
+// SetDescription sets the value of Description.
+func (s *AttributeDefinition) SetDescription(val OptString) {
+	s.Description = val
+}
+
+// Type every value must have.
+type AttributeDefinitionType string
+
+const (
+	AttributeDefinitionTypeString  AttributeDefinitionType = "string"
+	AttributeDefinitionTypeInteger AttributeDefinitionType = "integer"
+	AttributeDefinitionTypeNumber  AttributeDefinitionType = "number"
+	AttributeDefinitionTypeBoolean AttributeDefinitionType = "boolean"
+)
+
+// AllValues returns all AttributeDefinitionType values.
+func (AttributeDefinitionType) AllValues() []AttributeDefinitionType {
+	return []AttributeDefinitionType{
+		AttributeDefinitionTypeString,
+		AttributeDefinitionTypeInteger,
+		AttributeDefinitionTypeNumber,
+		AttributeDefinitionTypeBoolean,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s AttributeDefinitionType) MarshalText() ([]byte, error) {
+	switch s {
+	case AttributeDefinitionTypeString:
+		return []byte(s), nil
+	case AttributeDefinitionTypeInteger:
+		return []byte(s), nil
+	case AttributeDefinitionTypeNumber:
+		return []byte(s), nil
+	case AttributeDefinitionTypeBoolean:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *AttributeDefinitionType) UnmarshalText(data []byte) error {
+	switch AttributeDefinitionType(data) {
+	case AttributeDefinitionTypeString:
+		*s = AttributeDefinitionTypeString
+		return nil
+	case AttributeDefinitionTypeInteger:
+		*s = AttributeDefinitionTypeInteger
+		return nil
+	case AttributeDefinitionTypeNumber:
+		*s = AttributeDefinitionTypeNumber
+		return nil
+	case AttributeDefinitionTypeBoolean:
+		*s = AttributeDefinitionTypeBoolean
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+// Ref: #/components/schemas/AttributeSchema
+type AttributeSchema struct {
+	Attributes []AttributeDefinition `json:"attributes"`
+}
+
+// GetAttributes returns the value of Attributes.
+func (s *AttributeSchema) GetAttributes() []AttributeDefinition {
+	return s.Attributes
+}
+
+// SetAttributes sets the value of Attributes.
+func (s *AttributeSchema) SetAttributes(val []AttributeDefinition) {
+	s.Attributes = val
+}
+
+func (*AttributeSchema) putAttributeSchemaRes() {}
+
+// Value of a custom attribute, of the type its definition sets.
+// Ref: #/components/schemas/AttributeValue
+// AttributeValue represents sum type.
+type AttributeValue struct {
+	Type    AttributeValueType // switch on this field
+	String  string
+	Float64 float64
+	Bool    bool
+}
+
+// AttributeValueType is oneOf type of AttributeValue.
+type AttributeValueType string
+
+// Possible values for AttributeValueType.
+const (
+	StringAttributeValue  AttributeValueType = "string"
+	Float64AttributeValue AttributeValueType = "float64"
+	BoolAttributeValue    AttributeValueType = "bool"
+)
+
+// IsString reports whether AttributeValue is string.
+func (s AttributeValue) IsString() bool { return s.Type == StringAttributeValue }
+
+// IsFloat64 reports whether AttributeValue is float64.
+func (s AttributeValue) IsFloat64() bool { return s.Type == Float64AttributeValue }
+
+// IsBool reports whether AttributeValue is bool.
+func (s AttributeValue) IsBool() bool { return s.Type == BoolAttributeValue }
+
+// SetString sets AttributeValue to string.
+func (s *AttributeValue) SetString(v string) {
+	s.Type = StringAttributeValue
+	s.String = v
+}
+
+// GetString returns string and true boolean if AttributeValue is string.
+func (s AttributeValue) GetString() (v string, ok bool) {
+	if !s.IsString() {
+		return v, false
+	}
+	return s.String, true
+}
+
+// NewStringAttributeValue returns new AttributeValue from string.
+func NewStringAttributeValue(v string) AttributeValue {
+	var s AttributeValue
+	s.SetString(v)
+	return s
+}
+
+// SetFloat64 sets AttributeValue to float64.
+func (s *AttributeValue) SetFloat64(v float64) {
+	s.Type = Float64AttributeValue
+	s.Float64 = v
+}
+
+// GetFloat64 returns float64 and true boolean if AttributeValue is float64.
+func (s AttributeValue) GetFloat64() (v float64, ok bool) {
+	if !s.IsFloat64() {
+		return v, false
+	}
+	return s.Float64, true
+}
+
+// NewFloat64AttributeValue returns new AttributeValue from float64.
+func NewFloat64AttributeValue(v float64) AttributeValue {
+	var s AttributeValue
+	s.SetFloat64(v)
+	return s
+}
+
+// SetBool sets AttributeValue to bool.
+func (s *AttributeValue) SetBool(v bool) {
+	s.Type = BoolAttributeValue
+	s.Bool = v
+}
+
+// GetBool returns bool and true boolean if AttributeValue is bool.
+func (s AttributeValue) GetBool() (v bool, ok bool) {
+	if !s.IsBool() {
+		return v, false
+	}
+	return s.Bool, true
+}
+
+// NewBoolAttributeValue returns new AttributeValue from bool.
+func NewBoolAttributeValue(v bool) AttributeValue {
+	var s AttributeValue
+	s.SetBool(v)
+	return s
+}
+
+// Custom attributes of a user, keyed by attribute name.
+// Ref: #/components/schemas/Attributes
+type Attributes map[string]AttributeValue
+
+func (s *Attributes) init() Attributes {
+	m := *s
+	if m == nil {
+		m = map[string]AttributeValue{}
+		*s = m
+	}
+	return m
+}
+
+type BearerAuth struct {
+	Token string
+	Roles []string
+}
+
+// GetToken returns the value of Token.
+func (s *BearerAuth) GetToken() string {
+	return s.Token
+}
+
+// GetRoles returns the value of Roles.
+func (s *BearerAuth) GetRoles() []string {
+	return s.Roles
+}
+
+// SetToken sets the value of Token.
+func (s *BearerAuth) SetToken(val string) {
+	s.Token = val
+}
+
+// SetRoles sets the value of Roles.
+func (s *BearerAuth) SetRoles(val []string) {
+	s.Roles = val
+}
+
+// ChangeUserPasswordConflict is response for ChangeUserPassword operation.
+type ChangeUserPasswordConflict struct{}
+
+func (*ChangeUserPasswordConflict) changeUserPasswordRes() {}
+
+// ChangeUserPasswordForbidden is response for ChangeUserPassword operation.
+type ChangeUserPasswordForbidden struct{}
+
+func (*ChangeUserPasswordForbidden) changeUserPasswordRes() {}
+
+// ChangeUserPasswordNoContent is response for ChangeUserPassword operation.
+type ChangeUserPasswordNoContent struct{}
+
+func (*ChangeUserPasswordNoContent) changeUserPasswordRes() {}
+
+// ChangeUserPasswordNotFound is response for ChangeUserPassword operation.
+type ChangeUserPasswordNotFound struct{}
+
+func (*ChangeUserPasswordNotFound) changeUserPasswordRes() {}
+
+type ClientCertAuth struct {
+	Request *http.Request
+	Roles   []string
+}
+
+// GetRequest returns the value of Request.
+func (s *ClientCertAuth) GetRequest() *http.Request {
+	return s.Request
+}
+
+// GetRoles returns the value of Roles.
+func (s *ClientCertAuth) GetRoles() []string {
+	return s.Roles
+}
+
+// SetRequest sets the value of Request.
+func (s *ClientCertAuth) SetRequest(val *http.Request) {
+	s.Request = val
+}
+
+// SetRoles sets the value of Roles.
+func (s *ClientCertAuth) SetRoles(val []string) {
+	s.Roles = val
+}
+
+// CreateGroupConflict is response for CreateGroup operation.
+type CreateGroupConflict struct{}
+
+func (*CreateGroupConflict) createGroupRes() {}
+
+// CreateSessionUnauthorized is response for CreateSession operation.
+type CreateSessionUnauthorized struct{}
+
+func (*CreateSessionUnauthorized) createSessionRes() {}
+
+// CreateTenantConflict is response for CreateTenant operation.
+type CreateTenantConflict struct{}
+
+func (*CreateTenantConflict) createTenantRes() {}
+
+// CreateUserConflict is response for CreateUser operation.
+type CreateUserConflict struct{}
+
+func (*CreateUserConflict) createUserRes() {}
+
+// DeleteGroupNoContent is response for DeleteGroup operation.
+type DeleteGroupNoContent struct{}
+
+func (*DeleteGroupNoContent) deleteGroupRes() {}
+
+// DeleteGroupNotFound is response for DeleteGroup operation.
+type DeleteGroupNotFound struct{}
+
+func (*DeleteGroupNotFound) deleteGroupRes() {}
+
+// DeleteTenantConflict is response for DeleteTenant operation.
+type DeleteTenantConflict struct{}
+
+func (*DeleteTenantConflict) deleteTenantRes() {}
+
+// DeleteTenantNoContent is response for DeleteTenant operation.
+type DeleteTenantNoContent struct{}
+
+func (*DeleteTenantNoContent) deleteTenantRes() {}
+
+// DeleteTenantNotFound is response for DeleteTenant operation.
+type DeleteTenantNotFound struct{}
+
+func (*DeleteTenantNotFound) deleteTenantRes() {}
+
 // DeleteUserNoContent is response for DeleteUser operation.
 type DeleteUserNoContent struct{}
 
@@ -16,71 +436,534 @@ type DeleteUserNotFound struct{}
 
 func (*DeleteUserNotFound) deleteUserRes() {}
 
+type Email string
+
+// Ref: #/components/schemas/EmailVerification
+type EmailVerification struct {
+	// Token sent to the email address.
+	Token string `json:"token"`
+}
+
+// GetToken returns the value of Token.
+func (s *EmailVerification) GetToken() string {
+	return s.Token
+}
+
+// SetToken sets the value of Token.
+func (s *EmailVerification) SetToken(val string) {
+	s.Token = val
+}
+
+// GetGroupNotFound is response for GetGroup operation.
+type GetGroupNotFound struct{}
+
+func (*GetGroupNotFound) getGroupRes() {}
+
+// GetTenantNotFound is response for GetTenant operation.
+type GetTenantNotFound struct{}
+
+func (*GetTenantNotFound) getTenantRes() {}
+
 // GetUserNotFound is response for GetUser operation.
 type GetUserNotFound struct{}
 
-func (*GetUserNotFound) getUserRes() {}
+func (*GetUserNotFound) getUserRes() {}
+
+// Ref: #/components/schemas/Group
+type Group struct {
+	// Unique group identifier.
+	ID uuid.UUID `json:"id"`
+	// Unique name of the group within the tenant.
+	Name string `json:"name"`
+	// What the group is for; empty if not set.
+	Description string `json:"description"`
+}
+
+// GetID returns the value of ID.
+func (s *Group) GetID() uuid.UUID {
+	return s.ID
+}
+
+// GetName returns the value of Name.
+func (s *Group) GetName() string {
+	return s.Name
+}
+
+// GetDescription returns the value of Description.
+func (s *Group) GetDescription() string {
+	return s.Description
+}
+
+// SetID sets the value of ID.
+func (s *Group) SetID(val uuid.UUID) {
+	s.ID = val
+}
+
+// SetName sets the value of Name.
+func (s *Group) SetName(val string) {
+	s.Name = val
+}
+
+// SetDescription sets the value of Description.
+func (s *Group) SetDescription(val string) {
+	s.Description = val
+}
+
+func (*Group) createGroupRes() {}
+func (*Group) getGroupRes()    {}
+func (*Group) updateGroupRes() {}
+
+// ListGroupMembersNotFound is response for ListGroupMembers operation.
+type ListGroupMembersNotFound struct{}
+
+func (*ListGroupMembersNotFound) listGroupMembersRes() {}
+
+type ListGroupMembersOKApplicationJSON []User
+
+func (*ListGroupMembersOKApplicationJSON) listGroupMembersRes() {}
+
+// ListUserGroupsNotFound is response for ListUserGroups operation.
+type ListUserGroupsNotFound struct{}
+
+func (*ListUserGroupsNotFound) listUserGroupsRes() {}
+
+type ListUserGroupsOKApplicationJSON []Group
+
+func (*ListUserGroupsOKApplicationJSON) listUserGroupsRes() {}
+
+// ListUserSessionsNotFound is response for ListUserSessions operation.
+type ListUserSessionsNotFound struct{}
+
+func (*ListUserSessionsNotFound) listUserSessionsRes() {}
+
+type ListUserSessionsOKApplicationJSON []Session
+
+func (*ListUserSessionsOKApplicationJSON) listUserSessionsRes() {}
+
+// Ref: #/components/responses/Locked
+type Locked struct {
+	RetryAfter int
+}
+
+// GetRetryAfter returns the value of RetryAfter.
+func (s *Locked) GetRetryAfter() int {
+	return s.RetryAfter
+}
+
+// SetRetryAfter sets the value of RetryAfter.
+func (s *Locked) SetRetryAfter(val int) {
+	s.RetryAfter = val
+}
+
+func (*Locked) changeUserPasswordRes() {}
+
+// Ref: #/components/schemas/Login
+type Login struct {
+	// Username of the user logging in.
+	Username string   `json:"username"`
+	Password Password `json:"password"`
+}
+
+// GetUsername returns the value of Username.
+func (s *Login) GetUsername() string {
+	return s.Username
+}
+
+// GetPassword returns the value of Password.
+func (s *Login) GetPassword() Password {
+	return s.Password
+}
+
+// SetUsername sets the value of Username.
+func (s *Login) SetUsername(val string) {
+	s.Username = val
+}
+
+// SetPassword sets the value of Password.
+func (s *Login) SetPassword(val Password) {
+	s.Password = val
+}
+
+// Ref: #/components/schemas/LoginResult
+type LoginResult struct {
+	User    User    `json:"user"`
+	Session Session `json:"session"`
+	// Opaque session token. It is returned only here; the service keeps just a hash of it.
+	Token string `json:"token"`
+}
+
+// GetUser returns the value of User.
+func (s *LoginResult) GetUser() User {
+	return s.User
+}
+
+// GetSession returns the value of Session.
+func (s *LoginResult) GetSession() Session {
+	return s.Session
+}
+
+// GetToken returns the value of Token.
+func (s *LoginResult) GetToken() string {
+	return s.Token
+}
+
+// SetUser sets the value of User.
+func (s *LoginResult) SetUser(val User) {
+	s.User = val
+}
+
+// SetSession sets the value of Session.
+func (s *LoginResult) SetSession(val Session) {
+	s.Session = val
+}
+
+// SetToken sets the value of Token.
+func (s *LoginResult) SetToken(val string) {
+	s.Token = val
+}
+
+func (*LoginResult) createSessionRes() {}
+
+// Ref: #/components/schemas/NewGroup
+type NewGroup struct {
+	// Unique name of the group within the tenant.
+	Name string `json:"name"`
+	// What the group is for.
+	Description OptString `json:"description"`
+}
+
+// GetName returns the value of Name.
+func (s *NewGroup) GetName() string {
+	return s.Name
+}
+
+// GetDescription returns the value of Description.
+func (s *NewGroup) GetDescription() OptString {
+	return s.Description
+}
+
+// SetName sets the value of Name.
+func (s *NewGroup) SetName(val string) {
+	s.Name = val
+}
+
+// SetDescription sets the value of Description.
+func (s *NewGroup) SetDescription(val OptString) {
+	s.Description = val
+}
+
+// Ref: #/components/schemas/NewUser
+type NewUser struct {
+	// Name shown for the user.
+	DisplayName string `json:"display_name"`
+	// Unique username.
+	Username   string        `json:"username"`
+	Email      OptEmail      `json:"email"`
+	Attributes OptAttributes `json:"attributes"`
+}
+
+// GetDisplayName returns the value of DisplayName.
+func (s *NewUser) GetDisplayName() string {
+	return s.DisplayName
+}
+
+// GetUsername returns the value of Username.
+func (s *NewUser) GetUsername() string {
+	return s.Username
+}
+
+// GetEmail returns the value of Email.
+func (s *NewUser) GetEmail() OptEmail {
+	return s.Email
+}
+
+// GetAttributes returns the value of Attributes.
+func (s *NewUser) GetAttributes() OptAttributes {
+	return s.Attributes
+}
+
+// SetDisplayName sets the value of DisplayName.
+func (s *NewUser) SetDisplayName(val string) {
+	s.DisplayName = val
+}
+
+// SetUsername sets the value of Username.
+func (s *NewUser) SetUsername(val string) {
+	s.Username = val
+}
+
+// SetEmail sets the value of Email.
+func (s *NewUser) SetEmail(val OptEmail) {
+	s.Email = val
+}
+
+// SetAttributes sets the value of Attributes.
+func (s *NewUser) SetAttributes(val OptAttributes) {
+	s.Attributes = val
+}
+
+// NewOptAttributes returns new OptAttributes with value set to v.
+func NewOptAttributes(v Attributes) OptAttributes {
+	return OptAttributes{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptAttributes is optional Attributes.
+type OptAttributes struct {
+	Value Attributes
+	Set   bool
+}
+
+// IsSet returns true if OptAttributes was set.
+func (o OptAttributes) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptAttributes) Reset() {
+	var v Attributes
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptAttributes) SetTo(v Attributes) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptAttributes) Get() (v Attributes, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptAttributes) Or(d Attributes) Attributes {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptBool returns new OptBool with value set to v.
+func NewOptBool(v bool) OptBool {
+	return OptBool{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptBool is optional bool.
+type OptBool struct {
+	Value bool
+	Set   bool
+}
+
+// IsSet returns true if OptBool was set.
+func (o OptBool) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptBool) Reset() {
+	var v bool
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptBool) SetTo(v bool) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptBool) Get() (v bool, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptBool) Or(d bool) bool {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptEmail returns new OptEmail with value set to v.
+func NewOptEmail(v Email) OptEmail {
+	return OptEmail{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptEmail is optional Email.
+type OptEmail struct {
+	Value Email
+	Set   bool
+}
+
+// IsSet returns true if OptEmail was set.
+func (o OptEmail) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptEmail) Reset() {
+	var v Email
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptEmail) SetTo(v Email) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptEmail) Get() (v Email, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptEmail) Or(d Email) Email {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptString returns new OptString with value set to v.
+func NewOptString(v string) OptString {
+	return OptString{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptString is optional string.
+type OptString struct {
+	Value string
+	Set   bool
+}
+
+// IsSet returns true if OptString was set.
+func (o OptString) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptString) Reset() {
+	var v string
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptString) SetTo(v string) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptString) Get() (v string, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptString) Or(d string) string {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptTenantID returns new OptTenantID with value set to v.
+func NewOptTenantID(v TenantID) OptTenantID {
+	return OptTenantID{
+		Value: v,
+		Set:   true,
+	}
+}
 
-// Ref: #/components/schemas/NewUser
-type NewUser struct {
-	// Full user name.
-	Name string `json:"name"`
-	// Unique username.
-	Username string `json:"username"`
+// OptTenantID is optional TenantID.
+type OptTenantID struct {
+	Value TenantID
+	Set   bool
 }
 
-// GetName returns the value of Name.
-func (s *NewUser) GetName() string {
-	return s.Name
+// IsSet returns true if OptTenantID was set.
+func (o OptTenantID) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptTenantID) Reset() {
+	var v TenantID
+	o.Value = v
+	o.Set = false
 }
 
-// GetUsername returns the value of Username.
-func (s *NewUser) GetUsername() string {
-	return s.Username
+// SetTo sets value to v.
+func (o *OptTenantID) SetTo(v TenantID) {
+	o.Set = true
+	o.Value = v
 }
 
-// SetName sets the value of Name.
-func (s *NewUser) SetName(val string) {
-	s.Name = val
+// Get returns value and boolean that denotes whether value was set.
+func (o OptTenantID) Get() (v TenantID, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
 }
 
-// SetUsername sets the value of Username.
-func (s *NewUser) SetUsername(val string) {
-	s.Username = val
+// Or returns value if set, or given parameter if does not.
+func (o OptTenantID) Or(d TenantID) TenantID {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
 }
 
-// NewOptString returns new OptString with value set to v.
-func NewOptString(v string) OptString {
-	return OptString{
+// NewOptUUID returns new OptUUID with value set to v.
+func NewOptUUID(v uuid.UUID) OptUUID {
+	return OptUUID{
 		Value: v,
 		Set:   true,
 	}
 }
 
-// OptString is optional string.
-type OptString struct {
-	Value string
+// OptUUID is optional uuid.UUID.
+type OptUUID struct {
+	Value uuid.UUID
 	Set   bool
 }
 
-// IsSet returns true if OptString was set.
-func (o OptString) IsSet() bool { return o.Set }
+// IsSet returns true if OptUUID was set.
+func (o OptUUID) IsSet() bool { return o.Set }
 
 // Reset unsets value.
-func (o *OptString) Reset() {
-	var v string
+func (o *OptUUID) Reset() {
+	var v uuid.UUID
 	o.Value = v
 	o.Set = false
 }
 
 // SetTo sets value to v.
-func (o *OptString) SetTo(v string) {
+func (o *OptUUID) SetTo(v uuid.UUID) {
 	o.Set = true
 	o.Value = v
 }
 
 // Get returns value and boolean that denotes whether value was set.
-func (o OptString) Get() (v string, ok bool) {
+func (o OptUUID) Get() (v uuid.UUID, ok bool) {
 	if !o.Set {
 		return v, false
 	}
@@ -88,34 +971,377 @@ func (o OptString) Get() (v string, ok bool) {
 }
 
 // Or returns value if set, or given parameter if does not.
-func (o OptString) Or(d string) string {
+func (o OptUUID) Or(d uuid.UUID) uuid.UUID {
 	if v, ok := o.Get(); ok {
 		return v
 	}
 	return d
 }
 
-// Ref: #/components/schemas/UpdateUser
-type UpdateUser struct {
-	// Full user name.
-	Name OptString `json:"name"`
+// User restricted to the properties selected by the fields parameter; a complete User when fields is
+// omitted.
+// Ref: #/components/schemas/PartialUser
+type PartialUser struct {
+	// Unique user identifier.
+	ID OptUUID `json:"id"`
+	// Name shown for the user.
+	DisplayName OptString `json:"display_name"`
 	// Unique username.
 	Username OptString `json:"username"`
+	Email    OptEmail  `json:"email"`
+	// Whether the user proved to own the email address.
+	EmailVerified OptBool       `json:"email_verified"`
+	Attributes    OptAttributes `json:"attributes"`
+}
+
+// GetID returns the value of ID.
+func (s *PartialUser) GetID() OptUUID {
+	return s.ID
+}
+
+// GetDisplayName returns the value of DisplayName.
+func (s *PartialUser) GetDisplayName() OptString {
+	return s.DisplayName
+}
+
+// GetUsername returns the value of Username.
+func (s *PartialUser) GetUsername() OptString {
+	return s.Username
+}
+
+// GetEmail returns the value of Email.
+func (s *PartialUser) GetEmail() OptEmail {
+	return s.Email
+}
+
+// GetEmailVerified returns the value of EmailVerified.
+func (s *PartialUser) GetEmailVerified() OptBool {
+	return s.EmailVerified
+}
+
+// GetAttributes returns the value of Attributes.
+func (s *PartialUser) GetAttributes() OptAttributes {
+	return s.Attributes
+}
+
+// SetID sets the value of ID.
+func (s *PartialUser) SetID(val OptUUID) {
+	s.ID = val
+}
+
+// SetDisplayName sets the value of DisplayName.
+func (s *PartialUser) SetDisplayName(val OptString) {
+	s.DisplayName = val
+}
+
+// SetUsername sets the value of Username.
+func (s *PartialUser) SetUsername(val OptString) {
+	s.Username = val
+}
+
+// SetEmail sets the value of Email.
+func (s *PartialUser) SetEmail(val OptEmail) {
+	s.Email = val
+}
+
+// SetEmailVerified sets the value of EmailVerified.
+func (s *PartialUser) SetEmailVerified(val OptBool) {
+	s.EmailVerified = val
+}
+
+// SetAttributes sets the value of Attributes.
+func (s *PartialUser) SetAttributes(val OptAttributes) {
+	s.Attributes = val
+}
+
+func (*PartialUser) getUserRes() {}
+
+type Password string
+
+// Ref: #/components/schemas/PasswordChange
+type PasswordChange struct {
+	CurrentPassword Password `json:"current_password"`
+	NewPassword     Password `json:"new_password"`
+}
+
+// GetCurrentPassword returns the value of CurrentPassword.
+func (s *PasswordChange) GetCurrentPassword() Password {
+	return s.CurrentPassword
+}
+
+// GetNewPassword returns the value of NewPassword.
+func (s *PasswordChange) GetNewPassword() Password {
+	return s.NewPassword
+}
+
+// SetCurrentPassword sets the value of CurrentPassword.
+func (s *PasswordChange) SetCurrentPassword(val Password) {
+	s.CurrentPassword = val
+}
+
+// SetNewPassword sets the value of NewPassword.
+func (s *PasswordChange) SetNewPassword(val Password) {
+	s.NewPassword = val
+}
+
+// Ref: #/components/schemas/PasswordSet
+type PasswordSet struct {
+	Password Password `json:"password"`
+}
+
+// GetPassword returns the value of Password.
+func (s *PasswordSet) GetPassword() Password {
+	return s.Password
+}
+
+// SetPassword sets the value of Password.
+func (s *PasswordSet) SetPassword(val Password) {
+	s.Password = val
+}
+
+// PutAttributeSchemaConflict is response for PutAttributeSchema operation.
+type PutAttributeSchemaConflict struct{}
+
+func (*PutAttributeSchemaConflict) putAttributeSchemaRes() {}
+
+// RemoveGroupMemberNoContent is response for RemoveGroupMember operation.
+type RemoveGroupMemberNoContent struct{}
+
+func (*RemoveGroupMemberNoContent) removeGroupMemberRes() {}
+
+// RemoveGroupMemberNotFound is response for RemoveGroupMember operation.
+type RemoveGroupMemberNotFound struct{}
+
+func (*RemoveGroupMemberNotFound) removeGroupMemberRes() {}
+
+// RequestEmailVerificationAccepted is response for RequestEmailVerification operation.
+type RequestEmailVerificationAccepted struct{}
+
+func (*RequestEmailVerificationAccepted) requestEmailVerificationRes() {}
+
+// RequestEmailVerificationConflict is response for RequestEmailVerification operation.
+type RequestEmailVerificationConflict struct{}
+
+func (*RequestEmailVerificationConflict) requestEmailVerificationRes() {}
+
+// RequestEmailVerificationNotFound is response for RequestEmailVerification operation.
+type RequestEmailVerificationNotFound struct{}
+
+func (*RequestEmailVerificationNotFound) requestEmailVerificationRes() {}
+
+// RevokeUserSessionNoContent is response for RevokeUserSession operation.
+type RevokeUserSessionNoContent struct{}
+
+func (*RevokeUserSessionNoContent) revokeUserSessionRes() {}
+
+// RevokeUserSessionNotFound is response for RevokeUserSession operation.
+type RevokeUserSessionNotFound struct{}
+
+func (*RevokeUserSessionNotFound) revokeUserSessionRes() {}
+
+// RevokeUserSessionsNoContent is response for RevokeUserSessions operation.
+type RevokeUserSessionsNoContent struct{}
+
+func (*RevokeUserSessionsNoContent) revokeUserSessionsRes() {}
+
+// RevokeUserSessionsNotFound is response for RevokeUserSessions operation.
+type RevokeUserSessionsNotFound struct{}
+
+func (*RevokeUserSessionsNotFound) revokeUserSessionsRes() {}
+
+// Ref: #/components/schemas/Session
+type Session struct {
+	// Unique session identifier.
+	ID uuid.UUID `json:"id"`
+	// When the user logged in.
+	CreatedAt time.Time `json:"created_at"`
+	// When the token last authenticated a request, to within a minute.
+	LastUsedAt time.Time `json:"last_used_at"`
+	// When the session ends unless it is used before. Use extends it, but never past the maximum
+	// lifetime counted from created_at.
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// GetID returns the value of ID.
+func (s *Session) GetID() uuid.UUID {
+	return s.ID
+}
+
+// GetCreatedAt returns the value of CreatedAt.
+func (s *Session) GetCreatedAt() time.Time {
+	return s.CreatedAt
+}
+
+// GetLastUsedAt returns the value of LastUsedAt.
+func (s *Session) GetLastUsedAt() time.Time {
+	return s.LastUsedAt
+}
+
+// GetExpiresAt returns the value of ExpiresAt.
+func (s *Session) GetExpiresAt() time.Time {
+	return s.ExpiresAt
+}
+
+// SetID sets the value of ID.
+func (s *Session) SetID(val uuid.UUID) {
+	s.ID = val
+}
+
+// SetCreatedAt sets the value of CreatedAt.
+func (s *Session) SetCreatedAt(val time.Time) {
+	s.CreatedAt = val
+}
+
+// SetLastUsedAt sets the value of LastUsedAt.
+func (s *Session) SetLastUsedAt(val time.Time) {
+	s.LastUsedAt = val
+}
+
+// SetExpiresAt sets the value of ExpiresAt.
+func (s *Session) SetExpiresAt(val time.Time) {
+	s.ExpiresAt = val
+}
+
+type SessionAuth struct {
+	APIKey string
+	Roles  []string
+}
+
+// GetAPIKey returns the value of APIKey.
+func (s *SessionAuth) GetAPIKey() string {
+	return s.APIKey
+}
+
+// GetRoles returns the value of Roles.
+func (s *SessionAuth) GetRoles() []string {
+	return s.Roles
+}
+
+// SetAPIKey sets the value of APIKey.
+func (s *SessionAuth) SetAPIKey(val string) {
+	s.APIKey = val
+}
+
+// SetRoles sets the value of Roles.
+func (s *SessionAuth) SetRoles(val []string) {
+	s.Roles = val
+}
+
+// SetUserPasswordNoContent is response for SetUserPassword operation.
+type SetUserPasswordNoContent struct{}
+
+func (*SetUserPasswordNoContent) setUserPasswordRes() {}
+
+// SetUserPasswordNotFound is response for SetUserPassword operation.
+type SetUserPasswordNotFound struct{}
+
+func (*SetUserPasswordNotFound) setUserPasswordRes() {}
+
+// Ref: #/components/schemas/Tenant
+type Tenant struct {
+	ID TenantID `json:"id"`
+	// Display name of the tenant.
+	Name string `json:"name"`
+}
+
+// GetID returns the value of ID.
+func (s *Tenant) GetID() TenantID {
+	return s.ID
+}
+
+// GetName returns the value of Name.
+func (s *Tenant) GetName() string {
+	return s.Name
+}
+
+// SetID sets the value of ID.
+func (s *Tenant) SetID(val TenantID) {
+	s.ID = val
+}
+
+// SetName sets the value of Name.
+func (s *Tenant) SetName(val string) {
+	s.Name = val
+}
+
+func (*Tenant) createTenantRes() {}
+func (*Tenant) getTenantRes()    {}
+
+type TenantID string
+
+// Ref: #/components/schemas/UpdateGroup
+type UpdateGroup struct {
+	// Unique name of the group within the tenant.
+	Name OptString `json:"name"`
+	// What the group is for.
+	Description OptString `json:"description"`
 }
 
 // GetName returns the value of Name.
-func (s *UpdateUser) GetName() OptString {
+func (s *UpdateGroup) GetName() OptString {
 	return s.Name
 }
 
+// GetDescription returns the value of Description.
+func (s *UpdateGroup) GetDescription() OptString {
+	return s.Description
+}
+
+// SetName sets the value of Name.
+func (s *UpdateGroup) SetName(val OptString) {
+	s.Name = val
+}
+
+// SetDescription sets the value of Description.
+func (s *UpdateGroup) SetDescription(val OptString) {
+	s.Description = val
+}
+
+// UpdateGroupConflict is response for UpdateGroup operation.
+type UpdateGroupConflict struct{}
+
+func (*UpdateGroupConflict) updateGroupRes() {}
+
+// UpdateGroupNotFound is response for UpdateGroup operation.
+type UpdateGroupNotFound struct{}
+
+func (*UpdateGroupNotFound) updateGroupRes() {}
+
+// Ref: #/components/schemas/UpdateUser
+type UpdateUser struct {
+	// Name shown for the user.
+	DisplayName OptString `json:"display_name"`
+	// Unique username.
+	Username OptString `json:"username"`
+	// New email address; an empty string removes it.
+	Email OptEmail `json:"email"`
+	// Replaces every attribute of the user.
+	Attributes OptAttributes `json:"attributes"`
+}
+
+// GetDisplayName returns the value of DisplayName.
+func (s *UpdateUser) GetDisplayName() OptString {
+	return s.DisplayName
+}
+
 // GetUsername returns the value of Username.
 func (s *UpdateUser) GetUsername() OptString {
 	return s.Username
 }
 
-// SetName sets the value of Name.
-func (s *UpdateUser) SetName(val OptString) {
-	s.Name = val
+// GetEmail returns the value of Email.
+func (s *UpdateUser) GetEmail() OptEmail {
+	return s.Email
+}
+
+// GetAttributes returns the value of Attributes.
+func (s *UpdateUser) GetAttributes() OptAttributes {
+	return s.Attributes
+}
+
+// SetDisplayName sets the value of DisplayName.
+func (s *UpdateUser) SetDisplayName(val OptString) {
+	s.DisplayName = val
 }
 
 // SetUsername sets the value of Username.
@@ -123,6 +1349,21 @@ func (s *UpdateUser) SetUsername(val OptString) {
 	s.Username = val
 }
 
+// SetEmail sets the value of Email.
+func (s *UpdateUser) SetEmail(val OptEmail) {
+	s.Email = val
+}
+
+// SetAttributes sets the value of Attributes.
+func (s *UpdateUser) SetAttributes(val OptAttributes) {
+	s.Attributes = val
+}
+
+// UpdateUserConflict is response for UpdateUser operation.
+type UpdateUserConflict struct{}
+
+func (*UpdateUserConflict) updateUserRes() {}
+
 // UpdateUserNotFound is response for UpdateUser operation.
 type UpdateUserNotFound struct{}
 
@@ -132,10 +1373,14 @@ func (*UpdateUserNotFound) updateUserRes() {}
 type User struct {
 	// Unique user identifier.
 	ID uuid.UUID `json:"id"`
-	// Full user name.
-	Name string `json:"name"`
+	// Name shown for the user, replacing name of version 1.
+	DisplayName string `json:"display_name"`
 	// Unique username.
-	Username string `json:"username"`
+	Username string   `json:"username"`
+	Email    OptEmail `json:"email"`
+	// Whether the user proved to own the email address; false without one.
+	EmailVerified bool       `json:"email_verified"`
+	Attributes    Attributes `json:"attributes"`
 }
 
 // GetID returns the value of ID.
@@ -143,9 +1388,9 @@ func (s *User) GetID() uuid.UUID {
 	return s.ID
 }
 
-// GetName returns the value of Name.
-func (s *User) GetName() string {
-	return s.Name
+// GetDisplayName returns the value of DisplayName.
+func (s *User) GetDisplayName() string {
+	return s.DisplayName
 }
 
 // GetUsername returns the value of Username.
@@ -153,14 +1398,29 @@ func (s *User) GetUsername() string {
 	return s.Username
 }
 
+// GetEmail returns the value of Email.
+func (s *User) GetEmail() OptEmail {
+	return s.Email
+}
+
+// GetEmailVerified returns the value of EmailVerified.
+func (s *User) GetEmailVerified() bool {
+	return s.EmailVerified
+}
+
+// GetAttributes returns the value of Attributes.
+func (s *User) GetAttributes() Attributes {
+	return s.Attributes
+}
+
 // SetID sets the value of ID.
 func (s *User) SetID(val uuid.UUID) {
 	s.ID = val
 }
 
-// SetName sets the value of Name.
-func (s *User) SetName(val string) {
-	s.Name = val
+// SetDisplayName sets the value of DisplayName.
+func (s *User) SetDisplayName(val string) {
+	s.DisplayName = val
 }
 
 // SetUsername sets the value of Username.
@@ -168,5 +1428,102 @@ func (s *User) SetUsername(val string) {
 	s.Username = val
 }
 
-func (*User) getUserRes()    {}
-func (*User) updateUserRes() {}
+// SetEmail sets the value of Email.
+func (s *User) SetEmail(val OptEmail) {
+	s.Email = val
+}
+
+// SetEmailVerified sets the value of EmailVerified.
+func (s *User) SetEmailVerified(val bool) {
+	s.EmailVerified = val
+}
+
+// SetAttributes sets the value of Attributes.
+func (s *User) SetAttributes(val Attributes) {
+	s.Attributes = val
+}
+
+func (*User) createUserRes()      {}
+func (*User) updateUserRes()      {}
+func (*User) verifyUserEmailRes() {}
+
+// Name of a User property.
+// Ref: #/components/schemas/UserField
+type UserField string
+
+const (
+	UserFieldID            UserField = "id"
+	UserFieldDisplayName   UserField = "display_name"
+	UserFieldUsername      UserField = "username"
+	UserFieldEmail         UserField = "email"
+	UserFieldEmailVerified UserField = "email_verified"
+	UserFieldAttributes    UserField = "attributes"
+)
+
+// AllValues returns all UserField values.
+func (UserField) AllValues() []UserField {
+	return []UserField{
+		UserFieldID,
+		UserFieldDisplayName,
+		UserFieldUsername,
+		UserFieldEmail,
+		UserFieldEmailVerified,
+		UserFieldAttributes,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s UserField) MarshalText() ([]byte, error) {
+	switch s {
+	case UserFieldID:
+		return []byte(s), nil
+	case UserFieldDisplayName:
+		return []byte(s), nil
+	case UserFieldUsername:
+		return []byte(s), nil
+	case UserFieldEmail:
+		return []byte(s), nil
+	case UserFieldEmailVerified:
+		return []byte(s), nil
+	case UserFieldAttributes:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *UserField) UnmarshalText(data []byte) error {
+	switch UserField(data) {
+	case UserFieldID:
+		*s = UserFieldID
+		return nil
+	case UserFieldDisplayName:
+		*s = UserFieldDisplayName
+		return nil
+	case UserFieldUsername:
+		*s = UserFieldUsername
+		return nil
+	case UserFieldEmail:
+		*s = UserFieldEmail
+		return nil
+	case UserFieldEmailVerified:
+		*s = UserFieldEmailVerified
+		return nil
+	case UserFieldAttributes:
+		*s = UserFieldAttributes
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+// VerifyUserEmailBadRequest is response for VerifyUserEmail operation.
+type VerifyUserEmailBadRequest struct{}
+
+func (*VerifyUserEmailBadRequest) verifyUserEmailRes() {}
+
+// VerifyUserEmailNotFound is response for VerifyUserEmail operation.
+type VerifyUserEmailNotFound struct{}
+
+func (*VerifyUserEmailNotFound) verifyUserEmailRes() {}
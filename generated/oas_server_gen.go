@@ -8,53 +8,200 @@ import (
 
 // Handler handles operations described by OpenAPI v3 specification.
 type Handler interface {
+	// AddGroupMember implements addGroupMember operation.
+	//
+	// Adds the user to the group. Adding a member again has no effect.
+	//
+	// POST /groups/{id}/members/{userId}
+	AddGroupMember(ctx context.Context, params AddGroupMemberParams) (AddGroupMemberRes, error)
+	// ChangeUserPassword implements changeUserPassword operation.
+	//
+	// Replaces the user's password after checking the current one. A wrong current password counts as a
+	// failed login.
+	//
+	// POST /users/{id}/password/change
+	ChangeUserPassword(ctx context.Context, req *PasswordChange, params ChangeUserPasswordParams) (ChangeUserPasswordRes, error)
+	// CreateGroup implements createGroup operation.
+	//
+	// Creates a group without members.
+	//
+	// POST /groups
+	CreateGroup(ctx context.Context, req *NewGroup, params CreateGroupParams) (CreateGroupRes, error)
+	// CreateSession implements createSession operation.
+	//
+	// Verifies a username and password and opens a session whose token authenticates later requests in
+	// the X-Session-Token header. After repeated failures the account is locked for a while, and even
+	// the right password is answered with 401.
+	//
+	// POST /sessions
+	CreateSession(ctx context.Context, req *Login, params CreateSessionParams) (CreateSessionRes, error)
+	// CreateTenant implements createTenant operation.
+	//
+	// Registers a tenant.
+	//
+	// POST /tenants
+	CreateTenant(ctx context.Context, req *Tenant) (CreateTenantRes, error)
 	// CreateUser implements createUser operation.
 	//
-	// Creates a new user.
+	// Creates a new user. A user created with an email address is sent a verification token for it.
 	//
 	// POST /users
-	CreateUser(ctx context.Context, req *NewUser) (*User, error)
+	CreateUser(ctx context.Context, req *NewUser, params CreateUserParams) (CreateUserRes, error)
+	// DeleteGroup implements deleteGroup operation.
+	//
+	// Deletes a group. Its members are not affected.
+	//
+	// DELETE /groups/{id}
+	DeleteGroup(ctx context.Context, params DeleteGroupParams) (DeleteGroupRes, error)
+	// DeleteTenant implements deleteTenant operation.
+	//
+	// Deletes a tenant that has no users. The default tenant cannot be deleted.
+	//
+	// DELETE /tenants/{id}
+	DeleteTenant(ctx context.Context, params DeleteTenantParams) (DeleteTenantRes, error)
 	// DeleteUser implements deleteUser operation.
 	//
 	// Deletes a user.
 	//
 	// DELETE /users/{id}
 	DeleteUser(ctx context.Context, params DeleteUserParams) (DeleteUserRes, error)
+	// GetAttributeSchema implements getAttributeSchema operation.
+	//
+	// Returns the custom user attributes defined for the tenant.
+	//
+	// GET /attribute-schema
+	GetAttributeSchema(ctx context.Context, params GetAttributeSchemaParams) (*AttributeSchema, error)
+	// GetGroup implements getGroup operation.
+	//
+	// Returns a group by identifier.
+	//
+	// GET /groups/{id}
+	GetGroup(ctx context.Context, params GetGroupParams) (GetGroupRes, error)
+	// GetTenant implements getTenant operation.
+	//
+	// Returns a tenant by identifier.
+	//
+	// GET /tenants/{id}
+	GetTenant(ctx context.Context, params GetTenantParams) (GetTenantRes, error)
 	// GetUser implements getUser operation.
 	//
 	// Returns a user by identifier.
 	//
 	// GET /users/{id}
 	GetUser(ctx context.Context, params GetUserParams) (GetUserRes, error)
+	// ListGroupMembers implements listGroupMembers operation.
+	//
+	// Returns the users that are members of the group.
+	//
+	// GET /groups/{id}/members
+	ListGroupMembers(ctx context.Context, params ListGroupMembersParams) (ListGroupMembersRes, error)
+	// ListGroups implements listGroups operation.
+	//
+	// Returns all groups of the tenant.
+	//
+	// GET /groups
+	ListGroups(ctx context.Context, params ListGroupsParams) ([]Group, error)
+	// ListTenants implements listTenants operation.
+	//
+	// Returns all tenants. Only principals not bound to a tenant may administer tenants.
+	//
+	// GET /tenants
+	ListTenants(ctx context.Context) ([]Tenant, error)
+	// ListUserGroups implements listUserGroups operation.
+	//
+	// Returns the groups the user is a member of.
+	//
+	// GET /users/{id}/groups
+	ListUserGroups(ctx context.Context, params ListUserGroupsParams) (ListUserGroupsRes, error)
+	// ListUserSessions implements listUserSessions operation.
+	//
+	// Returns the user's sessions that have not expired, oldest first.
+	//
+	// GET /users/{id}/sessions
+	ListUserSessions(ctx context.Context, params ListUserSessionsParams) (ListUserSessionsRes, error)
 	// ListUsers implements listUsers operation.
 	//
-	// Returns all users.
+	// Returns all users, or those whose attributes match every attribute filter.
 	//
 	// GET /users
-	ListUsers(ctx context.Context) ([]User, error)
+	ListUsers(ctx context.Context, params ListUsersParams) ([]PartialUser, error)
+	// PutAttributeSchema implements putAttributeSchema operation.
+	//
+	// Replaces the attribute schema of the tenant. The schema governs users created or updated
+	// afterwards; attributes of existing users that the schema no longer defines are kept until the
+	// user's attributes are replaced.
+	//
+	// PUT /attribute-schema
+	PutAttributeSchema(ctx context.Context, req *AttributeSchema, params PutAttributeSchemaParams) (PutAttributeSchemaRes, error)
+	// RemoveGroupMember implements removeGroupMember operation.
+	//
+	// Removes the user from the group.
+	//
+	// DELETE /groups/{id}/members/{userId}
+	RemoveGroupMember(ctx context.Context, params RemoveGroupMemberParams) (RemoveGroupMemberRes, error)
+	// RequestEmailVerification implements requestEmailVerification operation.
+	//
+	// Sends a new verification token to the user's email address. Tokens sent before stop working.
+	//
+	// POST /users/{id}/email/verification
+	RequestEmailVerification(ctx context.Context, params RequestEmailVerificationParams) (RequestEmailVerificationRes, error)
+	// RevokeUserSession implements revokeUserSession operation.
+	//
+	// Ends one session of the user; its token stops working.
+	//
+	// DELETE /users/{id}/sessions/{sessionId}
+	RevokeUserSession(ctx context.Context, params RevokeUserSessionParams) (RevokeUserSessionRes, error)
+	// RevokeUserSessions implements revokeUserSessions operation.
+	//
+	// Ends every session of the user, including the caller's own.
+	//
+	// DELETE /users/{id}/sessions
+	RevokeUserSessions(ctx context.Context, params RevokeUserSessionsParams) (RevokeUserSessionsRes, error)
+	// SetUserPassword implements setUserPassword operation.
+	//
+	// Sets the user's password without asking for the current one, and lifts a lockout. Meant for
+	// administrators; users change their own password with changeUserPassword.
+	//
+	// PUT /users/{id}/password
+	SetUserPassword(ctx context.Context, req *PasswordSet, params SetUserPasswordParams) (SetUserPasswordRes, error)
+	// UpdateGroup implements updateGroup operation.
+	//
+	// Updates group data.
+	//
+	// PUT /groups/{id}
+	UpdateGroup(ctx context.Context, req *UpdateGroup, params UpdateGroupParams) (UpdateGroupRes, error)
 	// UpdateUser implements updateUser operation.
 	//
-	// Updates user data.
+	// Updates user data. Changing the email address marks it unverified and sends a verification token
+	// to the new address.
 	//
 	// PUT /users/{id}
 	UpdateUser(ctx context.Context, req *UpdateUser, params UpdateUserParams) (UpdateUserRes, error)
+	// VerifyUserEmail implements verifyUserEmail operation.
+	//
+	// Marks the user's email address verified with a token sent to it. Each token works once.
+	//
+	// POST /users/{id}/email/verify
+	VerifyUserEmail(ctx context.Context, req *EmailVerification, params VerifyUserEmailParams) (VerifyUserEmailRes, error)
 }
 
 // Server implements http server based on OpenAPI v3 specification and
 // calls Handler to handle requests.
 type Server struct {
-	h Handler
+	h   Handler
+	sec SecurityHandler
 	baseServer
 }
 
 // NewServer creates new Server.
-func NewServer(h Handler, opts ...ServerOption) (*Server, error) {
+func NewServer(h Handler, sec SecurityHandler, opts ...ServerOption) (*Server, error) {
 	s, err := newServerConfig(opts...).baseServer()
 	if err != nil {
 		return nil, err
 	}
 	return &Server{
 		h:          h,
+		sec:        sec,
 		baseServer: s,
 	}, nil
 }
@@ -13,12 +13,78 @@ type UnimplementedHandler struct{}
 
 var _ Handler = UnimplementedHandler{}
 
+// AddGroupMember implements addGroupMember operation.
+//
+// Adds the user to the group. Adding a member again has no effect.
+//
+// POST /groups/{id}/members/{userId}
+func (UnimplementedHandler) AddGroupMember(ctx context.Context, params AddGroupMemberParams) (r AddGroupMemberRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// ChangeUserPassword implements changeUserPassword operation.
+//
+// Replaces the user's password after checking the current one. A wrong current password counts as a
+// failed login.
+//
+// POST /users/{id}/password/change
+func (UnimplementedHandler) ChangeUserPassword(ctx context.Context, req *PasswordChange, params ChangeUserPasswordParams) (r ChangeUserPasswordRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// CreateGroup implements createGroup operation.
+//
+// Creates a group without members.
+//
+// POST /groups
+func (UnimplementedHandler) CreateGroup(ctx context.Context, req *NewGroup, params CreateGroupParams) (r CreateGroupRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// CreateSession implements createSession operation.
+//
+// Verifies a username and password and opens a session whose token authenticates later requests in
+// the X-Session-Token header. After repeated failures the account is locked for a while, and even
+// the right password is answered with 401.
+//
+// POST /sessions
+func (UnimplementedHandler) CreateSession(ctx context.Context, req *Login, params CreateSessionParams) (r CreateSessionRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// CreateTenant implements createTenant operation.
+//
+// Registers a tenant.
+//
+// POST /tenants
+func (UnimplementedHandler) CreateTenant(ctx context.Context, req *Tenant) (r CreateTenantRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
 // CreateUser implements createUser operation.
 //
-// Creates a new user.
+// Creates a new user. A user created with an email address is sent a verification token for it.
 //
 // POST /users
-func (UnimplementedHandler) CreateUser(ctx context.Context, req *NewUser) (r *User, _ error) {
+func (UnimplementedHandler) CreateUser(ctx context.Context, req *NewUser, params CreateUserParams) (r CreateUserRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// DeleteGroup implements deleteGroup operation.
+//
+// Deletes a group. Its members are not affected.
+//
+// DELETE /groups/{id}
+func (UnimplementedHandler) DeleteGroup(ctx context.Context, params DeleteGroupParams) (r DeleteGroupRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// DeleteTenant implements deleteTenant operation.
+//
+// Deletes a tenant that has no users. The default tenant cannot be deleted.
+//
+// DELETE /tenants/{id}
+func (UnimplementedHandler) DeleteTenant(ctx context.Context, params DeleteTenantParams) (r DeleteTenantRes, _ error) {
 	return r, ht.ErrNotImplemented
 }
 
@@ -31,6 +97,33 @@ func (UnimplementedHandler) DeleteUser(ctx context.Context, params DeleteUserPar
 	return r, ht.ErrNotImplemented
 }
 
+// GetAttributeSchema implements getAttributeSchema operation.
+//
+// Returns the custom user attributes defined for the tenant.
+//
+// GET /attribute-schema
+func (UnimplementedHandler) GetAttributeSchema(ctx context.Context, params GetAttributeSchemaParams) (r *AttributeSchema, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// GetGroup implements getGroup operation.
+//
+// Returns a group by identifier.
+//
+// GET /groups/{id}
+func (UnimplementedHandler) GetGroup(ctx context.Context, params GetGroupParams) (r GetGroupRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// GetTenant implements getTenant operation.
+//
+// Returns a tenant by identifier.
+//
+// GET /tenants/{id}
+func (UnimplementedHandler) GetTenant(ctx context.Context, params GetTenantParams) (r GetTenantRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
 // GetUser implements getUser operation.
 //
 // Returns a user by identifier.
@@ -40,20 +133,141 @@ func (UnimplementedHandler) GetUser(ctx context.Context, params GetUserParams) (
 	return r, ht.ErrNotImplemented
 }
 
+// ListGroupMembers implements listGroupMembers operation.
+//
+// Returns the users that are members of the group.
+//
+// GET /groups/{id}/members
+func (UnimplementedHandler) ListGroupMembers(ctx context.Context, params ListGroupMembersParams) (r ListGroupMembersRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// ListGroups implements listGroups operation.
+//
+// Returns all groups of the tenant.
+//
+// GET /groups
+func (UnimplementedHandler) ListGroups(ctx context.Context, params ListGroupsParams) (r []Group, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// ListTenants implements listTenants operation.
+//
+// Returns all tenants. Only principals not bound to a tenant may administer tenants.
+//
+// GET /tenants
+func (UnimplementedHandler) ListTenants(ctx context.Context) (r []Tenant, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// ListUserGroups implements listUserGroups operation.
+//
+// Returns the groups the user is a member of.
+//
+// GET /users/{id}/groups
+func (UnimplementedHandler) ListUserGroups(ctx context.Context, params ListUserGroupsParams) (r ListUserGroupsRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// ListUserSessions implements listUserSessions operation.
+//
+// Returns the user's sessions that have not expired, oldest first.
+//
+// GET /users/{id}/sessions
+func (UnimplementedHandler) ListUserSessions(ctx context.Context, params ListUserSessionsParams) (r ListUserSessionsRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
 // ListUsers implements listUsers operation.
 //
-// Returns all users.
+// Returns all users, or those whose attributes match every attribute filter.
 //
 // GET /users
-func (UnimplementedHandler) ListUsers(ctx context.Context) (r []User, _ error) {
+func (UnimplementedHandler) ListUsers(ctx context.Context, params ListUsersParams) (r []PartialUser, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// PutAttributeSchema implements putAttributeSchema operation.
+//
+// Replaces the attribute schema of the tenant. The schema governs users created or updated
+// afterwards; attributes of existing users that the schema no longer defines are kept until the
+// user's attributes are replaced.
+//
+// PUT /attribute-schema
+func (UnimplementedHandler) PutAttributeSchema(ctx context.Context, req *AttributeSchema, params PutAttributeSchemaParams) (r PutAttributeSchemaRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// RemoveGroupMember implements removeGroupMember operation.
+//
+// Removes the user from the group.
+//
+// DELETE /groups/{id}/members/{userId}
+func (UnimplementedHandler) RemoveGroupMember(ctx context.Context, params RemoveGroupMemberParams) (r RemoveGroupMemberRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// RequestEmailVerification implements requestEmailVerification operation.
+//
+// Sends a new verification token to the user's email address. Tokens sent before stop working.
+//
+// POST /users/{id}/email/verification
+func (UnimplementedHandler) RequestEmailVerification(ctx context.Context, params RequestEmailVerificationParams) (r RequestEmailVerificationRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// RevokeUserSession implements revokeUserSession operation.
+//
+// Ends one session of the user; its token stops working.
+//
+// DELETE /users/{id}/sessions/{sessionId}
+func (UnimplementedHandler) RevokeUserSession(ctx context.Context, params RevokeUserSessionParams) (r RevokeUserSessionRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// RevokeUserSessions implements revokeUserSessions operation.
+//
+// Ends every session of the user, including the caller's own.
+//
+// DELETE /users/{id}/sessions
+func (UnimplementedHandler) RevokeUserSessions(ctx context.Context, params RevokeUserSessionsParams) (r RevokeUserSessionsRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// SetUserPassword implements setUserPassword operation.
+//
+// Sets the user's password without asking for the current one, and lifts a lockout. Meant for
+// administrators; users change their own password with changeUserPassword.
+//
+// PUT /users/{id}/password
+func (UnimplementedHandler) SetUserPassword(ctx context.Context, req *PasswordSet, params SetUserPasswordParams) (r SetUserPasswordRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// UpdateGroup implements updateGroup operation.
+//
+// Updates group data.
+//
+// PUT /groups/{id}
+func (UnimplementedHandler) UpdateGroup(ctx context.Context, req *UpdateGroup, params UpdateGroupParams) (r UpdateGroupRes, _ error) {
 	return r, ht.ErrNotImplemented
 }
 
 // UpdateUser implements updateUser operation.
 //
-// Updates user data.
+// Updates user data. Changing the email address marks it unverified and sends a verification token
+// to the new address.
 //
 // PUT /users/{id}
 func (UnimplementedHandler) UpdateUser(ctx context.Context, req *UpdateUser, params UpdateUserParams) (r UpdateUserRes, _ error) {
 	return r, ht.ErrNotImplemented
 }
+
+// VerifyUserEmail implements verifyUserEmail operation.
+//
+// Marks the user's email address verified with a token sent to it. Each token works once.
+//
+// POST /users/{id}/email/verify
+func (UnimplementedHandler) VerifyUserEmail(ctx context.Context, req *EmailVerification, params VerifyUserEmailParams) (r VerifyUserEmailRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
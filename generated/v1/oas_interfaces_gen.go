@@ -0,0 +1,30 @@
+// Code generated by ogen, DO NOT EDIT.
+package apiv1
+
+type CreateTenantRes interface {
+	createTenantRes()
+}
+
+type CreateUserRes interface {
+	createUserRes()
+}
+
+type DeleteTenantRes interface {
+	deleteTenantRes()
+}
+
+type DeleteUserRes interface {
+	deleteUserRes()
+}
+
+type GetTenantRes interface {
+	getTenantRes()
+}
+
+type GetUserRes interface {
+	getUserRes()
+}
+
+type UpdateUserRes interface {
+	updateUserRes()
+}
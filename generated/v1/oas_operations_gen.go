@@ -0,0 +1,18 @@
+// Code generated by ogen, DO NOT EDIT.
+
+package apiv1
+
+// OperationName is the ogen operation name
+type OperationName = string
+
+const (
+	CreateTenantOperation OperationName = "CreateTenant"
+	CreateUserOperation   OperationName = "CreateUser"
+	DeleteTenantOperation OperationName = "DeleteTenant"
+	DeleteUserOperation   OperationName = "DeleteUser"
+	GetTenantOperation    OperationName = "GetTenant"
+	GetUserOperation      OperationName = "GetUser"
+	ListTenantsOperation  OperationName = "ListTenants"
+	ListUsersOperation    OperationName = "ListUsers"
+	UpdateUserOperation   OperationName = "UpdateUser"
+)
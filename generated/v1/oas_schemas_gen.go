@@ -0,0 +1,363 @@
+// Code generated by ogen, DO NOT EDIT.
+
+package apiv1
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+type ApiKeyAuth struct {
+	APIKey string
+	Roles  []string
+}
+
+// GetAPIKey returns the value of APIKey.
+func (s *ApiKeyAuth) GetAPIKey() string {
+	return s.APIKey
+}
+
+// GetRoles returns the value of Roles.
+func (s *ApiKeyAuth) GetRoles() []string {
+	return s.Roles
+}
+
+// SetAPIKey sets the value of APIKey.
+func (s *ApiKeyAuth) SetAPIKey(val string) {
+	s.APIKey = val
+}
+
+// SetRoles sets the value of Roles.
+func (s *ApiKeyAuth) SetRoles(val []string) {
+	s.Roles = val
+}
+
+type BearerAuth struct {
+	Token string
+	Roles []string
+}
+
+// GetToken returns the value of Token.
+func (s *BearerAuth) GetToken() string {
+	return s.Token
+}
+
+// GetRoles returns the value of Roles.
+func (s *BearerAuth) GetRoles() []string {
+	return s.Roles
+}
+
+// SetToken sets the value of Token.
+func (s *BearerAuth) SetToken(val string) {
+	s.Token = val
+}
+
+// SetRoles sets the value of Roles.
+func (s *BearerAuth) SetRoles(val []string) {
+	s.Roles = val
+}
+
+type ClientCertAuth struct {
+	Request *http.Request
+	Roles   []string
+}
+
+// GetRequest returns the value of Request.
+func (s *ClientCertAuth) GetRequest() *http.Request {
+	return s.Request
+}
+
+// GetRoles returns the value of Roles.
+func (s *ClientCertAuth) GetRoles() []string {
+	return s.Roles
+}
+
+// SetRequest sets the value of Request.
+func (s *ClientCertAuth) SetRequest(val *http.Request) {
+	s.Request = val
+}
+
+// SetRoles sets the value of Roles.
+func (s *ClientCertAuth) SetRoles(val []string) {
+	s.Roles = val
+}
+
+// CreateTenantConflict is response for CreateTenant operation.
+type CreateTenantConflict struct{}
+
+func (*CreateTenantConflict) createTenantRes() {}
+
+// CreateUserConflict is response for CreateUser operation.
+type CreateUserConflict struct{}
+
+func (*CreateUserConflict) createUserRes() {}
+
+// DeleteTenantConflict is response for DeleteTenant operation.
+type DeleteTenantConflict struct{}
+
+func (*DeleteTenantConflict) deleteTenantRes() {}
+
+// DeleteTenantNoContent is response for DeleteTenant operation.
+type DeleteTenantNoContent struct{}
+
+func (*DeleteTenantNoContent) deleteTenantRes() {}
+
+// DeleteTenantNotFound is response for DeleteTenant operation.
+type DeleteTenantNotFound struct{}
+
+func (*DeleteTenantNotFound) deleteTenantRes() {}
+
+// DeleteUserNoContent is response for DeleteUser operation.
+type DeleteUserNoContent struct{}
+
+func (*DeleteUserNoContent) deleteUserRes() {}
+
+// DeleteUserNotFound is response for DeleteUser operation.
+type DeleteUserNotFound struct{}
+
+func (*DeleteUserNotFound) deleteUserRes() {}
+
+// GetTenantNotFound is response for GetTenant operation.
+type GetTenantNotFound struct{}
+
+func (*GetTenantNotFound) getTenantRes() {}
+
+// GetUserNotFound is response for GetUser operation.
+type GetUserNotFound struct{}
+
+func (*GetUserNotFound) getUserRes() {}
+
+// Ref: #/components/schemas/NewUser
+type NewUser struct {
+	// Full user name.
+	Name string `json:"name"`
+	// Unique username.
+	Username string `json:"username"`
+}
+
+// GetName returns the value of Name.
+func (s *NewUser) GetName() string {
+	return s.Name
+}
+
+// GetUsername returns the value of Username.
+func (s *NewUser) GetUsername() string {
+	return s.Username
+}
+
+// SetName sets the value of Name.
+func (s *NewUser) SetName(val string) {
+	s.Name = val
+}
+
+// SetUsername sets the value of Username.
+func (s *NewUser) SetUsername(val string) {
+	s.Username = val
+}
+
+// NewOptString returns new OptString with value set to v.
+func NewOptString(v string) OptString {
+	return OptString{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptString is optional string.
+type OptString struct {
+	Value string
+	Set   bool
+}
+
+// IsSet returns true if OptString was set.
+func (o OptString) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptString) Reset() {
+	var v string
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptString) SetTo(v string) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptString) Get() (v string, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptString) Or(d string) string {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptTenantID returns new OptTenantID with value set to v.
+func NewOptTenantID(v TenantID) OptTenantID {
+	return OptTenantID{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptTenantID is optional TenantID.
+type OptTenantID struct {
+	Value TenantID
+	Set   bool
+}
+
+// IsSet returns true if OptTenantID was set.
+func (o OptTenantID) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptTenantID) Reset() {
+	var v TenantID
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptTenantID) SetTo(v TenantID) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptTenantID) Get() (v TenantID, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptTenantID) Or(d TenantID) TenantID {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// Ref: #/components/schemas/Tenant
+type Tenant struct {
+	ID TenantID `json:"id"`
+	// Display name of the tenant.
+	Name string `json:"name"`
+}
+
+// GetID returns the value of ID.
+func (s *Tenant) GetID() TenantID {
+	return s.ID
+}
+
+// GetName returns the value of Name.
+func (s *Tenant) GetName() string {
+	return s.Name
+}
+
+// SetID sets the value of ID.
+func (s *Tenant) SetID(val TenantID) {
+	s.ID = val
+}
+
+// SetName sets the value of Name.
+func (s *Tenant) SetName(val string) {
+	s.Name = val
+}
+
+func (*Tenant) createTenantRes() {}
+func (*Tenant) getTenantRes()    {}
+
+type TenantID string
+
+// Ref: #/components/schemas/UpdateUser
+type UpdateUser struct {
+	// Full user name.
+	Name OptString `json:"name"`
+	// Unique username.
+	Username OptString `json:"username"`
+}
+
+// GetName returns the value of Name.
+func (s *UpdateUser) GetName() OptString {
+	return s.Name
+}
+
+// GetUsername returns the value of Username.
+func (s *UpdateUser) GetUsername() OptString {
+	return s.Username
+}
+
+// SetName sets the value of Name.
+func (s *UpdateUser) SetName(val OptString) {
+	s.Name = val
+}
+
+// SetUsername sets the value of Username.
+func (s *UpdateUser) SetUsername(val OptString) {
+	s.Username = val
+}
+
+// UpdateUserConflict is response for UpdateUser operation.
+type UpdateUserConflict struct{}
+
+func (*UpdateUserConflict) updateUserRes() {}
+
+// UpdateUserNotFound is response for UpdateUser operation.
+type UpdateUserNotFound struct{}
+
+func (*UpdateUserNotFound) updateUserRes() {}
+
+// Ref: #/components/schemas/User
+type User struct {
+	// Unique user identifier.
+	ID uuid.UUID `json:"id"`
+	// Full user name.
+	Name string `json:"name"`
+	// Unique username.
+	Username string `json:"username"`
+}
+
+// GetID returns the value of ID.
+func (s *User) GetID() uuid.UUID {
+	return s.ID
+}
+
+// GetName returns the value of Name.
+func (s *User) GetName() string {
+	return s.Name
+}
+
+// GetUsername returns the value of Username.
+func (s *User) GetUsername() string {
+	return s.Username
+}
+
+// SetID sets the value of ID.
+func (s *User) SetID(val uuid.UUID) {
+	s.ID = val
+}
+
+// SetName sets the value of Name.
+func (s *User) SetName(val string) {
+	s.Name = val
+}
+
+// SetUsername sets the value of Username.
+func (s *User) SetUsername(val string) {
+	s.Username = val
+}
+
+func (*User) createUserRes() {}
+func (*User) getUserRes()    {}
+func (*User) updateUserRes() {}
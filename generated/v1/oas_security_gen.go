@@ -0,0 +1,178 @@
+// Code generated by ogen, DO NOT EDIT.
+
+package apiv1
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/go-faster/errors"
+	"github.com/ogen-go/ogen/ogenerrors"
+)
+
+// SecurityHandler is handler for security parameters.
+type SecurityHandler interface {
+	// HandleApiKeyAuth handles apiKeyAuth security.
+	// Static API key issued to a client.
+	HandleApiKeyAuth(ctx context.Context, operationName OperationName, t ApiKeyAuth) (context.Context, error)
+	// HandleBearerAuth handles bearerAuth security.
+	// JWT issued by the platform; scopes are carried in the scope or scp claim.
+	HandleBearerAuth(ctx context.Context, operationName OperationName, t BearerAuth) (context.Context, error)
+	// HandleClientCertAuth handles clientCertAuth security.
+	// Client certificate verified against the configured CA bundle.
+	HandleClientCertAuth(ctx context.Context, operationName OperationName, t ClientCertAuth) (context.Context, error)
+}
+
+func findAuthorization(h http.Header, prefix string) (string, bool) {
+	v, ok := h["Authorization"]
+	if !ok {
+		return "", false
+	}
+	for _, vv := range v {
+		scheme, value, ok := strings.Cut(vv, " ")
+		if !ok || !strings.EqualFold(scheme, prefix) {
+			continue
+		}
+		return value, true
+	}
+	return "", false
+}
+
+var operationRolesApiKeyAuth = map[string][]string{
+	CreateTenantOperation: []string{},
+	CreateUserOperation:   []string{},
+	DeleteTenantOperation: []string{},
+	DeleteUserOperation:   []string{},
+	GetTenantOperation:    []string{},
+	GetUserOperation:      []string{},
+	ListTenantsOperation:  []string{},
+	ListUsersOperation:    []string{},
+	UpdateUserOperation:   []string{},
+}
+
+func (s *Server) securityApiKeyAuth(ctx context.Context, operationName OperationName, req *http.Request) (context.Context, bool, error) {
+	var t ApiKeyAuth
+	const parameterName = "X-API-Key"
+	value := req.Header.Get(parameterName)
+	if value == "" {
+		return ctx, false, nil
+	}
+	t.APIKey = value
+	t.Roles = operationRolesApiKeyAuth[operationName]
+	rctx, err := s.sec.HandleApiKeyAuth(ctx, operationName, t)
+	if errors.Is(err, ogenerrors.ErrSkipServerSecurity) {
+		return nil, false, nil
+	} else if err != nil {
+		return nil, false, err
+	}
+	return rctx, true, err
+}
+
+var operationRolesBearerAuth = map[string][]string{
+	CreateTenantOperation: []string{
+		"tenants:write",
+	},
+	CreateUserOperation: []string{
+		"users:write",
+	},
+	DeleteTenantOperation: []string{
+		"tenants:write",
+	},
+	DeleteUserOperation: []string{
+		"users:write",
+	},
+	GetTenantOperation: []string{
+		"tenants:read",
+	},
+	GetUserOperation: []string{
+		"users:read",
+	},
+	ListTenantsOperation: []string{
+		"tenants:read",
+	},
+	ListUsersOperation: []string{
+		"users:read",
+	},
+	UpdateUserOperation: []string{
+		"users:write",
+	},
+}
+
+func (s *Server) securityBearerAuth(ctx context.Context, operationName OperationName, req *http.Request) (context.Context, bool, error) {
+	var t BearerAuth
+	token, ok := findAuthorization(req.Header, "Bearer")
+	if !ok {
+		return ctx, false, nil
+	}
+	t.Token = token
+	t.Roles = operationRolesBearerAuth[operationName]
+	rctx, err := s.sec.HandleBearerAuth(ctx, operationName, t)
+	if errors.Is(err, ogenerrors.ErrSkipServerSecurity) {
+		return nil, false, nil
+	} else if err != nil {
+		return nil, false, err
+	}
+	return rctx, true, err
+}
+
+var operationRolesClientCertAuth = map[string][]string{
+	CreateTenantOperation: []string{},
+	CreateUserOperation:   []string{},
+	DeleteTenantOperation: []string{},
+	DeleteUserOperation:   []string{},
+	GetTenantOperation:    []string{},
+	GetUserOperation:      []string{},
+	ListTenantsOperation:  []string{},
+	ListUsersOperation:    []string{},
+	UpdateUserOperation:   []string{},
+}
+
+func (s *Server) securityClientCertAuth(ctx context.Context, operationName OperationName, req *http.Request) (context.Context, bool, error) {
+	var t ClientCertAuth
+	t.Request = req
+	t.Roles = operationRolesClientCertAuth[operationName]
+	rctx, err := s.sec.HandleClientCertAuth(ctx, operationName, t)
+	if errors.Is(err, ogenerrors.ErrSkipServerSecurity) {
+		return nil, false, nil
+	} else if err != nil {
+		return nil, false, err
+	}
+	return rctx, true, err
+}
+
+// SecuritySource is provider of security values (tokens, passwords, etc.).
+type SecuritySource interface {
+	// ApiKeyAuth provides apiKeyAuth security value.
+	// Static API key issued to a client.
+	ApiKeyAuth(ctx context.Context, operationName OperationName) (ApiKeyAuth, error)
+	// BearerAuth provides bearerAuth security value.
+	// JWT issued by the platform; scopes are carried in the scope or scp claim.
+	BearerAuth(ctx context.Context, operationName OperationName) (BearerAuth, error)
+	// ClientCertAuth provides clientCertAuth security value.
+	// Client certificate verified against the configured CA bundle.
+	ClientCertAuth(ctx context.Context, operationName OperationName, req *http.Request) error
+}
+
+func (s *Client) securityApiKeyAuth(ctx context.Context, operationName OperationName, req *http.Request) error {
+	t, err := s.sec.ApiKeyAuth(ctx, operationName)
+	if err != nil {
+		return errors.Wrap(err, "security source \"ApiKeyAuth\"")
+	}
+	req.Header.Set("X-API-Key", t.APIKey)
+	return nil
+}
+func (s *Client) securityBearerAuth(ctx context.Context, operationName OperationName, req *http.Request) error {
+	t, err := s.sec.BearerAuth(ctx, operationName)
+	if err != nil {
+		return errors.Wrap(err, "security source \"BearerAuth\"")
+	}
+	req.Header.Set("Authorization", "Bearer "+t.Token)
+	return nil
+}
+func (s *Client) securityClientCertAuth(ctx context.Context, operationName OperationName, req *http.Request) error {
+	if err := s.sec.ClientCertAuth(ctx, operationName, req); err != nil {
+		return errors.Wrap(err, "security source \"ClientCertAuth\"")
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+// Code generated by ogen, DO NOT EDIT.
+
+package apiv1
+
+import (
+	"context"
+)
+
+// Handler handles operations described by OpenAPI v3 specification.
+type Handler interface {
+	// CreateTenant implements createTenant operation.
+	//
+	// Registers a tenant.
+	//
+	// POST /tenants
+	CreateTenant(ctx context.Context, req *Tenant) (CreateTenantRes, error)
+	// CreateUser implements createUser operation.
+	//
+	// Creates a new user.
+	//
+	// POST /users
+	CreateUser(ctx context.Context, req *NewUser, params CreateUserParams) (CreateUserRes, error)
+	// DeleteTenant implements deleteTenant operation.
+	//
+	// Deletes a tenant that has no users. The default tenant cannot be deleted.
+	//
+	// DELETE /tenants/{id}
+	DeleteTenant(ctx context.Context, params DeleteTenantParams) (DeleteTenantRes, error)
+	// DeleteUser implements deleteUser operation.
+	//
+	// Deletes a user.
+	//
+	// DELETE /users/{id}
+	DeleteUser(ctx context.Context, params DeleteUserParams) (DeleteUserRes, error)
+	// GetTenant implements getTenant operation.
+	//
+	// Returns a tenant by identifier.
+	//
+	// GET /tenants/{id}
+	GetTenant(ctx context.Context, params GetTenantParams) (GetTenantRes, error)
+	// GetUser implements getUser operation.
+	//
+	// Returns a user by identifier.
+	//
+	// GET /users/{id}
+	GetUser(ctx context.Context, params GetUserParams) (GetUserRes, error)
+	// ListTenants implements listTenants operation.
+	//
+	// Returns all tenants. Only principals not bound to a tenant may administer tenants.
+	//
+	// GET /tenants
+	ListTenants(ctx context.Context) ([]Tenant, error)
+	// ListUsers implements listUsers operation.
+	//
+	// Returns all users.
+	//
+	// GET /users
+	ListUsers(ctx context.Context, params ListUsersParams) ([]User, error)
+	// UpdateUser implements updateUser operation.
+	//
+	// Updates user data.
+	//
+	// PUT /users/{id}
+	UpdateUser(ctx context.Context, req *UpdateUser, params UpdateUserParams) (UpdateUserRes, error)
+}
+
+// Server implements http server based on OpenAPI v3 specification and
+// calls Handler to handle requests.
+type Server struct {
+	h   Handler
+	sec SecurityHandler
+	baseServer
+}
+
+// NewServer creates new Server.
+func NewServer(h Handler, sec SecurityHandler, opts ...ServerOption) (*Server, error) {
+	s, err := newServerConfig(opts...).baseServer()
+	if err != nil {
+		return nil, err
+	}
+	return &Server{
+		h:          h,
+		sec:        sec,
+		baseServer: s,
+	}, nil
+}
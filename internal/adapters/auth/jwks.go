@@ -0,0 +1,179 @@
+package auth
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"os"
+	"sync"
+	"time"
+
+	xerrors "github.com/go-faster/errors"
+)
+
+const keySetReloadInterval = time.Second
+
+var (
+	errUnknownKeyID       = xerrors.New("unknown key id")
+	errUnsupportedKeyType = xerrors.New("unsupported key type")
+	errMissingKeyID       = xerrors.New("missing key id")
+)
+
+type verificationKey struct {
+	alg string
+	key any
+}
+
+// KeySet holds verification keys from a local JWKS file. Keys are selected by
+// kid; an unknown kid triggers a reload when the file has changed, so rotated
+// keys are picked up without a restart.
+type KeySet struct {
+	path string
+	now  func() time.Time
+
+	mu      sync.RWMutex
+	keys    map[string]verificationKey
+	modTime time.Time
+	checked time.Time
+}
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	K   string `json:"k"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+}
+
+func NewKeySet(path string) (*KeySet, error) {
+	set := &KeySet{path: path, now: time.Now}
+
+	if err := set.reload(); err != nil {
+		return nil, xerrors.Wrap(err, "auth.NewKeySet")
+	}
+
+	return set, nil
+}
+
+func (s *KeySet) key(kid string) (verificationKey, error) {
+	s.mu.RLock()
+	key, ok := s.keys[kid]
+	due := s.now().Sub(s.checked) >= keySetReloadInterval
+	s.mu.RUnlock()
+
+	if ok {
+		return key, nil
+	}
+
+	if due {
+		if err := s.reload(); err != nil {
+			return verificationKey{}, xerrors.Wrap(err, "reload key set")
+		}
+
+		s.mu.RLock()
+		key, ok = s.keys[kid]
+		s.mu.RUnlock()
+
+		if ok {
+			return key, nil
+		}
+	}
+
+	return verificationKey{}, xerrors.Wrapf(errUnknownKeyID, "%q", kid)
+}
+
+func (s *KeySet) reload() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.checked = s.now()
+
+	info, err := os.Stat(s.path)
+	if err != nil {
+		return xerrors.Wrap(err, "stat")
+	}
+
+	if s.keys != nil && info.ModTime().Equal(s.modTime) {
+		return nil
+	}
+
+	content, err := os.ReadFile(s.path)
+	if err != nil {
+		return xerrors.Wrap(err, "read")
+	}
+
+	var document struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+
+	if err := json.Unmarshal(content, &document); err != nil {
+		return xerrors.Wrapf(err, "decode %s", s.path)
+	}
+
+	keys := make(map[string]verificationKey, len(document.Keys))
+
+	for i, jwk := range document.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+
+		if jwk.Kid == "" {
+			return xerrors.Wrapf(errMissingKeyID, "key %d", i)
+		}
+
+		key, err := parseJSONWebKey(jwk)
+		if err != nil {
+			return xerrors.Wrapf(err, "key %q", jwk.Kid)
+		}
+
+		if jwk.Alg != "" && jwk.Alg != key.alg {
+			return xerrors.Errorf("key %q: alg %q does not match key type %q", jwk.Kid, jwk.Alg, jwk.Kty)
+		}
+
+		keys[jwk.Kid] = key
+	}
+
+	s.keys = keys
+	s.modTime = info.ModTime()
+
+	return nil
+}
+
+func parseJSONWebKey(jwk jsonWebKey) (verificationKey, error) {
+	switch {
+	case jwk.Kty == "oct":
+		secret, err := base64.RawURLEncoding.DecodeString(jwk.K)
+		if err != nil || len(secret) == 0 {
+			return verificationKey{}, xerrors.New("invalid oct key material")
+		}
+
+		return verificationKey{alg: algHS256, key: secret}, nil
+	case jwk.Kty == "RSA":
+		n, errN := base64.RawURLEncoding.DecodeString(jwk.N)
+		e, errE := base64.RawURLEncoding.DecodeString(jwk.E)
+
+		if errN != nil || errE != nil || len(n) == 0 || len(e) == 0 || len(e) > 4 {
+			return verificationKey{}, xerrors.New("invalid RSA key material")
+		}
+
+		return verificationKey{alg: algRS256, key: &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}}, nil
+	case jwk.Kty == "OKP" && jwk.Crv == "Ed25519":
+		x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+		if err != nil || len(x) != ed25519.PublicKeySize {
+			return verificationKey{}, xerrors.New("invalid Ed25519 key material")
+		}
+
+		return verificationKey{alg: algEdDSA, key: ed25519.PublicKey(x)}, nil
+	default:
+		return verificationKey{}, xerrors.Wrapf(errUnsupportedKeyType, "kty %q crv %q", jwk.Kty, jwk.Crv)
+	}
+}
@@ -0,0 +1,113 @@
+package auth
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/flexer2006/t-t-ogen-go/internal/testutil"
+)
+
+// writeKeySet writes keys as a JWKS file and moves its modification time
+// forward, so a reload sees the change even on coarse file system clocks.
+func writeKeySet(t *testing.T, path string, keys ...testKey) {
+	t.Helper()
+
+	document := struct {
+		Keys []map[string]string `json:"keys"`
+	}{}
+
+	for _, key := range keys {
+		document.Keys = append(document.Keys, key.jwk())
+	}
+
+	content, err := json.Marshal(document)
+	if err != nil {
+		t.Fatalf("encode key set: %v", err)
+	}
+
+	var modTime time.Time
+
+	if info, err := os.Stat(path); err == nil {
+		modTime = info.ModTime().Add(time.Second)
+	} else {
+		modTime = time.Now()
+	}
+
+	if err := os.WriteFile(path, content, 0o600); err != nil {
+		t.Fatalf("write key set: %v", err)
+	}
+
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatalf("touch key set: %v", err)
+	}
+}
+
+func newTestKeySet(t *testing.T, clock *testutil.Clock, keys ...testKey) (*KeySet, string) {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	writeKeySet(t, path, keys...)
+
+	set, err := NewKeySet(path)
+	if err != nil {
+		t.Fatalf("NewKeySet: %v", err)
+	}
+
+	set.now = clock.Now
+	set.checked = clock.Now()
+
+	return set, path
+}
+
+func TestKeySetReloadsOnUnknownKid(t *testing.T) {
+	first := newHMACKey(t, "first")
+	second := newHMACKey(t, "second")
+
+	clock := testutil.NewClock(testNow)
+	keys, path := newTestKeySet(t, clock, first)
+
+	writeKeySet(t, path, first, second)
+	clock.Advance(keySetReloadInterval)
+
+	key, err := keys.key(second.kid)
+	if err != nil {
+		t.Fatalf("key after rotation: %v", err)
+	}
+
+	if key.alg != algHS256 {
+		t.Errorf("alg = %q, want %q", key.alg, algHS256)
+	}
+}
+
+func TestKeySetReloadIsRateLimited(t *testing.T) {
+	first := newHMACKey(t, "first")
+	second := newHMACKey(t, "second")
+
+	clock := testutil.NewClock(testNow)
+	keys, path := newTestKeySet(t, clock, first)
+
+	// The unknown kid is checked once, which counts as the reload of this
+	// interval.
+	clock.Advance(keySetReloadInterval)
+
+	if _, err := keys.key(second.kid); !errors.Is(err, errUnknownKeyID) {
+		t.Fatalf("key before rotation: error = %v, want errUnknownKeyID", err)
+	}
+
+	writeKeySet(t, path, first, second)
+	clock.Advance(keySetReloadInterval / 2)
+
+	if _, err := keys.key(second.kid); !errors.Is(err, errUnknownKeyID) {
+		t.Fatalf("key within the reload interval: error = %v, want errUnknownKeyID", err)
+	}
+
+	clock.Advance(keySetReloadInterval / 2)
+
+	if _, err := keys.key(second.kid); err != nil {
+		t.Fatalf("key after the reload interval: %v", err)
+	}
+}
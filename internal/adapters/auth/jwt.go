@@ -0,0 +1,255 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	xerrors "github.com/go-faster/errors"
+
+	"github.com/flexer2006/t-t-ogen-go/internal/domain"
+	"github.com/flexer2006/t-t-ogen-go/internal/ports"
+)
+
+const (
+	algHS256 = "HS256"
+	algRS256 = "RS256"
+	algEdDSA = "EdDSA"
+
+	// maxNumericDate is the last second of the year 9999, far enough for any
+	// real token and small enough that adding the clock skew cannot
+	// overflow.
+	maxNumericDate = 253402300799
+)
+
+var ErrNilKeySet = xerrors.New("nil key set")
+
+type JWTOptions struct {
+	Issuer    string
+	Audience  string
+	ClockSkew time.Duration
+}
+
+type JWTVerifier struct {
+	keys    *KeySet
+	options JWTOptions
+	now     func() time.Time
+}
+
+var _ ports.TokenVerifier = (*JWTVerifier)(nil)
+
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+type jwtClaims struct {
+	Issuer    string      `json:"iss"`
+	Subject   string      `json:"sub"`
+	Audience  audience    `json:"aud"`
+	ExpiresAt json.Number `json:"exp"`
+	NotBefore json.Number `json:"nbf"`
+	Scope     string      `json:"scope"`
+	Scp       []string    `json:"scp"`
+	Roles     []string    `json:"roles"`
+	Tenant    string      `json:"tenant"`
+}
+
+func NewJWTVerifier(keys *KeySet, options JWTOptions) (*JWTVerifier, error) {
+	if keys == nil {
+		return nil, ErrNilKeySet
+	}
+
+	return &JWTVerifier{keys: keys, options: options, now: time.Now}, nil
+}
+
+func (v *JWTVerifier) VerifyToken(ctx context.Context, token string) (domain.Principal, error) {
+	if err := ctx.Err(); err != nil {
+		return domain.Principal{}, xerrors.Wrap(err, "auth.JWTVerifier.VerifyToken")
+	}
+
+	claims, err := v.verify(token)
+	if err != nil {
+		return domain.Principal{}, xerrors.Wrap(ports.ErrUnauthenticated, err.Error())
+	}
+
+	scopes := slices.Clone(claims.Scp)
+	scopes = append(scopes, strings.Fields(claims.Scope)...)
+
+	return domain.Principal{
+		Subject: claims.Subject,
+		Method:  domain.AuthMethodJWT,
+		Scopes:  scopes,
+		Roles:   claims.Roles,
+		Tenant:  claims.Tenant,
+	}, nil
+}
+
+func (v *JWTVerifier) verify(token string) (jwtClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return jwtClaims{}, xerrors.New("malformed token")
+	}
+
+	var header jwtHeader
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return jwtClaims{}, xerrors.Wrap(err, "header")
+	}
+
+	key, err := v.keys.key(header.Kid)
+	if err != nil {
+		return jwtClaims{}, err
+	}
+
+	if header.Alg != key.alg {
+		return jwtClaims{}, xerrors.Errorf("algorithm %q not allowed for key %q", header.Alg, header.Kid)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return jwtClaims{}, xerrors.New("malformed signature")
+	}
+
+	if !verifySignature(key, []byte(parts[0]+"."+parts[1]), signature) {
+		return jwtClaims{}, xerrors.New("invalid signature")
+	}
+
+	var claims jwtClaims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return jwtClaims{}, xerrors.Wrap(err, "claims")
+	}
+
+	if err := v.validate(claims); err != nil {
+		return jwtClaims{}, err
+	}
+
+	return claims, nil
+}
+
+func (v *JWTVerifier) validate(claims jwtClaims) error {
+	now := v.now()
+	skew := v.options.ClockSkew
+
+	if claims.Subject == "" {
+		return xerrors.New("missing sub claim")
+	}
+
+	expiresAt, err := numericDate(claims.ExpiresAt)
+	if err != nil || expiresAt.IsZero() {
+		return xerrors.New("missing or invalid exp claim")
+	}
+
+	if !now.Before(expiresAt.Add(skew)) {
+		return xerrors.New("token expired")
+	}
+
+	notBefore, err := numericDate(claims.NotBefore)
+	if err != nil {
+		return xerrors.New("invalid nbf claim")
+	}
+
+	if !notBefore.IsZero() && now.Before(notBefore.Add(-skew)) {
+		return xerrors.New("token not valid yet")
+	}
+
+	if v.options.Issuer != "" && claims.Issuer != v.options.Issuer {
+		return xerrors.Errorf("unexpected issuer %q", claims.Issuer)
+	}
+
+	if v.options.Audience != "" && !slices.Contains(claims.Audience, v.options.Audience) {
+		return xerrors.New("token not issued for this audience")
+	}
+
+	if claims.Tenant != "" && !domain.ValidTenantID(claims.Tenant) {
+		return xerrors.Errorf("invalid tenant %q", claims.Tenant)
+	}
+
+	return nil
+}
+
+func verifySignature(key verificationKey, signed, signature []byte) bool {
+	switch k := key.key.(type) {
+	case []byte:
+		mac := hmac.New(sha256.New, k)
+		mac.Write(signed)
+
+		return hmac.Equal(mac.Sum(nil), signature)
+	case *rsa.PublicKey:
+		digest := sha256.Sum256(signed)
+
+		return rsa.VerifyPKCS1v15(k, crypto.SHA256, digest[:], signature) == nil
+	case ed25519.PublicKey:
+		return ed25519.Verify(k, signed, signature)
+	default:
+		return false
+	}
+}
+
+func decodeSegment(segment string, target any) error {
+	raw, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return xerrors.New("malformed encoding")
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+
+	if err := decoder.Decode(target); err != nil {
+		return xerrors.New("malformed json")
+	}
+
+	return nil
+}
+
+// numericDate parses a NumericDate claim. Dates before the epoch or after
+// maxNumericDate are rejected rather than wrapped around.
+func numericDate(value json.Number) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	seconds, err := value.Float64()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if seconds < 0 || seconds > maxNumericDate {
+		return time.Time{}, xerrors.Errorf("numeric date %s out of range", value)
+	}
+
+	whole, fraction := math.Modf(seconds)
+
+	return time.Unix(int64(whole), int64(fraction*float64(time.Second))), nil
+}
+
+// audience accepts both forms allowed by RFC 7519: a single string or an
+// array of strings.
+type audience []string
+
+func (a *audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = audience{single}
+
+		return nil
+	}
+
+	var many []string
+	if err := json.Unmarshal(data, &many); err != nil {
+		return err
+	}
+
+	*a = many
+
+	return nil
+}
@@ -0,0 +1,217 @@
+package auth
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/flexer2006/t-t-ogen-go/internal/ports"
+	"github.com/flexer2006/t-t-ogen-go/internal/testutil"
+)
+
+const (
+	testIssuer   = "https://issuer.example"
+	testAudience = "user-service"
+	testSkew     = 30 * time.Second
+)
+
+var testNow = time.Unix(1_700_000_000, 0)
+
+type testKey struct {
+	kid    string
+	secret []byte
+	public ed25519.PublicKey
+}
+
+func newHMACKey(t *testing.T, kid string) testKey {
+	t.Helper()
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		t.Fatalf("generate secret: %v", err)
+	}
+
+	return testKey{kid: kid, secret: secret}
+}
+
+func (k testKey) jwk() map[string]string {
+	if k.public != nil {
+		return map[string]string{"kty": "OKP", "crv": "Ed25519", "kid": k.kid, "x": base64.RawURLEncoding.EncodeToString(k.public)}
+	}
+
+	return map[string]string{"kty": "oct", "kid": k.kid, "k": base64.RawURLEncoding.EncodeToString(k.secret)}
+}
+
+// signHS256 signs claims with an HMAC-SHA256 of secret, whatever alg the
+// header names.
+func signHS256(t *testing.T, header map[string]any, claims map[string]any, secret []byte) string {
+	t.Helper()
+
+	signed := encodeSegment(t, header) + "." + encodeSegment(t, claims)
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(signed))
+
+	return signed + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func encodeSegment(t *testing.T, value any) string {
+	t.Helper()
+
+	raw, err := json.Marshal(value)
+	if err != nil {
+		t.Fatalf("encode segment: %v", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func validClaims() map[string]any {
+	return map[string]any{
+		"sub":   "alice",
+		"iss":   testIssuer,
+		"aud":   testAudience,
+		"exp":   testNow.Add(time.Hour).Unix(),
+		"scope": "users:read users:write",
+	}
+}
+
+func TestJWTVerifierVerifyToken(t *testing.T) {
+	hmacKey := newHMACKey(t, "hmac")
+
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("generate Ed25519 key: %v", err)
+	}
+
+	edKey := testKey{kid: "ed", public: public}
+
+	signEdDSA := func(claims map[string]any) string {
+		signed := encodeSegment(t, map[string]any{"alg": algEdDSA, "kid": edKey.kid}) + "." + encodeSegment(t, claims)
+
+		return signed + "." + base64.RawURLEncoding.EncodeToString(ed25519.Sign(private, []byte(signed)))
+	}
+
+	with := func(changes map[string]any) map[string]any {
+		claims := validClaims()
+
+		for name, value := range changes {
+			if value == nil {
+				delete(claims, name)
+			} else {
+				claims[name] = value
+			}
+		}
+
+		return claims
+	}
+
+	hs256 := map[string]any{"alg": algHS256, "kid": hmacKey.kid}
+
+	tests := []struct {
+		name  string
+		token string
+		ok    bool
+	}{
+		{name: "valid HS256", token: signHS256(t, hs256, validClaims(), hmacKey.secret), ok: true},
+		{name: "valid EdDSA", token: signEdDSA(validClaims()), ok: true},
+		{
+			name:  "alg none",
+			token: encodeSegment(t, map[string]any{"alg": "none", "kid": hmacKey.kid}) + "." + encodeSegment(t, validClaims()) + ".",
+		},
+		{
+			// The public key must not be usable as an HMAC secret.
+			name:  "HS256 on an EdDSA key",
+			token: signHS256(t, map[string]any{"alg": algHS256, "kid": edKey.kid}, validClaims(), public),
+		},
+		{
+			name:  "RS256 on an HMAC key",
+			token: signHS256(t, map[string]any{"alg": algRS256, "kid": hmacKey.kid}, validClaims(), hmacKey.secret),
+		},
+		{name: "wrong secret", token: signHS256(t, hs256, validClaims(), []byte("not the secret"))},
+		{name: "malformed", token: "not.a-token"},
+		{name: "missing sub", token: signHS256(t, hs256, with(map[string]any{"sub": nil}), hmacKey.secret)},
+		{name: "missing exp", token: signHS256(t, hs256, with(map[string]any{"exp": nil}), hmacKey.secret)},
+		{
+			name:  "expired within skew",
+			token: signHS256(t, hs256, with(map[string]any{"exp": testNow.Add(-testSkew / 2).Unix()}), hmacKey.secret),
+			ok:    true,
+		},
+		{
+			name:  "expired beyond skew",
+			token: signHS256(t, hs256, with(map[string]any{"exp": testNow.Add(-testSkew).Unix()}), hmacKey.secret),
+		},
+		{
+			name:  "nbf within skew",
+			token: signHS256(t, hs256, with(map[string]any{"nbf": testNow.Add(testSkew / 2).Unix()}), hmacKey.secret),
+			ok:    true,
+		},
+		{
+			name:  "nbf beyond skew",
+			token: signHS256(t, hs256, with(map[string]any{"nbf": testNow.Add(2 * testSkew).Unix()}), hmacKey.secret),
+		},
+		{
+			name:  "fractional exp",
+			token: signHS256(t, hs256, with(map[string]any{"exp": float64(testNow.Add(time.Hour).Unix()) + 0.5}), hmacKey.secret),
+			ok:    true,
+		},
+		{
+			// Past the range of time.Duration in nanoseconds.
+			name:  "exp far in the future",
+			token: signHS256(t, hs256, with(map[string]any{"exp": 1e10}), hmacKey.secret),
+			ok:    true,
+		},
+		{name: "nbf far in the future", token: signHS256(t, hs256, with(map[string]any{"nbf": 1e10}), hmacKey.secret)},
+		{name: "exp out of range", token: signHS256(t, hs256, with(map[string]any{"exp": 1e300}), hmacKey.secret)},
+		{name: "negative exp", token: signHS256(t, hs256, with(map[string]any{"exp": -1}), hmacKey.secret)},
+		{name: "wrong issuer", token: signHS256(t, hs256, with(map[string]any{"iss": "https://other.example"}), hmacKey.secret)},
+		{name: "missing issuer", token: signHS256(t, hs256, with(map[string]any{"iss": nil}), hmacKey.secret)},
+		{name: "wrong audience", token: signHS256(t, hs256, with(map[string]any{"aud": "other-service"}), hmacKey.secret)},
+		{
+			name:  "audience list",
+			token: signHS256(t, hs256, with(map[string]any{"aud": []string{"other-service", testAudience}}), hmacKey.secret),
+			ok:    true,
+		},
+		{name: "invalid tenant", token: signHS256(t, hs256, with(map[string]any{"tenant": "Not A Tenant"}), hmacKey.secret)},
+		{name: "unknown kid", token: signHS256(t, map[string]any{"alg": algHS256, "kid": "missing"}, validClaims(), hmacKey.secret)},
+	}
+
+	clock := testutil.NewClock(testNow)
+	keys, _ := newTestKeySet(t, clock, hmacKey, edKey)
+
+	verifier, err := NewJWTVerifier(keys, JWTOptions{Issuer: testIssuer, Audience: testAudience, ClockSkew: testSkew})
+	if err != nil {
+		t.Fatalf("NewJWTVerifier: %v", err)
+	}
+
+	verifier.now = clock.Now
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			principal, err := verifier.VerifyToken(context.Background(), tt.token)
+
+			if !tt.ok {
+				if !errors.Is(err, ports.ErrUnauthenticated) {
+					t.Fatalf("VerifyToken error = %v, want ErrUnauthenticated", err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("VerifyToken: %v", err)
+			}
+
+			if principal.Subject != "alice" || !principal.HasScopes("users:read", "users:write") {
+				t.Errorf("principal = %+v, want subject alice with users:read and users:write", principal)
+			}
+		})
+	}
+}
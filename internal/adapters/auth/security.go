@@ -0,0 +1,183 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"slices"
+	"time"
+
+	xerrors "github.com/go-faster/errors"
+	"github.com/ogen-go/ogen/ogenerrors"
+
+	api "github.com/flexer2006/t-t-ogen-go/generated"
+	"github.com/flexer2006/t-t-ogen-go/internal/domain"
+	"github.com/flexer2006/t-t-ogen-go/internal/ports"
+)
+
+var ErrNoAuthenticators = xerrors.New("no authentication schemes configured")
+
+// SecurityHandler authenticates requests for the generated server. A nil
+// key store, token verifier or session authenticator disables the
+// corresponding scheme; client certificates are accepted only when
+// certificates is set.
+//
+// A request may carry only one of an API key, a bearer token and a session
+// token, since each names its own principal. A client certificate, which
+// the connection presents on every request, yields to any of them.
+type SecurityHandler struct {
+	keys         ports.APIKeyStore
+	tokens       ports.TokenVerifier
+	sessions     ports.SessionAuthenticator
+	certificates bool
+	now          func() time.Time
+}
+
+var _ api.SecurityHandler = (*SecurityHandler)(nil)
+
+func NewSecurityHandler(
+	keys ports.APIKeyStore,
+	tokens ports.TokenVerifier,
+	sessions ports.SessionAuthenticator,
+	certificates bool,
+) (*SecurityHandler, error) {
+	if keys == nil && tokens == nil && sessions == nil && !certificates {
+		return nil, ErrNoAuthenticators
+	}
+
+	return &SecurityHandler{keys: keys, tokens: tokens, sessions: sessions, certificates: certificates, now: time.Now}, nil
+}
+
+func (h *SecurityHandler) HandleApiKeyAuth(ctx context.Context, _ api.OperationName, t api.ApiKeyAuth) (context.Context, error) {
+	if err := singleCredential(ctx); err != nil {
+		return nil, err
+	}
+
+	if h.keys == nil {
+		return nil, xerrors.Wrap(ports.ErrUnauthenticated, "api keys are not accepted")
+	}
+
+	key, err := h.keys.LookupAPIKey(ctx, HashAPIKey(t.GetAPIKey()))
+	if err != nil {
+		if errors.Is(err, ports.ErrAPIKeyNotFound) {
+			return nil, xerrors.Wrap(ports.ErrUnauthenticated, "invalid api key")
+		}
+
+		return nil, xerrors.Wrap(err, "auth.SecurityHandler.HandleApiKeyAuth")
+	}
+
+	if key.Expired(h.now()) {
+		return nil, xerrors.Wrapf(ports.ErrUnauthenticated, "api key %q expired", key.Label)
+	}
+
+	return domain.ContextWithPrincipal(ctx, domain.Principal{
+		Subject: key.Label,
+		Method:  domain.AuthMethodAPIKey,
+		Roles:   slices.Clone(key.Roles),
+		Tenant:  key.Tenant,
+	}), nil
+}
+
+func (h *SecurityHandler) HandleBearerAuth(ctx context.Context, _ api.OperationName, t api.BearerAuth) (context.Context, error) {
+	if err := singleCredential(ctx); err != nil {
+		return nil, err
+	}
+
+	if h.tokens == nil {
+		return nil, xerrors.Wrap(ports.ErrUnauthenticated, "bearer tokens are not accepted")
+	}
+
+	principal, err := h.tokens.VerifyToken(ctx, t.GetToken())
+	if err != nil {
+		if errors.Is(err, ports.ErrUnauthenticated) {
+			return nil, err
+		}
+
+		return nil, xerrors.Wrap(err, "auth.SecurityHandler.HandleBearerAuth")
+	}
+
+	if required := t.GetRoles(); !principal.HasScopes(required...) {
+		return nil, xerrors.Wrapf(ports.ErrPermissionDenied, "token lacks required scopes %q", required)
+	}
+
+	return domain.ContextWithPrincipal(ctx, principal), nil
+}
+
+func (h *SecurityHandler) HandleSessionAuth(ctx context.Context, _ api.OperationName, t api.SessionAuth) (context.Context, error) {
+	if err := singleCredential(ctx); err != nil {
+		return nil, err
+	}
+
+	if h.sessions == nil {
+		return nil, xerrors.Wrap(ports.ErrUnauthenticated, "session tokens are not accepted")
+	}
+
+	principal, err := h.sessions.AuthenticateSession(ctx, t.GetAPIKey())
+	if err != nil {
+		if errors.Is(err, ports.ErrUnauthenticated) {
+			return nil, err
+		}
+
+		return nil, xerrors.Wrap(err, "auth.SecurityHandler.HandleSessionAuth")
+	}
+
+	return domain.ContextWithPrincipal(ctx, principal), nil
+}
+
+// HandleClientCertAuth authenticates the subject of a client certificate that
+// the TLS handshake already verified. Explicit credentials take precedence, so
+// a request carrying an API key or token keeps that principal.
+func (h *SecurityHandler) HandleClientCertAuth(ctx context.Context, _ api.OperationName, t api.ClientCertAuth) (context.Context, error) {
+	if _, ok := domain.PrincipalFromContext(ctx); ok {
+		return nil, ogenerrors.ErrSkipServerSecurity
+	}
+
+	state := t.GetRequest().TLS
+	if !h.certificates || state == nil || len(state.VerifiedChains) == 0 {
+		return nil, ogenerrors.ErrSkipServerSecurity
+	}
+
+	return domain.ContextWithPrincipal(ctx, certificatePrincipal(state.VerifiedChains[0][0])), nil
+}
+
+// singleCredential fails when an earlier scheme already authenticated the
+// request. The generated server runs every scheme whose credential is
+// present, and each would replace the principal of the one before.
+func singleCredential(ctx context.Context) error {
+	if _, ok := domain.PrincipalFromContext(ctx); ok {
+		return xerrors.Wrap(ports.ErrUnauthenticated, "request carries more than one credential")
+	}
+
+	return nil
+}
+
+// certificatePrincipal identifies a client by the common name of its
+// certificate, or the full subject when it has none. Organizational units
+// become roles, and an organization that is a valid tenant id binds the
+// client to that tenant.
+func certificatePrincipal(cert *x509.Certificate) domain.Principal {
+	subject := cert.Subject.CommonName
+	if subject == "" {
+		subject = cert.Subject.String()
+	}
+
+	principal := domain.Principal{
+		Subject: subject,
+		Method:  domain.AuthMethodCert,
+		Roles:   slices.Clone(cert.Subject.OrganizationalUnit),
+	}
+
+	if organizations := cert.Subject.Organization; len(organizations) > 0 && domain.ValidTenantID(organizations[0]) {
+		principal.Tenant = organizations[0]
+	}
+
+	return principal
+}
+
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+
+	return domain.APIKeyHashPrefix + hex.EncodeToString(sum[:])
+}
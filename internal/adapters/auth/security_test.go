@@ -0,0 +1,115 @@
+package auth
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/ogen-go/ogen/ogenerrors"
+
+	api "github.com/flexer2006/t-t-ogen-go/generated"
+	"github.com/flexer2006/t-t-ogen-go/internal/domain"
+	"github.com/flexer2006/t-t-ogen-go/internal/testutil"
+)
+
+// verifiedChains issues a client certificate for subject from a fresh CA
+// and returns the chains the TLS handshake would have verified.
+func verifiedChains(t *testing.T, subject pkix.Name) [][]*x509.Certificate {
+	t.Helper()
+
+	ca := testutil.NewCA(t)
+
+	cert, _ := ca.Issue(t, &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      subject,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+
+	chains, err := cert.Verify(x509.VerifyOptions{
+		Roots:     ca.Pool(),
+		KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+	if err != nil {
+		t.Fatalf("verify certificate: %v", err)
+	}
+
+	return chains
+}
+
+func TestHandleClientCertAuth(t *testing.T) {
+	tests := []struct {
+		name    string
+		subject pkix.Name
+		want    domain.Principal
+	}{
+		{
+			name: "units become roles",
+			subject: pkix.Name{
+				CommonName:         "billing-worker",
+				OrganizationalUnit: []string{"admin", "auditor"},
+			},
+			want: domain.Principal{Subject: "billing-worker", Roles: []string{"admin", "auditor"}},
+		},
+		{
+			name:    "organization binds tenant",
+			subject: pkix.Name{CommonName: "billing-worker", Organization: []string{"acme", "globex"}, OrganizationalUnit: []string{"reader"}},
+			want:    domain.Principal{Subject: "billing-worker", Roles: []string{"reader"}, Tenant: "acme"},
+		},
+		{
+			name:    "organization that is not a tenant id",
+			subject: pkix.Name{CommonName: "billing-worker", Organization: []string{"Acme Corp"}},
+			want:    domain.Principal{Subject: "billing-worker"},
+		},
+		{
+			name:    "subject without a common name",
+			subject: pkix.Name{Organization: []string{"acme"}, OrganizationalUnit: []string{"reader"}},
+			want:    domain.Principal{Subject: "OU=reader,O=acme", Roles: []string{"reader"}, Tenant: "acme"},
+		},
+	}
+
+	handler, err := NewSecurityHandler(nil, nil, nil, true)
+	if err != nil {
+		t.Fatalf("NewSecurityHandler: %v", err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/v2/users", nil)
+			req.TLS = &tls.ConnectionState{VerifiedChains: verifiedChains(t, tt.subject)}
+
+			ctx, err := handler.HandleClientCertAuth(context.Background(), api.ListUsersOperation, api.ClientCertAuth{Request: req})
+			if err != nil {
+				t.Fatalf("HandleClientCertAuth: %v", err)
+			}
+
+			principal, ok := domain.PrincipalFromContext(ctx)
+			if !ok {
+				t.Fatal("no principal in context")
+			}
+
+			if principal.Method != domain.AuthMethodCert {
+				t.Errorf("method = %q, want %q", principal.Method, domain.AuthMethodCert)
+			}
+
+			if principal.Subject != tt.want.Subject || principal.Tenant != tt.want.Tenant || !slices.Equal(principal.Roles, tt.want.Roles) {
+				t.Errorf("principal = %+v, want subject %q, roles %v, tenant %q", principal, tt.want.Subject, tt.want.Roles, tt.want.Tenant)
+			}
+		})
+	}
+
+	t.Run("unverified connection", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v2/users", nil)
+		req.TLS = &tls.ConnectionState{}
+
+		if _, err := handler.HandleClientCertAuth(context.Background(), api.ListUsersOperation, api.ClientCertAuth{Request: req}); !errors.Is(err, ogenerrors.ErrSkipServerSecurity) {
+			t.Errorf("HandleClientCertAuth error = %v, want ErrSkipServerSecurity", err)
+		}
+	})
+}
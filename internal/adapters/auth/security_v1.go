@@ -0,0 +1,32 @@
+package auth
+
+import (
+	"context"
+
+	api "github.com/flexer2006/t-t-ogen-go/generated"
+	apiv1 "github.com/flexer2006/t-t-ogen-go/generated/v1"
+)
+
+// SecurityHandlerV1 authenticates requests to the version 1 API exactly like
+// the SecurityHandler it wraps.
+type SecurityHandlerV1 struct {
+	next *SecurityHandler
+}
+
+var _ apiv1.SecurityHandler = (*SecurityHandlerV1)(nil)
+
+func (h *SecurityHandler) V1() *SecurityHandlerV1 {
+	return &SecurityHandlerV1{next: h}
+}
+
+func (h *SecurityHandlerV1) HandleApiKeyAuth(ctx context.Context, operation apiv1.OperationName, t apiv1.ApiKeyAuth) (context.Context, error) {
+	return h.next.HandleApiKeyAuth(ctx, operation, api.ApiKeyAuth(t))
+}
+
+func (h *SecurityHandlerV1) HandleBearerAuth(ctx context.Context, operation apiv1.OperationName, t apiv1.BearerAuth) (context.Context, error) {
+	return h.next.HandleBearerAuth(ctx, operation, api.BearerAuth(t))
+}
+
+func (h *SecurityHandlerV1) HandleClientCertAuth(ctx context.Context, operation apiv1.OperationName, t apiv1.ClientCertAuth) (context.Context, error) {
+	return h.next.HandleClientCertAuth(ctx, operation, api.ClientCertAuth(t))
+}
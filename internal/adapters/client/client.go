@@ -15,22 +15,60 @@ var ErrNilInvoker = xerrors.New("nil invoker")
 
 var errUnexpectedResponse = xerrors.New("unexpected response type")
 
+// invoker is the part of api.Invoker the client uses, which older API
+// versions implement through a translating adapter.
+type invoker interface {
+	ListUsers(ctx context.Context, params api.ListUsersParams) ([]api.PartialUser, error)
+	CreateUser(ctx context.Context, request *api.NewUser, params api.CreateUserParams) (api.CreateUserRes, error)
+	GetUser(ctx context.Context, params api.GetUserParams) (api.GetUserRes, error)
+	UpdateUser(ctx context.Context, request *api.UpdateUser, params api.UpdateUserParams) (api.UpdateUserRes, error)
+	DeleteUser(ctx context.Context, params api.DeleteUserParams) (api.DeleteUserRes, error)
+	RequestEmailVerification(ctx context.Context, params api.RequestEmailVerificationParams) (api.RequestEmailVerificationRes, error)
+	VerifyUserEmail(ctx context.Context, request *api.EmailVerification, params api.VerifyUserEmailParams) (api.VerifyUserEmailRes, error)
+}
+
 type Client struct {
-	invoker api.Invoker
+	invoker invoker
+	tenant  api.OptTenantID
 }
 
 var _ ports.UserService = (*Client)(nil)
 
-func New(invoker api.Invoker) (*Client, error) {
+// New returns a client whose user operations address tenant. An empty tenant
+// leaves the header unset, which suits principals bound to a tenant and
+// servers without tenancy.
+func New(invoker api.Invoker, tenant string) (*Client, error) {
 	if invoker == nil {
 		return nil, ErrNilInvoker
 	}
 
-	return &Client{invoker: invoker}, nil
+	return newClient(invoker, tenant), nil
+}
+
+func newClient(invoker invoker, tenant string) *Client {
+	client := &Client{invoker: invoker}
+
+	if tenant != "" {
+		client.tenant = api.NewOptTenantID(api.TenantID(tenant))
+	}
+
+	return client
+}
+
+func (c *Client) ListUsers(ctx context.Context, filter domain.AttributeFilter) ([]domain.User, error) {
+	return c.ListUsersWithFields(ctx, filter)
 }
 
-func (c *Client) ListUsers(ctx context.Context) ([]domain.User, error) {
-	users, err := c.invoker.ListUsers(ctx)
+// ListUsersWithFields asks the server for only the given properties; the
+// others are left zero. No fields means every property.
+func (c *Client) ListUsersWithFields(ctx context.Context, filter domain.AttributeFilter, fields ...api.UserField) ([]domain.User, error) {
+	params := api.ListUsersParams{Fields: fields, XTenantID: c.tenant}
+
+	for name, value := range filter {
+		params.Attribute = append(params.Attribute, name+":"+value)
+	}
+
+	users, err := c.invoker.ListUsers(ctx, params)
 	if err != nil {
 		return nil, xerrors.Wrap(err, "client.Client.ListUsers")
 	}
@@ -38,33 +76,55 @@ func (c *Client) ListUsers(ctx context.Context) ([]domain.User, error) {
 	result := make([]domain.User, len(users))
 
 	for i, user := range users {
-		result[i] = toDomainUser(user)
+		result[i] = partialToDomainUser(user)
 	}
 
 	return result, nil
 }
 
-func (c *Client) CreateUser(ctx context.Context, name, username string) (domain.User, error) {
-	resp, err := c.invoker.CreateUser(ctx, &api.NewUser{
-		Name:     name,
-		Username: username,
-	})
+// CreateUser reports ports.ErrUsernameTaken for any conflict, which may also
+// be an email address or a unique attribute value already in use.
+func (c *Client) CreateUser(ctx context.Context, name, username, email string, attributes domain.Attributes) (domain.User, error) {
+	payload := api.NewUser{DisplayName: name, Username: username}
+
+	if email != "" {
+		payload.Email = api.NewOptEmail(api.Email(email))
+	}
+
+	if attributes != nil {
+		payload.Attributes = api.NewOptAttributes(toAPIAttributes(attributes))
+	}
+
+	resp, err := c.invoker.CreateUser(ctx, &payload, api.CreateUserParams{XTenantID: c.tenant})
 	if err != nil {
 		return domain.User{}, xerrors.Wrap(err, "client.Client.CreateUser")
 	}
 
-	return toDomainUser(*resp), nil
+	switch result := resp.(type) {
+	case *api.User:
+		return toDomainUser(*result), nil
+	case *api.CreateUserConflict:
+		return domain.User{}, ports.ErrUsernameTaken
+	default:
+		return domain.User{}, xerrors.Wrapf(errUnexpectedResponse, "client.Client.CreateUser: %T", result)
+	}
 }
 
 func (c *Client) GetUser(ctx context.Context, id uuid.UUID) (domain.User, error) {
-	resp, err := c.invoker.GetUser(ctx, api.GetUserParams{ID: id})
+	return c.GetUserWithFields(ctx, id)
+}
+
+// GetUserWithFields is GetUser restricted to fields, like
+// ListUsersWithFields.
+func (c *Client) GetUserWithFields(ctx context.Context, id uuid.UUID, fields ...api.UserField) (domain.User, error) {
+	resp, err := c.invoker.GetUser(ctx, api.GetUserParams{ID: id, Fields: fields, XTenantID: c.tenant})
 	if err != nil {
 		return domain.User{}, xerrors.Wrap(err, "client.Client.GetUser")
 	}
 
 	switch result := resp.(type) {
-	case *api.User:
-		return toDomainUser(*result), nil
+	case *api.PartialUser:
+		return partialToDomainUser(*result), nil
 	case *api.GetUserNotFound:
 		return domain.User{}, ports.ErrUserNotFound
 	default:
@@ -72,18 +132,33 @@ func (c *Client) GetUser(ctx context.Context, id uuid.UUID) (domain.User, error)
 	}
 }
 
-func (c *Client) UpdateUser(ctx context.Context, userID uuid.UUID, name *string, username *string) (domain.User, error) {
+func (c *Client) UpdateUser(
+	ctx context.Context,
+	userID uuid.UUID,
+	name *string,
+	username *string,
+	email *string,
+	attributes domain.Attributes,
+) (domain.User, error) {
 	var payload api.UpdateUser
 
 	if name != nil {
-		payload.Name = api.NewOptString(*name)
+		payload.DisplayName = api.NewOptString(*name)
 	}
 
 	if username != nil {
 		payload.Username = api.NewOptString(*username)
 	}
 
-	resp, err := c.invoker.UpdateUser(ctx, &payload, api.UpdateUserParams{ID: userID})
+	if email != nil {
+		payload.Email = api.NewOptEmail(api.Email(*email))
+	}
+
+	if attributes != nil {
+		payload.Attributes = api.NewOptAttributes(toAPIAttributes(attributes))
+	}
+
+	resp, err := c.invoker.UpdateUser(ctx, &payload, api.UpdateUserParams{ID: userID, XTenantID: c.tenant})
 	if err != nil {
 		return domain.User{}, xerrors.Wrap(err, "client.Client.UpdateUser")
 	}
@@ -93,13 +168,15 @@ func (c *Client) UpdateUser(ctx context.Context, userID uuid.UUID, name *string,
 		return toDomainUser(*result), nil
 	case *api.UpdateUserNotFound:
 		return domain.User{}, ports.ErrUserNotFound
+	case *api.UpdateUserConflict:
+		return domain.User{}, ports.ErrUsernameTaken
 	default:
 		return domain.User{}, xerrors.Wrapf(errUnexpectedResponse, "client.Client.UpdateUser: %T", result)
 	}
 }
 
 func (c *Client) DeleteUser(ctx context.Context, id uuid.UUID) error {
-	resp, err := c.invoker.DeleteUser(ctx, api.DeleteUserParams{ID: id})
+	resp, err := c.invoker.DeleteUser(ctx, api.DeleteUserParams{ID: id, XTenantID: c.tenant})
 	if err != nil {
 		return xerrors.Wrap(err, "client.Client.DeleteUser")
 	}
@@ -114,10 +191,104 @@ func (c *Client) DeleteUser(ctx context.Context, id uuid.UUID) error {
 	}
 }
 
+func (c *Client) RequestEmailVerification(ctx context.Context, id uuid.UUID) error {
+	resp, err := c.invoker.RequestEmailVerification(ctx, api.RequestEmailVerificationParams{ID: id, XTenantID: c.tenant})
+	if err != nil {
+		return xerrors.Wrap(err, "client.Client.RequestEmailVerification")
+	}
+
+	switch result := resp.(type) {
+	case *api.RequestEmailVerificationAccepted:
+		return nil
+	case *api.RequestEmailVerificationNotFound:
+		return ports.ErrUserNotFound
+	case *api.RequestEmailVerificationConflict:
+		return ports.ErrNoEmailToVerify
+	default:
+		return xerrors.Wrapf(errUnexpectedResponse, "client.Client.RequestEmailVerification: %T", result)
+	}
+}
+
+func (c *Client) VerifyEmail(ctx context.Context, id uuid.UUID, token string) (domain.User, error) {
+	resp, err := c.invoker.VerifyUserEmail(ctx, &api.EmailVerification{Token: token}, api.VerifyUserEmailParams{ID: id, XTenantID: c.tenant})
+	if err != nil {
+		return domain.User{}, xerrors.Wrap(err, "client.Client.VerifyEmail")
+	}
+
+	switch result := resp.(type) {
+	case *api.User:
+		return toDomainUser(*result), nil
+	case *api.VerifyUserEmailNotFound:
+		return domain.User{}, ports.ErrUserNotFound
+	case *api.VerifyUserEmailBadRequest:
+		return domain.User{}, ports.ErrInvalidVerificationToken
+	default:
+		return domain.User{}, xerrors.Wrapf(errUnexpectedResponse, "client.Client.VerifyEmail: %T", result)
+	}
+}
+
 func toDomainUser(user api.User) domain.User {
 	return domain.User{
-		ID:       user.GetID(),
-		Name:     user.GetName(),
-		Username: user.GetUsername(),
+		ID:            user.GetID(),
+		Name:          user.GetDisplayName(),
+		Username:      user.GetUsername(),
+		Email:         string(user.GetEmail().Or("")),
+		EmailVerified: user.GetEmailVerified(),
+		Attributes:    fromAPIAttributes(user.GetAttributes()),
 	}
 }
+
+func partialToDomainUser(user api.PartialUser) domain.User {
+	return domain.User{
+		ID:            user.ID.Or(uuid.Nil),
+		Name:          user.DisplayName.Or(""),
+		Username:      user.Username.Or(""),
+		Email:         string(user.Email.Or("")),
+		EmailVerified: user.EmailVerified.Or(false),
+		Attributes:    fromAPIAttributes(user.Attributes.Or(nil)),
+	}
+}
+
+func toAPIAttributes(attributes domain.Attributes) api.Attributes {
+	result := make(api.Attributes, len(attributes))
+
+	for name, value := range attributes {
+		switch value := value.(type) {
+		case string:
+			result[name] = api.NewStringAttributeValue(value)
+		case int64:
+			result[name] = api.NewFloat64AttributeValue(float64(value))
+		case int:
+			result[name] = api.NewFloat64AttributeValue(float64(value))
+		case float64:
+			result[name] = api.NewFloat64AttributeValue(value)
+		case bool:
+			result[name] = api.NewBoolAttributeValue(value)
+		}
+	}
+
+	return result
+}
+
+// fromAPIAttributes returns numbers as float64, since the response does not
+// say which attributes are integers.
+func fromAPIAttributes(attributes api.Attributes) domain.Attributes {
+	if attributes == nil {
+		return nil
+	}
+
+	result := make(domain.Attributes, len(attributes))
+
+	for name, value := range attributes {
+		switch value.Type {
+		case api.Float64AttributeValue:
+			result[name] = value.Float64
+		case api.BoolAttributeValue:
+			result[name] = value.Bool
+		default:
+			result[name] = value.String
+		}
+	}
+
+	return result
+}
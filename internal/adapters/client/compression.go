@@ -0,0 +1,71 @@
+package client
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	xerrors "github.com/go-faster/errors"
+	ht "github.com/ogen-go/ogen/http"
+)
+
+const acceptEncoding = "gzip, deflate"
+
+type decompressingClient struct {
+	next ht.Client
+}
+
+// NewDecompressingClient asks for gzip or deflate responses and decodes them.
+// net/http only decodes gzip on its own, and only while it sets
+// Accept-Encoding itself.
+func NewDecompressingClient(next ht.Client) ht.Client {
+	return &decompressingClient{next: next}
+}
+
+func (c *decompressingClient) Do(req *http.Request) (*http.Response, error) {
+	if req.Header.Get("Accept-Encoding") == "" {
+		req.Header.Set("Accept-Encoding", acceptEncoding)
+	}
+
+	resp, err := c.next.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var decoded io.ReadCloser
+
+	switch strings.ToLower(strings.TrimSpace(resp.Header.Get("Content-Encoding"))) {
+	case "gzip":
+		decoded, err = gzip.NewReader(resp.Body)
+	case "deflate":
+		decoded, err = zlib.NewReader(resp.Body)
+	default:
+		return resp, nil
+	}
+
+	if err != nil {
+		resp.Body.Close()
+
+		return nil, xerrors.Wrap(err, "client.decompressingClient.Do: decode response")
+	}
+
+	resp.Body = &decodedBody{ReadCloser: decoded, raw: resp.Body}
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+
+	return resp, nil
+}
+
+type decodedBody struct {
+	io.ReadCloser
+	raw io.Closer
+}
+
+func (b *decodedBody) Close() error {
+	return errors.Join(b.ReadCloser.Close(), b.raw.Close())
+}
@@ -0,0 +1,54 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/ogen-go/ogen/ogenerrors"
+
+	api "github.com/flexer2006/t-t-ogen-go/generated"
+)
+
+type Credentials struct {
+	APIKey      string
+	BearerToken string
+	// SessionToken is the token returned by a login.
+	SessionToken string
+	// ClientCertificate reports that the transport presents a TLS client
+	// certificate, which satisfies the clientCertAuth scheme on its own.
+	ClientCertificate bool
+}
+
+var _ api.SecuritySource = Credentials{}
+
+func (c Credentials) ApiKeyAuth(context.Context, api.OperationName) (api.ApiKeyAuth, error) {
+	if c.APIKey == "" {
+		return api.ApiKeyAuth{}, ogenerrors.ErrSkipClientSecurity
+	}
+
+	return api.ApiKeyAuth{APIKey: c.APIKey}, nil
+}
+
+func (c Credentials) BearerAuth(context.Context, api.OperationName) (api.BearerAuth, error) {
+	if c.BearerToken == "" {
+		return api.BearerAuth{}, ogenerrors.ErrSkipClientSecurity
+	}
+
+	return api.BearerAuth{Token: c.BearerToken}, nil
+}
+
+func (c Credentials) SessionAuth(context.Context, api.OperationName) (api.SessionAuth, error) {
+	if c.SessionToken == "" {
+		return api.SessionAuth{}, ogenerrors.ErrSkipClientSecurity
+	}
+
+	return api.SessionAuth{APIKey: c.SessionToken}, nil
+}
+
+func (c Credentials) ClientCertAuth(context.Context, api.OperationName, *http.Request) error {
+	if !c.ClientCertificate {
+		return ogenerrors.ErrSkipClientSecurity
+	}
+
+	return nil
+}
@@ -0,0 +1,189 @@
+package client
+
+import (
+	"context"
+
+	xerrors "github.com/go-faster/errors"
+
+	api "github.com/flexer2006/t-t-ogen-go/generated"
+	apiv1 "github.com/flexer2006/t-t-ogen-go/generated/v1"
+)
+
+var errUnsupportedV1 = xerrors.New("not supported by API version 1")
+
+// CredentialsV1 supplies Credentials to the version 1 client.
+type CredentialsV1 struct {
+	Credentials
+}
+
+var _ apiv1.SecuritySource = CredentialsV1{}
+
+func (c CredentialsV1) ApiKeyAuth(ctx context.Context, operation apiv1.OperationName) (apiv1.ApiKeyAuth, error) {
+	auth, err := c.Credentials.ApiKeyAuth(ctx, operation)
+
+	return apiv1.ApiKeyAuth(auth), err
+}
+
+func (c CredentialsV1) BearerAuth(ctx context.Context, operation apiv1.OperationName) (apiv1.BearerAuth, error) {
+	auth, err := c.Credentials.BearerAuth(ctx, operation)
+
+	return apiv1.BearerAuth(auth), err
+}
+
+// NewV1 returns a client that talks to the deprecated version 1 API.
+func NewV1(invoker apiv1.Invoker, tenant string) (*Client, error) {
+	if invoker == nil {
+		return nil, ErrNilInvoker
+	}
+
+	return newClient(&v1Invoker{next: invoker}, tenant), nil
+}
+
+// v1Invoker translates calls and responses between the current and the
+// version 1 types.
+type v1Invoker struct {
+	next apiv1.Invoker
+}
+
+// ListUsers and GetUser return whole users, since version 1 has no fields
+// parameter. Version 1 has no attributes or email addresses, so calls that
+// use them fail instead of silently dropping them.
+func (i *v1Invoker) ListUsers(ctx context.Context, params api.ListUsersParams) ([]api.PartialUser, error) {
+	if len(params.Attribute) > 0 {
+		return nil, xerrors.Wrap(errUnsupportedV1, "client.v1Invoker.ListUsers: attribute filter")
+	}
+
+	users, err := i.next.ListUsers(ctx, apiv1.ListUsersParams{XTenantID: v1Tenant(params.XTenantID)})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]api.PartialUser, len(users))
+
+	for j, user := range users {
+		result[j] = fromV1PartialUser(user)
+	}
+
+	return result, nil
+}
+
+func (i *v1Invoker) CreateUser(ctx context.Context, request *api.NewUser, params api.CreateUserParams) (api.CreateUserRes, error) {
+	if request.Attributes.Set || request.Email.Set {
+		return nil, xerrors.Wrap(errUnsupportedV1, "client.v1Invoker.CreateUser: attributes or email")
+	}
+
+	resp, err := i.next.CreateUser(ctx, &apiv1.NewUser{
+		Name:     request.DisplayName,
+		Username: request.Username,
+	}, apiv1.CreateUserParams{XTenantID: v1Tenant(params.XTenantID)})
+	if err != nil {
+		return nil, err
+	}
+
+	switch result := resp.(type) {
+	case *apiv1.User:
+		user := fromV1User(*result)
+
+		return &user, nil
+	case *apiv1.CreateUserConflict:
+		return &api.CreateUserConflict{}, nil
+	default:
+		return nil, xerrors.Wrapf(errUnexpectedResponse, "client.v1Invoker.CreateUser: %T", result)
+	}
+}
+
+func (i *v1Invoker) GetUser(ctx context.Context, params api.GetUserParams) (api.GetUserRes, error) {
+	resp, err := i.next.GetUser(ctx, apiv1.GetUserParams{ID: params.ID, XTenantID: v1Tenant(params.XTenantID)})
+	if err != nil {
+		return nil, err
+	}
+
+	switch result := resp.(type) {
+	case *apiv1.User:
+		user := fromV1PartialUser(*result)
+
+		return &user, nil
+	case *apiv1.GetUserNotFound:
+		return &api.GetUserNotFound{}, nil
+	default:
+		return nil, xerrors.Wrapf(errUnexpectedResponse, "client.v1Invoker.GetUser: %T", result)
+	}
+}
+
+func (i *v1Invoker) UpdateUser(ctx context.Context, request *api.UpdateUser, params api.UpdateUserParams) (api.UpdateUserRes, error) {
+	if request.Attributes.Set || request.Email.Set {
+		return nil, xerrors.Wrap(errUnsupportedV1, "client.v1Invoker.UpdateUser: attributes or email")
+	}
+
+	payload := apiv1.UpdateUser{Username: apiv1.OptString(request.Username)}
+
+	if name, ok := request.DisplayName.Get(); ok {
+		payload.Name = apiv1.NewOptString(name)
+	}
+
+	resp, err := i.next.UpdateUser(ctx, &payload, apiv1.UpdateUserParams{ID: params.ID, XTenantID: v1Tenant(params.XTenantID)})
+	if err != nil {
+		return nil, err
+	}
+
+	switch result := resp.(type) {
+	case *apiv1.User:
+		user := fromV1User(*result)
+
+		return &user, nil
+	case *apiv1.UpdateUserNotFound:
+		return &api.UpdateUserNotFound{}, nil
+	case *apiv1.UpdateUserConflict:
+		return &api.UpdateUserConflict{}, nil
+	default:
+		return nil, xerrors.Wrapf(errUnexpectedResponse, "client.v1Invoker.UpdateUser: %T", result)
+	}
+}
+
+func (i *v1Invoker) DeleteUser(ctx context.Context, params api.DeleteUserParams) (api.DeleteUserRes, error) {
+	resp, err := i.next.DeleteUser(ctx, apiv1.DeleteUserParams{ID: params.ID, XTenantID: v1Tenant(params.XTenantID)})
+	if err != nil {
+		return nil, err
+	}
+
+	switch result := resp.(type) {
+	case *apiv1.DeleteUserNoContent:
+		return &api.DeleteUserNoContent{}, nil
+	case *apiv1.DeleteUserNotFound:
+		return &api.DeleteUserNotFound{}, nil
+	default:
+		return nil, xerrors.Wrapf(errUnexpectedResponse, "client.v1Invoker.DeleteUser: %T", result)
+	}
+}
+
+func (i *v1Invoker) RequestEmailVerification(context.Context, api.RequestEmailVerificationParams) (api.RequestEmailVerificationRes, error) {
+	return nil, xerrors.Wrap(errUnsupportedV1, "client.v1Invoker.RequestEmailVerification")
+}
+
+func (i *v1Invoker) VerifyUserEmail(context.Context, *api.EmailVerification, api.VerifyUserEmailParams) (api.VerifyUserEmailRes, error) {
+	return nil, xerrors.Wrap(errUnsupportedV1, "client.v1Invoker.VerifyUserEmail")
+}
+
+func v1Tenant(tenant api.OptTenantID) apiv1.OptTenantID {
+	if value, ok := tenant.Get(); ok {
+		return apiv1.NewOptTenantID(apiv1.TenantID(value))
+	}
+
+	return apiv1.OptTenantID{}
+}
+
+func fromV1User(user apiv1.User) api.User {
+	return api.User{
+		ID:          user.ID,
+		DisplayName: user.Name,
+		Username:    user.Username,
+	}
+}
+
+func fromV1PartialUser(user apiv1.User) api.PartialUser {
+	return api.PartialUser{
+		ID:          api.NewOptUUID(user.ID),
+		DisplayName: api.NewOptString(user.Name),
+		Username:    api.NewOptString(user.Username),
+	}
+}
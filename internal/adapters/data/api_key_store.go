@@ -0,0 +1,45 @@
+package data
+
+import (
+	"context"
+
+	xerrors "github.com/go-faster/errors"
+
+	"github.com/flexer2006/t-t-ogen-go/internal/domain"
+	"github.com/flexer2006/t-t-ogen-go/internal/ports"
+)
+
+var errDuplicateAPIKey = xerrors.New("duplicate api key")
+
+type StaticAPIKeyStore struct {
+	keys map[string]domain.APIKey
+}
+
+var _ ports.APIKeyStore = (*StaticAPIKeyStore)(nil)
+
+func NewStaticAPIKeyStore(keys []domain.APIKey) (*StaticAPIKeyStore, error) {
+	store := &StaticAPIKeyStore{keys: make(map[string]domain.APIKey, len(keys))}
+
+	for _, key := range keys {
+		if _, ok := store.keys[key.Hash]; ok {
+			return nil, xerrors.Wrapf(errDuplicateAPIKey, "data.NewStaticAPIKeyStore: %q", key.Label)
+		}
+
+		store.keys[key.Hash] = key
+	}
+
+	return store, nil
+}
+
+func (s *StaticAPIKeyStore) LookupAPIKey(ctx context.Context, hash string) (domain.APIKey, error) {
+	if err := ctx.Err(); err != nil {
+		return domain.APIKey{}, xerrors.Wrap(err, "data.StaticAPIKeyStore.LookupAPIKey")
+	}
+
+	key, ok := s.keys[hash]
+	if !ok {
+		return domain.APIKey{}, ports.ErrAPIKeyNotFound
+	}
+
+	return key, nil
+}
@@ -0,0 +1,125 @@
+package data
+
+import (
+	"context"
+	"math"
+	"slices"
+
+	xerrors "github.com/go-faster/errors"
+	"github.com/google/uuid"
+
+	"github.com/flexer2006/t-t-ogen-go/internal/domain"
+	"github.com/flexer2006/t-t-ogen-go/internal/ports"
+)
+
+func (s *InMemoryUserStorage) GetAttributeSchema(ctx context.Context, tenantID string) ([]domain.AttributeDefinition, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, xerrors.Wrap(err, "data.InMemoryUserStorage.GetAttributeSchema")
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if _, ok := s.tenants[tenantID]; !ok {
+		return nil, ports.ErrTenantNotFound
+	}
+
+	return cloneSchema(s.schemas[tenantID]), nil
+}
+
+// PutAttributeSchema refuses a schema that makes an attribute unique while
+// existing users share one of its values.
+func (s *InMemoryUserStorage) PutAttributeSchema(ctx context.Context, tenantID string, schema []domain.AttributeDefinition) error {
+	if err := ctx.Err(); err != nil {
+		return xerrors.Wrap(err, "data.InMemoryUserStorage.PutAttributeSchema")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.tenants[tenantID]; !ok {
+		return ports.ErrTenantNotFound
+	}
+
+	for _, definition := range schema {
+		if !definition.Unique {
+			continue
+		}
+
+		seen := make(map[any]struct{})
+
+		for _, userID := range s.usernames[tenantID] {
+			value, ok := s.users[userID].user.Attributes[definition.Name]
+			if !ok {
+				continue
+			}
+
+			if _, dup := seen[attributeKey(value)]; dup {
+				return xerrors.Wrapf(ports.ErrAttributeNotUnique, "attribute %q", definition.Name)
+			}
+
+			seen[attributeKey(value)] = struct{}{}
+		}
+	}
+
+	s.schemas[tenantID] = cloneSchema(schema)
+
+	return nil
+}
+
+// attributeTaken reports whether a user of the tenant other than userID
+// already holds one of the values that attributes sets for a unique
+// attribute. Tenants have few unique attributes, so a scan is cheaper than
+// keeping an index per attribute.
+func (s *InMemoryUserStorage) attributeTaken(tenantID string, userID uuid.UUID, attributes domain.Attributes) bool {
+	for _, definition := range s.schemas[tenantID] {
+		value, ok := attributes[definition.Name]
+		if !definition.Unique || !ok {
+			continue
+		}
+
+		for _, otherID := range s.usernames[tenantID] {
+			if otherID == userID {
+				continue
+			}
+
+			if other, ok := s.users[otherID].user.Attributes[definition.Name]; ok && attributeKey(other) == attributeKey(value) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func matches(attributes, match domain.Attributes) bool {
+	for name, want := range match {
+		if got, ok := attributes[name]; !ok || attributeKey(got) != attributeKey(want) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// attributeKey makes a number compare equal whether it is held as int64 or
+// float64. Values stored before the schema changed an attribute's type keep
+// their old representation.
+func attributeKey(value any) any {
+	if number, ok := value.(float64); ok && number == math.Trunc(number) && number >= math.MinInt64 && number < math.MaxInt64 {
+		return int64(number)
+	}
+
+	return value
+}
+
+func cloneSchema(schema []domain.AttributeDefinition) []domain.AttributeDefinition {
+	result := make([]domain.AttributeDefinition, len(schema))
+
+	for i, definition := range schema {
+		definition.Enum = slices.Clone(definition.Enum)
+		result[i] = definition
+	}
+
+	return result
+}
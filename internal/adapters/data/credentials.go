@@ -0,0 +1,170 @@
+package data
+
+import (
+	"context"
+	"time"
+
+	xerrors "github.com/go-faster/errors"
+	"github.com/google/uuid"
+
+	"github.com/flexer2006/t-t-ogen-go/internal/domain"
+	"github.com/flexer2006/t-t-ogen-go/internal/ports"
+)
+
+func (s *InMemoryUserStorage) GetUserByUsername(ctx context.Context, tenantID, username string) (domain.User, error) {
+	if err := ctx.Err(); err != nil {
+		return domain.User{}, xerrors.Wrap(err, "data.InMemoryUserStorage.GetUserByUsername")
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	index, ok := s.usernames[tenantID]
+	if !ok {
+		return domain.User{}, ports.ErrTenantNotFound
+	}
+
+	userID, ok := index[username]
+	if !ok {
+		return domain.User{}, ports.ErrUserNotFound
+	}
+
+	return cloneUser(s.users[userID].user), nil
+}
+
+func (s *InMemoryUserStorage) GetCredential(ctx context.Context, tenantID string, userID uuid.UUID) (domain.Credential, error) {
+	if err := ctx.Err(); err != nil {
+		return domain.Credential{}, xerrors.Wrap(err, "data.InMemoryUserStorage.GetCredential")
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.credential(tenantID, userID)
+}
+
+func (s *InMemoryUserStorage) SetPassword(ctx context.Context, tenantID string, userID uuid.UUID, hash string) error {
+	if err := ctx.Err(); err != nil {
+		return xerrors.Wrap(err, "data.InMemoryUserStorage.SetPassword")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.lookup(tenantID, userID); err != nil {
+		return err
+	}
+
+	s.credentials[userID] = domain.Credential{PasswordHash: hash}
+	s.endSessions(userID)
+
+	return nil
+}
+
+func (s *InMemoryUserStorage) RecordLoginFailure(
+	ctx context.Context,
+	tenantID string,
+	userID uuid.UUID,
+	now time.Time,
+	policy domain.LockoutPolicy,
+) (domain.Credential, error) {
+	if err := ctx.Err(); err != nil {
+		return domain.Credential{}, xerrors.Wrap(err, "data.InMemoryUserStorage.RecordLoginFailure")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	credential, err := s.credential(tenantID, userID)
+	if err != nil {
+		return domain.Credential{}, err
+	}
+
+	credential.Failures++
+
+	if policy.MaxFailures > 0 && credential.Failures >= policy.MaxFailures {
+		credential.Failures = 0
+		credential.LockedUntil = now.Add(policy.Duration)
+	}
+
+	s.credentials[userID] = credential
+
+	return credential, nil
+}
+
+func (s *InMemoryUserStorage) RecordLoginSuccess(
+	ctx context.Context,
+	tenantID string,
+	userID uuid.UUID,
+	now time.Time,
+	hash, rehash string,
+) error {
+	if err := ctx.Err(); err != nil {
+		return xerrors.Wrap(err, "data.InMemoryUserStorage.RecordLoginSuccess")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	credential, err := s.verifiedCredential(tenantID, userID, now, hash)
+	if err != nil {
+		return err
+	}
+
+	credential.Failures = 0
+
+	if rehash != "" {
+		credential.PasswordHash = rehash
+	}
+
+	s.credentials[userID] = credential
+
+	return nil
+}
+
+// loginSuccessChanges reports whether RecordLoginSuccess would change the
+// credential, and fails where it would.
+func (s *InMemoryUserStorage) loginSuccessChanges(tenantID string, userID uuid.UUID, now time.Time, hash, rehash string) (bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	credential, err := s.verifiedCredential(tenantID, userID, now, hash)
+	if err != nil {
+		return false, err
+	}
+
+	return credential.Failures > 0 || rehash != "", nil
+}
+
+// verifiedCredential returns the credential that a password verified against
+// hash at now logs in to. Another login may have locked the account, or the
+// password may have been replaced, while the password was being checked.
+func (s *InMemoryUserStorage) verifiedCredential(tenantID string, userID uuid.UUID, now time.Time, hash string) (domain.Credential, error) {
+	credential, err := s.credential(tenantID, userID)
+	if err != nil {
+		return domain.Credential{}, err
+	}
+
+	if credential.Locked(now) {
+		return domain.Credential{}, &ports.LockedError{Until: credential.LockedUntil}
+	}
+
+	if credential.PasswordHash != hash {
+		return domain.Credential{}, ports.ErrInvalidCredentials
+	}
+
+	return credential, nil
+}
+
+func (s *InMemoryUserStorage) credential(tenantID string, userID uuid.UUID) (domain.Credential, error) {
+	if _, err := s.lookup(tenantID, userID); err != nil {
+		return domain.Credential{}, err
+	}
+
+	credential, ok := s.credentials[userID]
+	if !ok {
+		return domain.Credential{}, ports.ErrNoPassword
+	}
+
+	return credential, nil
+}
@@ -0,0 +1,107 @@
+package data
+
+import (
+	"context"
+	"crypto/subtle"
+	"time"
+
+	xerrors "github.com/go-faster/errors"
+	"github.com/google/uuid"
+
+	"github.com/flexer2006/t-t-ogen-go/internal/domain"
+	"github.com/flexer2006/t-t-ogen-go/internal/ports"
+)
+
+func (s *InMemoryUserStorage) PutEmailVerification(ctx context.Context, tenantID string, userID uuid.UUID, token domain.VerificationToken) error {
+	if err := ctx.Err(); err != nil {
+		return xerrors.Wrap(err, "data.InMemoryUserStorage.PutEmailVerification")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, err := s.lookup(tenantID, userID)
+	if err != nil {
+		return err
+	}
+
+	// The address may have changed since the caller read the user.
+	if stored.user.Email == "" || stored.user.EmailVerified || stored.user.Email != token.Email {
+		return ports.ErrNoEmailToVerify
+	}
+
+	s.verifications[userID] = token
+
+	return nil
+}
+
+func (s *InMemoryUserStorage) ConsumeEmailVerification(ctx context.Context, tenantID string, userID uuid.UUID, hash []byte, now time.Time) (domain.User, error) {
+	if err := ctx.Err(); err != nil {
+		return domain.User{}, xerrors.Wrap(err, "data.InMemoryUserStorage.ConsumeEmailVerification")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, err := s.lookup(tenantID, userID)
+	if err != nil {
+		return domain.User{}, err
+	}
+
+	pending, ok := s.verifications[userID]
+	if !ok {
+		return domain.User{}, ports.ErrInvalidVerificationToken
+	}
+
+	if !now.Before(pending.ExpiresAt) {
+		delete(s.verifications, userID)
+
+		return domain.User{}, ports.ErrInvalidVerificationToken
+	}
+
+	if subtle.ConstantTimeCompare(pending.Hash, hash) != 1 || domain.EmailKey(pending.Email) != domain.EmailKey(stored.user.Email) {
+		return domain.User{}, ports.ErrInvalidVerificationToken
+	}
+
+	delete(s.verifications, userID)
+
+	user := stored.user
+	user.EmailVerified = true
+	s.users[userID] = tenantUser{tenant: tenantID, user: user}
+
+	return cloneUser(user), nil
+}
+
+func (s *InMemoryUserStorage) emailTaken(tenantID string, userID uuid.UUID, email string) bool {
+	if email == "" {
+		return false
+	}
+
+	owner, taken := s.emails[tenantID][domain.EmailKey(email)]
+
+	return taken && owner != userID
+}
+
+// setEmail changes the address of user, which must then be stored by the
+// caller. A new address is unverified and invalidates the pending
+// verification.
+func (s *InMemoryUserStorage) setEmail(tenantID string, user *domain.User, email string) {
+	index := s.emails[tenantID]
+
+	if user.Email != "" {
+		delete(index, domain.EmailKey(user.Email))
+	}
+
+	if email != "" {
+		index[domain.EmailKey(email)] = user.ID
+	}
+
+	// A change of case only keeps the same mailbox.
+	if domain.EmailKey(email) != domain.EmailKey(user.Email) {
+		user.EmailVerified = false
+
+		delete(s.verifications, user.ID)
+	}
+
+	user.Email = email
+}
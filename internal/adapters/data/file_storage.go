@@ -0,0 +1,913 @@
+package data
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	xerrors "github.com/go-faster/errors"
+	"github.com/google/uuid"
+
+	"github.com/flexer2006/t-t-ogen-go/internal/domain"
+	"github.com/flexer2006/t-t-ogen-go/internal/ports"
+)
+
+// FileUserStorage keeps the data in memory and writes a snapshot of it to a
+// file after each change.
+type FileUserStorage struct {
+	// mu serializes changes; readers use memory without it.
+	mu     sync.Mutex
+	path   string
+	memory atomic.Pointer[InMemoryUserStorage]
+	// sealed rejects changes once another process owns the file.
+	sealed bool
+}
+
+var (
+	_ ports.UserRepository              = (*FileUserStorage)(nil)
+	_ ports.TenantRepository            = (*FileUserStorage)(nil)
+	_ ports.GroupRepository             = (*FileUserStorage)(nil)
+	_ ports.AttributeSchemaRepository   = (*FileUserStorage)(nil)
+	_ ports.EmailVerificationRepository = (*FileUserStorage)(nil)
+	_ ports.CredentialRepository        = (*FileUserStorage)(nil)
+	_ ports.SessionRepository           = (*FileUserStorage)(nil)
+)
+
+type fileUser struct {
+	ID         uuid.UUID      `json:"id"`
+	Tenant     string         `json:"tenant,omitempty"`
+	Name       string         `json:"name"`
+	Username   string         `json:"username"`
+	Email      string         `json:"email,omitempty"`
+	Verified   bool           `json:"email_verified,omitempty"`
+	Attributes map[string]any `json:"attributes,omitempty"`
+	// Verification is the pending email verification, if any.
+	Verification *fileVerification `json:"verification,omitempty"`
+	Password     *fileCredential   `json:"password,omitempty"`
+	Sessions     []fileSession     `json:"sessions,omitempty"`
+}
+
+type fileSession struct {
+	ID            uuid.UUID `json:"id"`
+	TokenHash     []byte    `json:"token_hash"`
+	CreatedAt     time.Time `json:"created_at"`
+	LastUsedAt    time.Time `json:"last_used_at"`
+	IdleExpiresAt time.Time `json:"idle_expires_at"`
+	ExpiresAt     time.Time `json:"expires_at"`
+}
+
+type fileCredential struct {
+	Hash        string     `json:"hash"`
+	Failures    int        `json:"failures,omitempty"`
+	LockedUntil *time.Time `json:"locked_until,omitempty"`
+}
+
+type fileVerification struct {
+	Hash      []byte    `json:"hash"`
+	Email     string    `json:"email"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type fileAttribute struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Required    bool   `json:"required,omitempty"`
+	Unique      bool   `json:"unique,omitempty"`
+	Enum        []any  `json:"enum,omitempty"`
+	Pattern     string `json:"pattern,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+type fileTenant struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type fileGroup struct {
+	ID          uuid.UUID   `json:"id"`
+	Tenant      string      `json:"tenant"`
+	Name        string      `json:"name"`
+	Description string      `json:"description,omitempty"`
+	Members     []uuid.UUID `json:"members,omitempty"`
+}
+
+type fileSnapshot struct {
+	Tenants []fileTenant `json:"tenants"`
+	Users   []fileUser   `json:"users"`
+	Groups  []fileGroup  `json:"groups,omitempty"`
+	// AttributeSchemas are keyed by tenant.
+	AttributeSchemas map[string][]fileAttribute `json:"attribute_schemas,omitempty"`
+}
+
+var (
+	errDuplicateUsername  = xerrors.New("duplicate username")
+	errDuplicateEmail     = xerrors.New("duplicate email address")
+	errDuplicateGroupName = xerrors.New("duplicate group name")
+	errUnknownTenant      = xerrors.New("entry of unknown tenant")
+	errUnknownMember      = xerrors.New("member is not a user of the tenant")
+)
+
+func NewFileUserStorage(path string) (*FileUserStorage, error) {
+	storage := &FileUserStorage{path: path}
+
+	content, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, xerrors.Wrap(err, "data.NewFileUserStorage: read")
+	}
+
+	memory, err := decodeSnapshot(content)
+	if err != nil {
+		return nil, xerrors.Wrapf(err, "data.NewFileUserStorage: %s", path)
+	}
+
+	storage.memory.Store(memory)
+
+	return storage, nil
+}
+
+func (s *FileUserStorage) ListUsers(ctx context.Context, tenantID string, match domain.Attributes) ([]domain.User, error) {
+	return s.memory.Load().ListUsers(ctx, tenantID, match)
+}
+
+func (s *FileUserStorage) CountUsers(ctx context.Context) (int, error) {
+	return s.memory.Load().CountUsers(ctx)
+}
+
+func (s *FileUserStorage) CreateUser(ctx context.Context, tenantID, name, username, email string, attributes domain.Attributes) (domain.User, error) {
+	var user domain.User
+
+	err := s.update("data.FileUserStorage.CreateUser", func(memory *InMemoryUserStorage) (err error) {
+		user, err = memory.CreateUser(ctx, tenantID, name, username, email, attributes)
+
+		return err
+	})
+	if err != nil {
+		return domain.User{}, err
+	}
+
+	return user, nil
+}
+
+func (s *FileUserStorage) GetUser(ctx context.Context, tenantID string, userID uuid.UUID) (domain.User, error) {
+	return s.memory.Load().GetUser(ctx, tenantID, userID)
+}
+
+func (s *FileUserStorage) UpdateUser(
+	ctx context.Context,
+	tenantID string,
+	userID uuid.UUID,
+	name *string,
+	username *string,
+	email *string,
+	attributes domain.Attributes,
+) (domain.User, error) {
+	var user domain.User
+
+	err := s.update("data.FileUserStorage.UpdateUser", func(memory *InMemoryUserStorage) (err error) {
+		user, err = memory.UpdateUser(ctx, tenantID, userID, name, username, email, attributes)
+
+		return err
+	})
+	if err != nil {
+		return domain.User{}, err
+	}
+
+	return user, nil
+}
+
+func (s *FileUserStorage) DeleteUser(ctx context.Context, tenantID string, userID uuid.UUID) error {
+	return s.update("data.FileUserStorage.DeleteUser", func(memory *InMemoryUserStorage) error {
+		return memory.DeleteUser(ctx, tenantID, userID)
+	})
+}
+
+func (s *FileUserStorage) ListTenants(ctx context.Context) ([]domain.Tenant, error) {
+	return s.memory.Load().ListTenants(ctx)
+}
+
+func (s *FileUserStorage) CreateTenant(ctx context.Context, tenant domain.Tenant) (domain.Tenant, error) {
+	var created domain.Tenant
+
+	err := s.update("data.FileUserStorage.CreateTenant", func(memory *InMemoryUserStorage) (err error) {
+		created, err = memory.CreateTenant(ctx, tenant)
+
+		return err
+	})
+	if err != nil {
+		return domain.Tenant{}, err
+	}
+
+	return created, nil
+}
+
+func (s *FileUserStorage) GetTenant(ctx context.Context, id string) (domain.Tenant, error) {
+	return s.memory.Load().GetTenant(ctx, id)
+}
+
+func (s *FileUserStorage) DeleteTenant(ctx context.Context, id string) error {
+	return s.update("data.FileUserStorage.DeleteTenant", func(memory *InMemoryUserStorage) error {
+		return memory.DeleteTenant(ctx, id)
+	})
+}
+
+func (s *FileUserStorage) ListGroups(ctx context.Context, tenantID string) ([]domain.Group, error) {
+	return s.memory.Load().ListGroups(ctx, tenantID)
+}
+
+func (s *FileUserStorage) CreateGroup(ctx context.Context, tenantID, name, description string) (domain.Group, error) {
+	var group domain.Group
+
+	err := s.update("data.FileUserStorage.CreateGroup", func(memory *InMemoryUserStorage) (err error) {
+		group, err = memory.CreateGroup(ctx, tenantID, name, description)
+
+		return err
+	})
+	if err != nil {
+		return domain.Group{}, err
+	}
+
+	return group, nil
+}
+
+func (s *FileUserStorage) GetGroup(ctx context.Context, tenantID string, groupID uuid.UUID) (domain.Group, error) {
+	return s.memory.Load().GetGroup(ctx, tenantID, groupID)
+}
+
+func (s *FileUserStorage) UpdateGroup(ctx context.Context, tenantID string, groupID uuid.UUID, name *string, description *string) (domain.Group, error) {
+	var group domain.Group
+
+	err := s.update("data.FileUserStorage.UpdateGroup", func(memory *InMemoryUserStorage) (err error) {
+		group, err = memory.UpdateGroup(ctx, tenantID, groupID, name, description)
+
+		return err
+	})
+	if err != nil {
+		return domain.Group{}, err
+	}
+
+	return group, nil
+}
+
+func (s *FileUserStorage) DeleteGroup(ctx context.Context, tenantID string, groupID uuid.UUID) error {
+	return s.update("data.FileUserStorage.DeleteGroup", func(memory *InMemoryUserStorage) error {
+		return memory.DeleteGroup(ctx, tenantID, groupID)
+	})
+}
+
+func (s *FileUserStorage) ListGroupMembers(ctx context.Context, tenantID string, groupID uuid.UUID) ([]domain.User, error) {
+	return s.memory.Load().ListGroupMembers(ctx, tenantID, groupID)
+}
+
+func (s *FileUserStorage) AddGroupMember(ctx context.Context, tenantID string, groupID, userID uuid.UUID) error {
+	return s.update("data.FileUserStorage.AddGroupMember", func(memory *InMemoryUserStorage) error {
+		return memory.AddGroupMember(ctx, tenantID, groupID, userID)
+	})
+}
+
+func (s *FileUserStorage) RemoveGroupMember(ctx context.Context, tenantID string, groupID, userID uuid.UUID) error {
+	return s.update("data.FileUserStorage.RemoveGroupMember", func(memory *InMemoryUserStorage) error {
+		return memory.RemoveGroupMember(ctx, tenantID, groupID, userID)
+	})
+}
+
+func (s *FileUserStorage) ListUserGroups(ctx context.Context, tenantID string, userID uuid.UUID) ([]domain.Group, error) {
+	return s.memory.Load().ListUserGroups(ctx, tenantID, userID)
+}
+
+func (s *FileUserStorage) GetAttributeSchema(ctx context.Context, tenantID string) ([]domain.AttributeDefinition, error) {
+	return s.memory.Load().GetAttributeSchema(ctx, tenantID)
+}
+
+func (s *FileUserStorage) PutAttributeSchema(ctx context.Context, tenantID string, schema []domain.AttributeDefinition) error {
+	return s.update("data.FileUserStorage.PutAttributeSchema", func(memory *InMemoryUserStorage) error {
+		return memory.PutAttributeSchema(ctx, tenantID, schema)
+	})
+}
+
+func (s *FileUserStorage) PutEmailVerification(ctx context.Context, tenantID string, userID uuid.UUID, token domain.VerificationToken) error {
+	return s.update("data.FileUserStorage.PutEmailVerification", func(memory *InMemoryUserStorage) error {
+		return memory.PutEmailVerification(ctx, tenantID, userID, token)
+	})
+}
+
+// ConsumeEmailVerification also persists after a failure, which may have
+// dropped an expired verification.
+func (s *FileUserStorage) ConsumeEmailVerification(ctx context.Context, tenantID string, userID uuid.UUID, hash []byte, now time.Time) (domain.User, error) {
+	var (
+		user       domain.User
+		consumeErr error
+	)
+
+	err := s.update("data.FileUserStorage.ConsumeEmailVerification", func(memory *InMemoryUserStorage) error {
+		user, consumeErr = memory.ConsumeEmailVerification(ctx, tenantID, userID, hash, now)
+		if consumeErr != nil && !errors.Is(consumeErr, ports.ErrInvalidVerificationToken) {
+			return consumeErr
+		}
+
+		return nil
+	})
+	if err != nil {
+		return domain.User{}, err
+	}
+
+	return user, consumeErr
+}
+
+func (s *FileUserStorage) GetUserByUsername(ctx context.Context, tenantID, username string) (domain.User, error) {
+	return s.memory.Load().GetUserByUsername(ctx, tenantID, username)
+}
+
+func (s *FileUserStorage) GetCredential(ctx context.Context, tenantID string, userID uuid.UUID) (domain.Credential, error) {
+	return s.memory.Load().GetCredential(ctx, tenantID, userID)
+}
+
+func (s *FileUserStorage) SetPassword(ctx context.Context, tenantID string, userID uuid.UUID, hash string) error {
+	return s.update("data.FileUserStorage.SetPassword", func(memory *InMemoryUserStorage) error {
+		return memory.SetPassword(ctx, tenantID, userID, hash)
+	})
+}
+
+func (s *FileUserStorage) RecordLoginFailure(
+	ctx context.Context,
+	tenantID string,
+	userID uuid.UUID,
+	now time.Time,
+	policy domain.LockoutPolicy,
+) (domain.Credential, error) {
+	var credential domain.Credential
+
+	err := s.update("data.FileUserStorage.RecordLoginFailure", func(memory *InMemoryUserStorage) (err error) {
+		credential, err = memory.RecordLoginFailure(ctx, tenantID, userID, now, policy)
+
+		return err
+	})
+	if err != nil {
+		return domain.Credential{}, err
+	}
+
+	return credential, nil
+}
+
+// RecordLoginSuccess checks the live data first and leaves the file alone
+// when the login changes nothing, which is the common case, so that logging
+// in stays cheap.
+func (s *FileUserStorage) RecordLoginSuccess(
+	ctx context.Context,
+	tenantID string,
+	userID uuid.UUID,
+	now time.Time,
+	hash, rehash string,
+) error {
+	return s.updateIf("data.FileUserStorage.RecordLoginSuccess", func(memory *InMemoryUserStorage) (bool, error) {
+		return memory.loginSuccessChanges(tenantID, userID, now, hash, rehash)
+	}, func(memory *InMemoryUserStorage) error {
+		return memory.RecordLoginSuccess(ctx, tenantID, userID, now, hash, rehash)
+	})
+}
+
+func (s *FileUserStorage) CreateSession(ctx context.Context, session domain.Session) error {
+	return s.update("data.FileUserStorage.CreateSession", func(memory *InMemoryUserStorage) error {
+		return memory.CreateSession(ctx, session)
+	})
+}
+
+func (s *FileUserStorage) GetSessionByToken(ctx context.Context, tokenHash []byte, now time.Time) (domain.Session, error) {
+	return s.memory.Load().GetSessionByToken(ctx, tokenHash, now)
+}
+
+// TouchSession writes the new idle expiry to the file, so that a restart
+// or a handoff keeps the session alive. The session service touches a
+// session at most once per touch interval, and a touch that an earlier one
+// already covered writes nothing.
+func (s *FileUserStorage) TouchSession(ctx context.Context, id uuid.UUID, usedAt, idleExpiresAt time.Time) error {
+	return s.updateIf("data.FileUserStorage.TouchSession", func(memory *InMemoryUserStorage) (bool, error) {
+		return memory.touchChanges(id, usedAt)
+	}, func(memory *InMemoryUserStorage) error {
+		return memory.TouchSession(ctx, id, usedAt, idleExpiresAt)
+	})
+}
+
+func (s *FileUserStorage) ListSessions(ctx context.Context, tenantID string, userID uuid.UUID, now time.Time) ([]domain.Session, error) {
+	return s.memory.Load().ListSessions(ctx, tenantID, userID, now)
+}
+
+func (s *FileUserStorage) DeleteSession(ctx context.Context, tenantID string, userID, sessionID uuid.UUID) error {
+	return s.update("data.FileUserStorage.DeleteSession", func(memory *InMemoryUserStorage) error {
+		return memory.DeleteSession(ctx, tenantID, userID, sessionID)
+	})
+}
+
+func (s *FileUserStorage) DeleteUserSessions(ctx context.Context, tenantID string, userID uuid.UUID) error {
+	return s.update("data.FileUserStorage.DeleteUserSessions", func(memory *InMemoryUserStorage) error {
+		return memory.DeleteUserSessions(ctx, tenantID, userID)
+	})
+}
+
+// DeleteExpiredSessions rewrites the file only when a session expired.
+func (s *FileUserStorage) DeleteExpiredSessions(ctx context.Context, now time.Time) (int, error) {
+	var deleted int
+
+	err := s.updateIf("data.FileUserStorage.DeleteExpiredSessions", func(memory *InMemoryUserStorage) (bool, error) {
+		return memory.hasExpiredSessions(now), nil
+	}, func(memory *InMemoryUserStorage) (err error) {
+		deleted, err = memory.DeleteExpiredSessions(ctx, now)
+
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return deleted, nil
+}
+
+// Flush rewrites the snapshot from memory. Every change is written as it is
+// made, so this only confirms at shutdown that the file is current and still
+// writable. Sealed storage is left alone, since the file belongs to another
+// process.
+func (s *FileUserStorage) Flush(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return xerrors.Wrap(err, "data.FileUserStorage.Flush")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.sealed {
+		return nil
+	}
+
+	if err := s.persist(s.memory.Load()); err != nil {
+		return xerrors.Wrap(err, "data.FileUserStorage.Flush")
+	}
+
+	return nil
+}
+
+// Seal flushes the data and rejects every later change with
+// ports.ErrStorageReadOnly, so that another process can load the file
+// without losing writes made here after it did.
+func (s *FileUserStorage) Seal(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return xerrors.Wrap(err, "data.FileUserStorage.Seal")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.persist(s.memory.Load()); err != nil {
+		return xerrors.Wrap(err, "data.FileUserStorage.Seal")
+	}
+
+	s.sealed = true
+
+	return nil
+}
+
+// Unseal accepts changes again after Seal, for when the other process never
+// took over.
+func (s *FileUserStorage) Unseal() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sealed = false
+}
+
+// update applies change to a copy of the data and makes the copy live only
+// once it is on disk, so a failed write leaves memory as it was.
+func (s *FileUserStorage) update(op string, change func(memory *InMemoryUserStorage) error) error {
+	return s.updateIf(op, nil, change)
+}
+
+// updateIf is update for changes that often turn out to have nothing to do.
+// pending looks at the live data first; when it reports nothing to do, the
+// data is neither copied nor written.
+func (s *FileUserStorage) updateIf(
+	op string,
+	pending func(memory *InMemoryUserStorage) (bool, error),
+	change func(memory *InMemoryUserStorage) error,
+) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.sealed {
+		return xerrors.Wrap(ports.ErrStorageReadOnly, op)
+	}
+
+	live := s.memory.Load()
+
+	if pending != nil {
+		if ok, err := pending(live); err != nil || !ok {
+			return err
+		}
+	}
+
+	next := live.clone()
+
+	if err := change(next); err != nil {
+		return err
+	}
+
+	if err := s.persist(next); err != nil {
+		return xerrors.Wrap(err, op)
+	}
+
+	s.memory.Store(next)
+
+	return nil
+}
+
+// persist writes a full snapshot to a temporary file and renames it over the
+// data file, so a crash never leaves a partially written snapshot behind.
+func (s *FileUserStorage) persist(memory *InMemoryUserStorage) error {
+	content, err := json.MarshalIndent(memory.snapshot(), "", "  ")
+	if err != nil {
+		return xerrors.Wrap(err, "encode")
+	}
+
+	dir := filepath.Dir(s.path)
+
+	tmp, err := os.CreateTemp(dir, filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return xerrors.Wrap(err, "create temp file")
+	}
+
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if _, err := tmp.Write(content); err != nil {
+		_ = tmp.Close()
+
+		return xerrors.Wrap(err, "write temp file")
+	}
+
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+
+		return xerrors.Wrap(err, "sync temp file")
+	}
+
+	if err := tmp.Close(); err != nil {
+		return xerrors.Wrap(err, "close temp file")
+	}
+
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return xerrors.Wrap(err, "rename temp file")
+	}
+
+	// The rename survives a crash only once the directory is synced too.
+	directory, err := os.Open(dir)
+	if err != nil {
+		return xerrors.Wrap(err, "open directory")
+	}
+
+	syncErr := directory.Sync()
+	closeErr := directory.Close()
+
+	if err := errors.Join(syncErr, closeErr); err != nil {
+		return xerrors.Wrap(err, "sync directory")
+	}
+
+	return nil
+}
+
+// CheckWritable verifies that snapshots can still be written next to the
+// data file.
+func (s *FileUserStorage) CheckWritable(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return xerrors.Wrap(err, "data.FileUserStorage.CheckWritable")
+	}
+
+	probe, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*.probe")
+	if err != nil {
+		return xerrors.Wrap(err, "data.FileUserStorage.CheckWritable")
+	}
+
+	closeErr := probe.Close()
+	removeErr := os.Remove(probe.Name())
+
+	if err := errors.Join(closeErr, removeErr); err != nil {
+		return xerrors.Wrap(err, "data.FileUserStorage.CheckWritable")
+	}
+
+	return nil
+}
+
+// decodeSnapshot loads the content of a data file into new storage.
+func decodeSnapshot(content []byte) (*InMemoryUserStorage, error) {
+	memory := NewInMemoryUserStorage()
+
+	content = bytes.TrimSpace(content)
+	if len(content) == 0 {
+		return memory, nil
+	}
+
+	var (
+		snapshot fileSnapshot
+		err      error
+	)
+
+	// Files written before tenancy hold a plain array of users, which all
+	// belong to the default tenant.
+	if content[0] == '[' {
+		err = json.Unmarshal(content, &snapshot.Users)
+	} else {
+		err = json.Unmarshal(content, &snapshot)
+	}
+
+	if err != nil {
+		return nil, xerrors.Wrap(err, "decode")
+	}
+
+	if err := memory.restore(snapshot); err != nil {
+		return nil, err
+	}
+
+	return memory, nil
+}
+
+// clone copies the data so that changing the copy leaves s as it is. Stored
+// entries are always replaced rather than modified in place, so copying the
+// maps, including the per-tenant and membership indexes inside them, is
+// enough.
+func (s *InMemoryUserStorage) clone() *InMemoryUserStorage {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return &InMemoryUserStorage{
+		tenants:     maps.Clone(s.tenants),
+		users:       maps.Clone(s.users),
+		usernames:   cloneIndex(s.usernames),
+		emails:      cloneIndex(s.emails),
+		groups:      maps.Clone(s.groups),
+		groupNames:  cloneIndex(s.groupNames),
+		members:     cloneIndex(s.members),
+		memberships: cloneIndex(s.memberships),
+		schemas:     maps.Clone(s.schemas),
+
+		verifications: maps.Clone(s.verifications),
+		credentials:   maps.Clone(s.credentials),
+		sessions:      maps.Clone(s.sessions),
+		sessionTokens: maps.Clone(s.sessionTokens),
+		userSessions:  cloneIndex(s.userSessions),
+	}
+}
+
+func cloneIndex[K, L comparable, V any](index map[K]map[L]V) map[K]map[L]V {
+	result := make(map[K]map[L]V, len(index))
+
+	for key, inner := range index {
+		result[key] = maps.Clone(inner)
+	}
+
+	return result
+}
+
+func (s *InMemoryUserStorage) snapshot() fileSnapshot {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	snapshot := fileSnapshot{
+		Tenants: make([]fileTenant, 0, len(s.tenants)),
+		Users:   make([]fileUser, 0, len(s.users)),
+	}
+
+	for _, tenant := range s.tenants {
+		snapshot.Tenants = append(snapshot.Tenants, fileTenant{ID: tenant.ID, Name: tenant.Name})
+	}
+
+	for _, stored := range s.users {
+		user := fileUser{
+			ID:         stored.user.ID,
+			Tenant:     stored.tenant,
+			Name:       stored.user.Name,
+			Username:   stored.user.Username,
+			Email:      stored.user.Email,
+			Verified:   stored.user.EmailVerified,
+			Attributes: stored.user.Attributes,
+		}
+
+		if pending, ok := s.verifications[stored.user.ID]; ok {
+			user.Verification = &fileVerification{Hash: pending.Hash, Email: pending.Email, ExpiresAt: pending.ExpiresAt}
+		}
+
+		if credential, ok := s.credentials[stored.user.ID]; ok {
+			user.Password = &fileCredential{Hash: credential.PasswordHash, Failures: credential.Failures}
+
+			if !credential.LockedUntil.IsZero() {
+				user.Password.LockedUntil = &credential.LockedUntil
+			}
+		}
+
+		for id := range s.userSessions[stored.user.ID] {
+			session := s.sessions[id]
+			user.Sessions = append(user.Sessions, fileSession{
+				ID:            session.ID,
+				TokenHash:     session.TokenHash,
+				CreatedAt:     session.CreatedAt,
+				LastUsedAt:    session.LastUsedAt,
+				IdleExpiresAt: session.IdleExpiresAt,
+				ExpiresAt:     session.ExpiresAt,
+			})
+		}
+
+		slices.SortFunc(user.Sessions, func(a, b fileSession) int { return a.CreatedAt.Compare(b.CreatedAt) })
+
+		snapshot.Users = append(snapshot.Users, user)
+	}
+
+	for tenantID, schema := range s.schemas {
+		if len(schema) == 0 {
+			continue
+		}
+
+		if snapshot.AttributeSchemas == nil {
+			snapshot.AttributeSchemas = make(map[string][]fileAttribute)
+		}
+
+		attributes := make([]fileAttribute, len(schema))
+
+		for i, definition := range schema {
+			attributes[i] = fileAttribute{
+				Name:        definition.Name,
+				Type:        string(definition.Type),
+				Required:    definition.Required,
+				Unique:      definition.Unique,
+				Enum:        definition.Enum,
+				Pattern:     definition.Pattern,
+				Description: definition.Description,
+			}
+		}
+
+		snapshot.AttributeSchemas[tenantID] = attributes
+	}
+
+	for _, stored := range s.groups {
+		group := fileGroup{
+			ID:          stored.group.ID,
+			Tenant:      stored.tenant,
+			Name:        stored.group.Name,
+			Description: stored.group.Description,
+		}
+
+		for userID := range s.members[stored.group.ID] {
+			group.Members = append(group.Members, userID)
+		}
+
+		snapshot.Groups = append(snapshot.Groups, group)
+	}
+
+	return snapshot
+}
+
+func (s *InMemoryUserStorage) restore(snapshot fileSnapshot) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, tenant := range snapshot.Tenants {
+		s.addTenant(domain.Tenant{ID: tenant.ID, Name: tenant.Name})
+	}
+
+	for tenantID, attributes := range snapshot.AttributeSchemas {
+		if _, ok := s.tenants[tenantID]; !ok {
+			return xerrors.Wrapf(errUnknownTenant, "attribute schema: tenant %q", tenantID)
+		}
+
+		schema := make([]domain.AttributeDefinition, len(attributes))
+
+		for i, attribute := range attributes {
+			schema[i] = domain.AttributeDefinition{
+				Name:        attribute.Name,
+				Type:        domain.AttributeType(attribute.Type),
+				Required:    attribute.Required,
+				Unique:      attribute.Unique,
+				Enum:        attribute.Enum,
+				Pattern:     attribute.Pattern,
+				Description: attribute.Description,
+			}
+
+			for j, value := range schema[i].Enum {
+				schema[i].Enum[j] = restoreAttributeValue(schema[i].Type, value)
+			}
+		}
+
+		s.schemas[tenantID] = schema
+	}
+
+	for _, user := range snapshot.Users {
+		tenantID := user.Tenant
+		if tenantID == "" {
+			tenantID = domain.DefaultTenant
+		}
+
+		index, ok := s.usernames[tenantID]
+		if !ok {
+			return xerrors.Wrapf(errUnknownTenant, "user %s: tenant %q", user.ID, tenantID)
+		}
+
+		if _, taken := index[user.Username]; taken {
+			return xerrors.Wrapf(errDuplicateUsername, "tenant %q: %q", tenantID, user.Username)
+		}
+
+		if s.emailTaken(tenantID, user.ID, user.Email) {
+			return xerrors.Wrapf(errDuplicateEmail, "tenant %q: %q", tenantID, user.Email)
+		}
+
+		s.addUser(tenantID, domain.User{
+			ID:            user.ID,
+			Name:          user.Name,
+			Username:      user.Username,
+			Email:         user.Email,
+			EmailVerified: user.Verified,
+			Attributes:    s.restoreAttributes(tenantID, user.Attributes),
+		})
+
+		if pending := user.Verification; pending != nil {
+			s.verifications[user.ID] = domain.VerificationToken{Hash: pending.Hash, Email: pending.Email, ExpiresAt: pending.ExpiresAt}
+		}
+
+		if password := user.Password; password != nil {
+			credential := domain.Credential{PasswordHash: password.Hash, Failures: password.Failures}
+
+			if password.LockedUntil != nil {
+				credential.LockedUntil = *password.LockedUntil
+			}
+
+			s.credentials[user.ID] = credential
+		}
+
+		for _, session := range user.Sessions {
+			s.addSession(domain.Session{
+				ID:            session.ID,
+				TenantID:      tenantID,
+				UserID:        user.ID,
+				TokenHash:     session.TokenHash,
+				CreatedAt:     session.CreatedAt,
+				LastUsedAt:    session.LastUsedAt,
+				IdleExpiresAt: session.IdleExpiresAt,
+				ExpiresAt:     session.ExpiresAt,
+			})
+		}
+	}
+
+	for _, group := range snapshot.Groups {
+		index, ok := s.groupNames[group.Tenant]
+		if !ok {
+			return xerrors.Wrapf(errUnknownTenant, "group %s: tenant %q", group.ID, group.Tenant)
+		}
+
+		if _, taken := index[group.Name]; taken {
+			return xerrors.Wrapf(errDuplicateGroupName, "tenant %q: %q", group.Tenant, group.Name)
+		}
+
+		s.addGroup(group.Tenant, domain.Group{ID: group.ID, Name: group.Name, Description: group.Description})
+
+		for _, userID := range group.Members {
+			if stored, ok := s.users[userID]; !ok || stored.tenant != group.Tenant {
+				return xerrors.Wrapf(errUnknownMember, "group %s: %s", group.ID, userID)
+			}
+
+			s.addMember(group.ID, userID)
+		}
+	}
+
+	return nil
+}
+
+// restoreAttributes brings back the integer values that JSON decoded as
+// float64.
+func (s *InMemoryUserStorage) restoreAttributes(tenantID string, attributes map[string]any) domain.Attributes {
+	if attributes == nil {
+		return nil
+	}
+
+	types := make(map[string]domain.AttributeType, len(s.schemas[tenantID]))
+
+	for _, definition := range s.schemas[tenantID] {
+		types[definition.Name] = definition.Type
+	}
+
+	result := make(domain.Attributes, len(attributes))
+
+	for name, value := range attributes {
+		result[name] = restoreAttributeValue(types[name], value)
+	}
+
+	return result
+}
+
+func restoreAttributeValue(attributeType domain.AttributeType, value any) any {
+	if number, ok := value.(float64); ok && attributeType == domain.AttributeInteger {
+		return int64(number)
+	}
+
+	return value
+}
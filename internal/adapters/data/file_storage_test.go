@@ -0,0 +1,199 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/flexer2006/t-t-ogen-go/internal/domain"
+	"github.com/flexer2006/t-t-ogen-go/internal/ports"
+)
+
+// TestFileUserStorageFailedWriteLeavesMemory makes the data file unwritable
+// and checks that the changes it refused, including their index entries,
+// never reach the live data.
+func TestFileUserStorageFailedWriteLeavesMemory(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "users.json")
+
+	storage, err := NewFileUserStorage(path)
+	if err != nil {
+		t.Fatalf("NewFileUserStorage: %v", err)
+	}
+
+	wile, err := storage.CreateUser(ctx, domain.DefaultTenant, "Wile E. Coyote", "wile", "wile@example.com", nil)
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+
+	group, err := storage.CreateGroup(ctx, domain.DefaultTenant, "hunters", "")
+	if err != nil {
+		t.Fatalf("CreateGroup: %v", err)
+	}
+
+	// Renaming the snapshot over a directory fails.
+	if err := os.Remove(path); err != nil {
+		t.Fatalf("remove data file: %v", err)
+	}
+
+	if err := os.Mkdir(path, 0o700); err != nil {
+		t.Fatalf("replace data file: %v", err)
+	}
+
+	if _, err := storage.CreateUser(ctx, domain.DefaultTenant, "Road Runner", "runner", "runner@example.com", nil); err == nil {
+		t.Fatal("CreateUser succeeded without a writable data file")
+	}
+
+	if err := storage.AddGroupMember(ctx, domain.DefaultTenant, group.ID, wile.ID); err == nil {
+		t.Fatal("AddGroupMember succeeded without a writable data file")
+	}
+
+	if err := os.Remove(path); err != nil {
+		t.Fatalf("restore data file: %v", err)
+	}
+
+	users, err := storage.ListUsers(ctx, domain.DefaultTenant, nil)
+	if err != nil {
+		t.Fatalf("ListUsers: %v", err)
+	}
+
+	if len(users) != 1 || users[0].ID != wile.ID {
+		t.Errorf("users = %+v, want only wile", users)
+	}
+
+	if members, err := storage.ListGroupMembers(ctx, domain.DefaultTenant, group.ID); err != nil || len(members) != 0 {
+		t.Errorf("ListGroupMembers = %v, %v; want no members", members, err)
+	}
+
+	// The refused user left no username or email behind.
+	if _, err := storage.CreateUser(ctx, domain.DefaultTenant, "Road Runner", "runner", "runner@example.com", nil); err != nil {
+		t.Fatalf("CreateUser after the write failure: %v", err)
+	}
+
+	reloaded, err := NewFileUserStorage(path)
+	if err != nil {
+		t.Fatalf("NewFileUserStorage: %v", err)
+	}
+
+	if _, err := reloaded.GetUserByUsername(ctx, domain.DefaultTenant, "runner"); err != nil {
+		t.Errorf("GetUserByUsername after reload: %v", err)
+	}
+
+	if _, err := reloaded.CreateUser(ctx, domain.DefaultTenant, "Wile E. Coyote", "wile", "", nil); !errors.Is(err, ports.ErrUsernameTaken) {
+		t.Errorf("CreateUser with a taken username after reload: error = %v, want ErrUsernameTaken", err)
+	}
+}
+
+// TestFileUserStorageRecordLoginSuccess checks a login against the live data
+// and writes only when the login changes the credential.
+func TestFileUserStorageRecordLoginSuccess(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "users.json")
+	now := time.Unix(1_700_000_000, 0)
+
+	storage, err := NewFileUserStorage(path)
+	if err != nil {
+		t.Fatalf("NewFileUserStorage: %v", err)
+	}
+
+	wile, err := storage.CreateUser(ctx, domain.DefaultTenant, "Wile E. Coyote", "wile", "", nil)
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+
+	runner, err := storage.CreateUser(ctx, domain.DefaultTenant, "Road Runner", "runner", "", nil)
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+
+	for _, user := range []domain.User{wile, runner} {
+		if err := storage.SetPassword(ctx, domain.DefaultTenant, user.ID, "hash"); err != nil {
+			t.Fatalf("SetPassword: %v", err)
+		}
+	}
+
+	policy := domain.LockoutPolicy{MaxFailures: 1, Duration: time.Hour}
+	if _, err := storage.RecordLoginFailure(ctx, domain.DefaultTenant, runner.ID, now, policy); err != nil {
+		t.Fatalf("RecordLoginFailure: %v", err)
+	}
+
+	// Any write from here on fails.
+	if err := os.Remove(path); err != nil {
+		t.Fatalf("remove data file: %v", err)
+	}
+
+	if err := os.Mkdir(path, 0o700); err != nil {
+		t.Fatalf("replace data file: %v", err)
+	}
+
+	if err := storage.RecordLoginSuccess(ctx, domain.DefaultTenant, wile.ID, now, "hash", ""); err != nil {
+		t.Errorf("RecordLoginSuccess that changes nothing: %v", err)
+	}
+
+	if err := storage.RecordLoginSuccess(ctx, domain.DefaultTenant, wile.ID, now, "old hash", ""); !errors.Is(err, ports.ErrInvalidCredentials) {
+		t.Errorf("RecordLoginSuccess with a replaced hash: error = %v, want ErrInvalidCredentials", err)
+	}
+
+	if err := storage.RecordLoginSuccess(ctx, domain.DefaultTenant, runner.ID, now, "hash", ""); !errors.Is(err, ports.ErrAccountLocked) {
+		t.Errorf("RecordLoginSuccess on a locked account: error = %v, want ErrAccountLocked", err)
+	}
+
+	if err := storage.RecordLoginSuccess(ctx, domain.DefaultTenant, wile.ID, now, "hash", "rehash"); err == nil {
+		t.Error("RecordLoginSuccess with a rehash succeeded without a writable data file")
+	}
+}
+
+// TestFileUserStorageTouchSessionSurvivesReload checks that the idle expiry a
+// touch extends is on disk, so a restart does not log the session out.
+func TestFileUserStorageTouchSessionSurvivesReload(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "users.json")
+	now := time.Unix(1_700_000_000, 0)
+
+	storage, err := NewFileUserStorage(path)
+	if err != nil {
+		t.Fatalf("NewFileUserStorage: %v", err)
+	}
+
+	user, err := storage.CreateUser(ctx, domain.DefaultTenant, "Wile E. Coyote", "wile", "", nil)
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+
+	session := domain.Session{
+		ID:            uuid.New(),
+		TenantID:      domain.DefaultTenant,
+		UserID:        user.ID,
+		TokenHash:     []byte("token hash"),
+		CreatedAt:     now,
+		LastUsedAt:    now,
+		IdleExpiresAt: now.Add(time.Minute),
+		ExpiresAt:     now.Add(time.Hour),
+	}
+
+	if err := storage.CreateSession(ctx, session); err != nil {
+		t.Fatalf("CreateSession: %v", err)
+	}
+
+	if err := storage.TouchSession(ctx, session.ID, now.Add(30*time.Second), now.Add(90*time.Second)); err != nil {
+		t.Fatalf("TouchSession: %v", err)
+	}
+
+	reloaded, err := NewFileUserStorage(path)
+	if err != nil {
+		t.Fatalf("NewFileUserStorage: %v", err)
+	}
+
+	if _, err := reloaded.GetSessionByToken(ctx, session.TokenHash, now.Add(80*time.Second)); err != nil {
+		t.Errorf("GetSessionByToken after reload within the extended idle expiry: %v", err)
+	}
+
+	if err := reloaded.TouchSession(ctx, uuid.New(), now, now); !errors.Is(err, ports.ErrSessionNotFound) {
+		t.Errorf("TouchSession of an unknown session: error = %v, want ErrSessionNotFound", err)
+	}
+}
@@ -0,0 +1,273 @@
+package data
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	xerrors "github.com/go-faster/errors"
+	"github.com/google/uuid"
+
+	"github.com/flexer2006/t-t-ogen-go/internal/domain"
+	"github.com/flexer2006/t-t-ogen-go/internal/ports"
+)
+
+type tenantGroup struct {
+	tenant string
+	group  domain.Group
+}
+
+func (s *InMemoryUserStorage) ListGroups(ctx context.Context, tenantID string) ([]domain.Group, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, xerrors.Wrap(err, "data.InMemoryUserStorage.ListGroups")
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	index, ok := s.groupNames[tenantID]
+	if !ok {
+		return nil, ports.ErrTenantNotFound
+	}
+
+	result := make([]domain.Group, 0, len(index))
+
+	for _, id := range index {
+		result = append(result, s.groups[id].group)
+	}
+
+	sortGroups(result)
+
+	return result, nil
+}
+
+func (s *InMemoryUserStorage) CreateGroup(ctx context.Context, tenantID, name, description string) (domain.Group, error) {
+	if err := ctx.Err(); err != nil {
+		return domain.Group{}, xerrors.Wrap(err, "data.InMemoryUserStorage.CreateGroup")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	index, ok := s.groupNames[tenantID]
+	if !ok {
+		return domain.Group{}, ports.ErrTenantNotFound
+	}
+
+	if _, taken := index[name]; taken {
+		return domain.Group{}, ports.ErrGroupNameTaken
+	}
+
+	group := domain.Group{
+		ID:          uuid.New(),
+		Name:        name,
+		Description: description,
+	}
+
+	s.addGroup(tenantID, group)
+
+	return group, nil
+}
+
+func (s *InMemoryUserStorage) GetGroup(ctx context.Context, tenantID string, groupID uuid.UUID) (domain.Group, error) {
+	if err := ctx.Err(); err != nil {
+		return domain.Group{}, xerrors.Wrap(err, "data.InMemoryUserStorage.GetGroup")
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	stored, err := s.lookupGroup(tenantID, groupID)
+	if err != nil {
+		return domain.Group{}, err
+	}
+
+	return stored.group, nil
+}
+
+func (s *InMemoryUserStorage) UpdateGroup(ctx context.Context, tenantID string, groupID uuid.UUID, name *string, description *string) (domain.Group, error) {
+	if err := ctx.Err(); err != nil {
+		return domain.Group{}, xerrors.Wrap(err, "data.InMemoryUserStorage.UpdateGroup")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, err := s.lookupGroup(tenantID, groupID)
+	if err != nil {
+		return domain.Group{}, err
+	}
+
+	group := stored.group
+	index := s.groupNames[tenantID]
+
+	if name != nil && *name != group.Name {
+		if _, taken := index[*name]; taken {
+			return domain.Group{}, ports.ErrGroupNameTaken
+		}
+
+		delete(index, group.Name)
+		index[*name] = groupID
+		group.Name = *name
+	}
+
+	if description != nil {
+		group.Description = *description
+	}
+
+	s.groups[groupID] = tenantGroup{tenant: tenantID, group: group}
+
+	return group, nil
+}
+
+func (s *InMemoryUserStorage) DeleteGroup(ctx context.Context, tenantID string, groupID uuid.UUID) error {
+	if err := ctx.Err(); err != nil {
+		return xerrors.Wrap(err, "data.InMemoryUserStorage.DeleteGroup")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, err := s.lookupGroup(tenantID, groupID)
+	if err != nil {
+		return err
+	}
+
+	for userID := range s.members[groupID] {
+		delete(s.memberships[userID], groupID)
+	}
+
+	delete(s.groupNames[tenantID], stored.group.Name)
+	delete(s.groups, groupID)
+	delete(s.members, groupID)
+
+	return nil
+}
+
+func (s *InMemoryUserStorage) ListGroupMembers(ctx context.Context, tenantID string, groupID uuid.UUID) ([]domain.User, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, xerrors.Wrap(err, "data.InMemoryUserStorage.ListGroupMembers")
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if _, err := s.lookupGroup(tenantID, groupID); err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.User, 0, len(s.members[groupID]))
+
+	for userID := range s.members[groupID] {
+		result = append(result, cloneUser(s.users[userID].user))
+	}
+
+	slices.SortFunc(result, func(a, b domain.User) int {
+		return strings.Compare(a.Username, b.Username)
+	})
+
+	return result, nil
+}
+
+func (s *InMemoryUserStorage) AddGroupMember(ctx context.Context, tenantID string, groupID, userID uuid.UUID) error {
+	if err := ctx.Err(); err != nil {
+		return xerrors.Wrap(err, "data.InMemoryUserStorage.AddGroupMember")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.lookupGroup(tenantID, groupID); err != nil {
+		return err
+	}
+
+	if _, err := s.lookup(tenantID, userID); err != nil {
+		return err
+	}
+
+	s.addMember(groupID, userID)
+
+	return nil
+}
+
+func (s *InMemoryUserStorage) RemoveGroupMember(ctx context.Context, tenantID string, groupID, userID uuid.UUID) error {
+	if err := ctx.Err(); err != nil {
+		return xerrors.Wrap(err, "data.InMemoryUserStorage.RemoveGroupMember")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.lookupGroup(tenantID, groupID); err != nil {
+		return err
+	}
+
+	if _, ok := s.members[groupID][userID]; !ok {
+		return ports.ErrNotGroupMember
+	}
+
+	delete(s.members[groupID], userID)
+	delete(s.memberships[userID], groupID)
+
+	return nil
+}
+
+func (s *InMemoryUserStorage) ListUserGroups(ctx context.Context, tenantID string, userID uuid.UUID) ([]domain.Group, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, xerrors.Wrap(err, "data.InMemoryUserStorage.ListUserGroups")
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if _, err := s.lookup(tenantID, userID); err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.Group, 0, len(s.memberships[userID]))
+
+	for groupID := range s.memberships[userID] {
+		result = append(result, s.groups[groupID].group)
+	}
+
+	sortGroups(result)
+
+	return result, nil
+}
+
+// lookupGroup reports groups of other tenants as missing, like lookup does
+// for users.
+func (s *InMemoryUserStorage) lookupGroup(tenantID string, groupID uuid.UUID) (tenantGroup, error) {
+	if _, ok := s.tenants[tenantID]; !ok {
+		return tenantGroup{}, ports.ErrTenantNotFound
+	}
+
+	stored, ok := s.groups[groupID]
+	if !ok || stored.tenant != tenantID {
+		return tenantGroup{}, ports.ErrGroupNotFound
+	}
+
+	return stored, nil
+}
+
+func (s *InMemoryUserStorage) addGroup(tenantID string, group domain.Group) {
+	s.groups[group.ID] = tenantGroup{tenant: tenantID, group: group}
+	s.groupNames[tenantID][group.Name] = group.ID
+	s.members[group.ID] = make(map[uuid.UUID]struct{})
+}
+
+func (s *InMemoryUserStorage) addMember(groupID, userID uuid.UUID) {
+	s.members[groupID][userID] = struct{}{}
+
+	if _, ok := s.memberships[userID]; !ok {
+		s.memberships[userID] = make(map[uuid.UUID]struct{})
+	}
+
+	s.memberships[userID][groupID] = struct{}{}
+}
+
+func sortGroups(groups []domain.Group) {
+	slices.SortFunc(groups, func(a, b domain.Group) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+}
@@ -0,0 +1,221 @@
+package data
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	xerrors "github.com/go-faster/errors"
+	"github.com/google/uuid"
+
+	"github.com/flexer2006/t-t-ogen-go/internal/domain"
+	"github.com/flexer2006/t-t-ogen-go/internal/ports"
+)
+
+func (s *InMemoryUserStorage) CreateSession(ctx context.Context, session domain.Session) error {
+	if err := ctx.Err(); err != nil {
+		return xerrors.Wrap(err, "data.InMemoryUserStorage.CreateSession")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.lookup(session.TenantID, session.UserID); err != nil {
+		return err
+	}
+
+	s.addSession(cloneSession(session))
+
+	return nil
+}
+
+func (s *InMemoryUserStorage) GetSessionByToken(ctx context.Context, tokenHash []byte, now time.Time) (domain.Session, error) {
+	if err := ctx.Err(); err != nil {
+		return domain.Session{}, xerrors.Wrap(err, "data.InMemoryUserStorage.GetSessionByToken")
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	session, ok := s.sessions[s.sessionTokens[string(tokenHash)]]
+	if !ok || session.Expired(now) {
+		return domain.Session{}, ports.ErrSessionNotFound
+	}
+
+	return cloneSession(session), nil
+}
+
+func (s *InMemoryUserStorage) TouchSession(ctx context.Context, id uuid.UUID, usedAt, idleExpiresAt time.Time) error {
+	if err := ctx.Err(); err != nil {
+		return xerrors.Wrap(err, "data.InMemoryUserStorage.TouchSession")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	session, ok := s.sessions[id]
+	if !ok {
+		return ports.ErrSessionNotFound
+	}
+
+	// Concurrent requests may touch out of order; keep the latest use.
+	if usedAt.After(session.LastUsedAt) {
+		session.LastUsedAt = usedAt
+		session.IdleExpiresAt = idleExpiresAt
+		s.sessions[id] = session
+	}
+
+	return nil
+}
+
+// touchChanges reports whether TouchSession would record usedAt, and fails
+// where it would.
+func (s *InMemoryUserStorage) touchChanges(id uuid.UUID, usedAt time.Time) (bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	session, ok := s.sessions[id]
+	if !ok {
+		return false, ports.ErrSessionNotFound
+	}
+
+	return usedAt.After(session.LastUsedAt), nil
+}
+
+func (s *InMemoryUserStorage) ListSessions(ctx context.Context, tenantID string, userID uuid.UUID, now time.Time) ([]domain.Session, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, xerrors.Wrap(err, "data.InMemoryUserStorage.ListSessions")
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if _, err := s.lookup(tenantID, userID); err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.Session, 0, len(s.userSessions[userID]))
+
+	for id := range s.userSessions[userID] {
+		if session := s.sessions[id]; !session.Expired(now) {
+			result = append(result, cloneSession(session))
+		}
+	}
+
+	slices.SortFunc(result, func(a, b domain.Session) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+
+	return result, nil
+}
+
+func (s *InMemoryUserStorage) DeleteSession(ctx context.Context, tenantID string, userID, sessionID uuid.UUID) error {
+	if err := ctx.Err(); err != nil {
+		return xerrors.Wrap(err, "data.InMemoryUserStorage.DeleteSession")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.lookup(tenantID, userID); err != nil {
+		return err
+	}
+
+	if _, ok := s.userSessions[userID][sessionID]; !ok {
+		return ports.ErrSessionNotFound
+	}
+
+	s.deleteSession(sessionID)
+
+	return nil
+}
+
+func (s *InMemoryUserStorage) DeleteUserSessions(ctx context.Context, tenantID string, userID uuid.UUID) error {
+	if err := ctx.Err(); err != nil {
+		return xerrors.Wrap(err, "data.InMemoryUserStorage.DeleteUserSessions")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.lookup(tenantID, userID); err != nil {
+		return err
+	}
+
+	s.endSessions(userID)
+
+	return nil
+}
+
+func (s *InMemoryUserStorage) DeleteExpiredSessions(ctx context.Context, now time.Time) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, xerrors.Wrap(err, "data.InMemoryUserStorage.DeleteExpiredSessions")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var deleted int
+
+	for id, session := range s.sessions {
+		if session.Expired(now) {
+			s.deleteSession(id)
+			deleted++
+		}
+	}
+
+	return deleted, nil
+}
+
+// hasExpiredSessions reports whether DeleteExpiredSessions would delete any
+// session.
+func (s *InMemoryUserStorage) hasExpiredSessions(now time.Time) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, session := range s.sessions {
+		if session.Expired(now) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s *InMemoryUserStorage) addSession(session domain.Session) {
+	s.sessions[session.ID] = session
+	s.sessionTokens[string(session.TokenHash)] = session.ID
+
+	if s.userSessions[session.UserID] == nil {
+		s.userSessions[session.UserID] = make(map[uuid.UUID]struct{})
+	}
+
+	s.userSessions[session.UserID][session.ID] = struct{}{}
+}
+
+func (s *InMemoryUserStorage) deleteSession(id uuid.UUID) {
+	session, ok := s.sessions[id]
+	if !ok {
+		return
+	}
+
+	delete(s.sessions, id)
+	delete(s.sessionTokens, string(session.TokenHash))
+	delete(s.userSessions[session.UserID], id)
+
+	if len(s.userSessions[session.UserID]) == 0 {
+		delete(s.userSessions, session.UserID)
+	}
+}
+
+func (s *InMemoryUserStorage) endSessions(userID uuid.UUID) {
+	for id := range s.userSessions[userID] {
+		s.deleteSession(id)
+	}
+}
+
+func cloneSession(session domain.Session) domain.Session {
+	session.TokenHash = slices.Clone(session.TokenHash)
+
+	return session
+}
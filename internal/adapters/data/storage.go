@@ -2,6 +2,9 @@ package data
 
 import (
 	"context"
+	"maps"
+	"slices"
+	"strings"
 	"sync"
 
 	xerrors "github.com/go-faster/errors"
@@ -11,21 +14,76 @@ import (
 	"github.com/flexer2006/t-t-ogen-go/internal/ports"
 )
 
+type tenantUser struct {
+	tenant string
+	user   domain.User
+}
+
+// InMemoryUserStorage keeps tenants, their users, groups and attribute
+// schemas. Every tenant has its own username, email and group name index,
+// which both enforces uniqueness and lists the tenant's entries without
+// looking at anyone else's. Users and groups share one lock, so deleting a
+// user and its memberships is a single step.
 type InMemoryUserStorage struct {
-	mu    sync.RWMutex
-	users map[uuid.UUID]domain.User
+	mu        sync.RWMutex
+	tenants   map[string]domain.Tenant
+	users     map[uuid.UUID]tenantUser
+	usernames map[string]map[string]uuid.UUID
+	// emails is keyed by domain.EmailKey.
+	emails     map[string]map[string]uuid.UUID
+	groups     map[uuid.UUID]tenantGroup
+	groupNames map[string]map[string]uuid.UUID
+	// members and memberships index group membership both ways.
+	members     map[uuid.UUID]map[uuid.UUID]struct{}
+	memberships map[uuid.UUID]map[uuid.UUID]struct{}
+	schemas     map[string][]domain.AttributeDefinition
+	// verifications holds the pending email verification of each user.
+	verifications map[uuid.UUID]domain.VerificationToken
+	credentials   map[uuid.UUID]domain.Credential
+	// sessions are indexed by the hash of their token and by user.
+	sessions      map[uuid.UUID]domain.Session
+	sessionTokens map[string]uuid.UUID
+	userSessions  map[uuid.UUID]map[uuid.UUID]struct{}
 }
 
-var _ ports.UserRepository = (*InMemoryUserStorage)(nil)
+var (
+	_ ports.UserRepository              = (*InMemoryUserStorage)(nil)
+	_ ports.TenantRepository            = (*InMemoryUserStorage)(nil)
+	_ ports.GroupRepository             = (*InMemoryUserStorage)(nil)
+	_ ports.AttributeSchemaRepository   = (*InMemoryUserStorage)(nil)
+	_ ports.EmailVerificationRepository = (*InMemoryUserStorage)(nil)
+	_ ports.CredentialRepository        = (*InMemoryUserStorage)(nil)
+	_ ports.SessionRepository           = (*InMemoryUserStorage)(nil)
+)
 
+// NewInMemoryUserStorage returns an empty storage that already contains the
+// default tenant.
 func NewInMemoryUserStorage() *InMemoryUserStorage {
-	return &InMemoryUserStorage{
-		mu:    sync.RWMutex{},
-		users: make(map[uuid.UUID]domain.User),
+	storage := &InMemoryUserStorage{
+		mu:          sync.RWMutex{},
+		tenants:     make(map[string]domain.Tenant),
+		users:       make(map[uuid.UUID]tenantUser),
+		usernames:   make(map[string]map[string]uuid.UUID),
+		emails:      make(map[string]map[string]uuid.UUID),
+		groups:      make(map[uuid.UUID]tenantGroup),
+		groupNames:  make(map[string]map[string]uuid.UUID),
+		members:     make(map[uuid.UUID]map[uuid.UUID]struct{}),
+		memberships: make(map[uuid.UUID]map[uuid.UUID]struct{}),
+		schemas:     make(map[string][]domain.AttributeDefinition),
+
+		verifications: make(map[uuid.UUID]domain.VerificationToken),
+		credentials:   make(map[uuid.UUID]domain.Credential),
+		sessions:      make(map[uuid.UUID]domain.Session),
+		sessionTokens: make(map[string]uuid.UUID),
+		userSessions:  make(map[uuid.UUID]map[uuid.UUID]struct{}),
 	}
+
+	storage.addTenant(domain.Tenant{ID: domain.DefaultTenant, Name: "Default"})
+
+	return storage
 }
 
-func (s *InMemoryUserStorage) ListUsers(ctx context.Context) ([]domain.User, error) {
+func (s *InMemoryUserStorage) ListUsers(ctx context.Context, tenantID string, match domain.Attributes) ([]domain.User, error) {
 	if err := ctx.Err(); err != nil {
 		return nil, xerrors.Wrap(err, "data.InMemoryUserStorage.ListUsers")
 	}
@@ -33,16 +91,34 @@ func (s *InMemoryUserStorage) ListUsers(ctx context.Context) ([]domain.User, err
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	result := make([]domain.User, 0, len(s.users))
+	index, ok := s.usernames[tenantID]
+	if !ok {
+		return nil, ports.ErrTenantNotFound
+	}
+
+	result := make([]domain.User, 0, len(index))
 
-	for _, user := range s.users {
-		result = append(result, cloneUser(user))
+	for _, id := range index {
+		if user := s.users[id].user; matches(user.Attributes, match) {
+			result = append(result, cloneUser(user))
+		}
 	}
 
 	return result, nil
 }
 
-func (s *InMemoryUserStorage) CreateUser(ctx context.Context, name, username string) (domain.User, error) {
+func (s *InMemoryUserStorage) CountUsers(ctx context.Context) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, xerrors.Wrap(err, "data.InMemoryUserStorage.CountUsers")
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return len(s.users), nil
+}
+
+func (s *InMemoryUserStorage) CreateUser(ctx context.Context, tenantID, name, username, email string, attributes domain.Attributes) (domain.User, error) {
 	if err := ctx.Err(); err != nil {
 		return domain.User{}, xerrors.Wrap(err, "data.InMemoryUserStorage.CreateUser")
 	}
@@ -50,18 +126,37 @@ func (s *InMemoryUserStorage) CreateUser(ctx context.Context, name, username str
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	index, ok := s.usernames[tenantID]
+	if !ok {
+		return domain.User{}, ports.ErrTenantNotFound
+	}
+
+	if _, taken := index[username]; taken {
+		return domain.User{}, ports.ErrUsernameTaken
+	}
+
+	if s.emailTaken(tenantID, uuid.Nil, email) {
+		return domain.User{}, ports.ErrEmailTaken
+	}
+
+	if s.attributeTaken(tenantID, uuid.Nil, attributes) {
+		return domain.User{}, ports.ErrAttributeNotUnique
+	}
+
 	user := domain.User{
-		ID:       uuid.New(),
-		Name:     name,
-		Username: username,
+		ID:         uuid.New(),
+		Name:       name,
+		Username:   username,
+		Email:      email,
+		Attributes: maps.Clone(attributes),
 	}
 
-	s.users[user.ID] = user
+	s.addUser(tenantID, user)
 
 	return cloneUser(user), nil
 }
 
-func (s *InMemoryUserStorage) GetUser(ctx context.Context, userID uuid.UUID) (domain.User, error) {
+func (s *InMemoryUserStorage) GetUser(ctx context.Context, tenantID string, userID uuid.UUID) (domain.User, error) {
 	if err := ctx.Err(); err != nil {
 		return domain.User{}, xerrors.Wrap(err, "data.InMemoryUserStorage.GetUser")
 	}
@@ -69,15 +164,23 @@ func (s *InMemoryUserStorage) GetUser(ctx context.Context, userID uuid.UUID) (do
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	user, ok := s.users[userID]
-	if !ok {
-		return domain.User{}, ports.ErrUserNotFound
+	stored, err := s.lookup(tenantID, userID)
+	if err != nil {
+		return domain.User{}, err
 	}
 
-	return cloneUser(user), nil
+	return cloneUser(stored.user), nil
 }
 
-func (s *InMemoryUserStorage) UpdateUser(ctx context.Context, userID uuid.UUID, name *string, username *string) (domain.User, error) {
+func (s *InMemoryUserStorage) UpdateUser(
+	ctx context.Context,
+	tenantID string,
+	userID uuid.UUID,
+	name *string,
+	username *string,
+	email *string,
+	attributes domain.Attributes,
+) (domain.User, error) {
 	if err := ctx.Err(); err != nil {
 		return domain.User{}, xerrors.Wrap(err, "data.InMemoryUserStorage.UpdateUser")
 	}
@@ -85,25 +188,55 @@ func (s *InMemoryUserStorage) UpdateUser(ctx context.Context, userID uuid.UUID,
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	user, ok := s.users[userID]
-	if !ok {
-		return domain.User{}, ports.ErrUserNotFound
+	stored, err := s.lookup(tenantID, userID)
+	if err != nil {
+		return domain.User{}, err
+	}
+
+	user := stored.user
+	index := s.usernames[tenantID]
+	renamed := username != nil && *username != user.Username
+
+	if renamed {
+		if _, taken := index[*username]; taken {
+			return domain.User{}, ports.ErrUsernameTaken
+		}
+	}
+
+	readdressed := email != nil && *email != user.Email
+
+	if readdressed && s.emailTaken(tenantID, userID, *email) {
+		return domain.User{}, ports.ErrEmailTaken
+	}
+
+	if attributes != nil && s.attributeTaken(tenantID, userID, attributes) {
+		return domain.User{}, ports.ErrAttributeNotUnique
+	}
+
+	if renamed {
+		delete(index, user.Username)
+		index[*username] = userID
+		user.Username = *username
+	}
+
+	if readdressed {
+		s.setEmail(tenantID, &user, *email)
 	}
 
 	if name != nil {
 		user.Name = *name
 	}
 
-	if username != nil {
-		user.Username = *username
+	if attributes != nil {
+		user.Attributes = maps.Clone(attributes)
 	}
 
-	s.users[userID] = user
+	s.users[userID] = tenantUser{tenant: tenantID, user: user}
 
 	return cloneUser(user), nil
 }
 
-func (s *InMemoryUserStorage) DeleteUser(ctx context.Context, userID uuid.UUID) error {
+func (s *InMemoryUserStorage) DeleteUser(ctx context.Context, tenantID string, userID uuid.UUID) error {
 	if err := ctx.Err(); err != nil {
 		return xerrors.Wrap(err, "data.InMemoryUserStorage.DeleteUser")
 	}
@@ -111,19 +244,154 @@ func (s *InMemoryUserStorage) DeleteUser(ctx context.Context, userID uuid.UUID)
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	if _, ok := s.users[userID]; !ok {
-		return ports.ErrUserNotFound
+	stored, err := s.lookup(tenantID, userID)
+	if err != nil {
+		return err
 	}
 
+	delete(s.usernames[tenantID], stored.user.Username)
+	delete(s.emails[tenantID], domain.EmailKey(stored.user.Email))
 	delete(s.users, userID)
+	delete(s.verifications, userID)
+	delete(s.credentials, userID)
+	s.endSessions(userID)
+
+	for groupID := range s.memberships[userID] {
+		delete(s.members[groupID], userID)
+	}
+
+	delete(s.memberships, userID)
 
 	return nil
 }
 
-func cloneUser(user domain.User) domain.User {
-	return domain.User{
-		ID:       user.ID,
-		Name:     user.Name,
-		Username: user.Username,
+func (s *InMemoryUserStorage) ListTenants(ctx context.Context) ([]domain.Tenant, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, xerrors.Wrap(err, "data.InMemoryUserStorage.ListTenants")
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]domain.Tenant, 0, len(s.tenants))
+
+	for _, tenant := range s.tenants {
+		result = append(result, tenant)
+	}
+
+	slices.SortFunc(result, func(a, b domain.Tenant) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+
+	return result, nil
+}
+
+func (s *InMemoryUserStorage) CreateTenant(ctx context.Context, tenant domain.Tenant) (domain.Tenant, error) {
+	if err := ctx.Err(); err != nil {
+		return domain.Tenant{}, xerrors.Wrap(err, "data.InMemoryUserStorage.CreateTenant")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.tenants[tenant.ID]; ok {
+		return domain.Tenant{}, ports.ErrTenantExists
+	}
+
+	s.addTenant(tenant)
+
+	return tenant, nil
+}
+
+func (s *InMemoryUserStorage) GetTenant(ctx context.Context, id string) (domain.Tenant, error) {
+	if err := ctx.Err(); err != nil {
+		return domain.Tenant{}, xerrors.Wrap(err, "data.InMemoryUserStorage.GetTenant")
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	tenant, ok := s.tenants[id]
+	if !ok {
+		return domain.Tenant{}, ports.ErrTenantNotFound
+	}
+
+	return tenant, nil
+}
+
+func (s *InMemoryUserStorage) DeleteTenant(ctx context.Context, id string) error {
+	if err := ctx.Err(); err != nil {
+		return xerrors.Wrap(err, "data.InMemoryUserStorage.DeleteTenant")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.tenants[id]; !ok {
+		return ports.ErrTenantNotFound
 	}
+
+	if len(s.usernames[id]) > 0 {
+		return ports.ErrTenantNotEmpty
+	}
+
+	// Without users every group of the tenant is empty.
+	for _, groupID := range s.groupNames[id] {
+		delete(s.groups, groupID)
+		delete(s.members, groupID)
+	}
+
+	delete(s.tenants, id)
+	delete(s.usernames, id)
+	delete(s.emails, id)
+	delete(s.groupNames, id)
+	delete(s.schemas, id)
+
+	return nil
+}
+
+// lookup reports users of other tenants as missing, so an identifier never
+// reveals whether it exists elsewhere.
+func (s *InMemoryUserStorage) lookup(tenantID string, userID uuid.UUID) (tenantUser, error) {
+	if _, ok := s.tenants[tenantID]; !ok {
+		return tenantUser{}, ports.ErrTenantNotFound
+	}
+
+	stored, ok := s.users[userID]
+	if !ok || stored.tenant != tenantID {
+		return tenantUser{}, ports.ErrUserNotFound
+	}
+
+	return stored, nil
+}
+
+func (s *InMemoryUserStorage) addTenant(tenant domain.Tenant) {
+	s.tenants[tenant.ID] = tenant
+
+	if _, ok := s.usernames[tenant.ID]; !ok {
+		s.usernames[tenant.ID] = make(map[string]uuid.UUID)
+	}
+
+	if _, ok := s.emails[tenant.ID]; !ok {
+		s.emails[tenant.ID] = make(map[string]uuid.UUID)
+	}
+
+	if _, ok := s.groupNames[tenant.ID]; !ok {
+		s.groupNames[tenant.ID] = make(map[string]uuid.UUID)
+	}
+}
+
+func (s *InMemoryUserStorage) addUser(tenantID string, user domain.User) {
+	s.users[user.ID] = tenantUser{tenant: tenantID, user: user}
+	s.usernames[tenantID][user.Username] = user.ID
+
+	if user.Email != "" {
+		s.emails[tenantID][domain.EmailKey(user.Email)] = user.ID
+	}
+}
+
+func cloneUser(user domain.User) domain.User {
+	user.Attributes = maps.Clone(user.Attributes)
+
+	return user
 }
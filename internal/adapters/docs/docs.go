@@ -0,0 +1,159 @@
+package docs
+
+import (
+	"bytes"
+	_ "embed"
+	"net/http"
+	"regexp"
+
+	xerrors "github.com/go-faster/errors"
+	"github.com/go-faster/jx"
+	"github.com/go-faster/yaml"
+)
+
+// The paths are relative to the prefix of the version they describe.
+const (
+	YAMLPath     = "/openapi.yaml"
+	JSONPath     = "/openapi.json"
+	ExplorerPath = "/docs"
+
+	// serverURLPlaceholder stands in for the base URL, which depends on how
+	// each request reached the service.
+	serverURLPlaceholder = "SERVER_URL_PLACEHOLDER"
+)
+
+var (
+	errNotDocument = xerrors.New("spec is not a YAML mapping")
+
+	// hostPattern admits host[:port] and [ipv6][:port], so the host can be
+	// spliced into YAML and JSON without escaping.
+	hostPattern = regexp.MustCompile(`^([A-Za-z0-9.-]+|\[[0-9A-Fa-f:.]+\])(:[0-9]+)?$`)
+
+	//go:embed explorer.html
+	explorerPage []byte
+)
+
+// Docs serves an OpenAPI document as YAML and JSON, with its servers list
+// pointing at the service as the client reached it.
+type Docs struct {
+	yaml   []byte
+	json   []byte
+	prefix string
+}
+
+// New prepares spec for a version served under prefix, such as "/v2".
+func New(spec []byte, prefix string) (*Docs, error) {
+	var document yaml.Node
+	if err := yaml.Unmarshal(spec, &document); err != nil {
+		return nil, xerrors.Wrap(err, "docs.New: parse spec")
+	}
+
+	if document.Kind != yaml.DocumentNode || len(document.Content) != 1 || document.Content[0].Kind != yaml.MappingNode {
+		return nil, xerrors.Wrap(errNotDocument, "docs.New")
+	}
+
+	setServers(document.Content[0])
+
+	var yamlOut bytes.Buffer
+
+	encoder := yaml.NewEncoder(&yamlOut)
+	encoder.SetIndent(2)
+
+	if err := encoder.Encode(&document); err != nil {
+		return nil, xerrors.Wrap(err, "docs.New: yaml")
+	}
+
+	var e jx.Encoder
+	e.SetIdent(2)
+
+	if err := document.Content[0].EncodeJSON(&e); err != nil {
+		return nil, xerrors.Wrap(err, "docs.New: json")
+	}
+
+	return &Docs{yaml: yamlOut.Bytes(), json: e.Bytes(), prefix: prefix}, nil
+}
+
+func (d *Docs) YAMLHandler() http.Handler {
+	return d.documentHandler(d.yaml, "application/yaml")
+}
+
+func (d *Docs) JSONHandler() http.Handler {
+	return d.documentHandler(d.json, "application/json")
+}
+
+// ExplorerHandler serves an API explorer that needs nothing beyond the
+// service itself. It loads openapi.json next to its own path and calls the
+// API at the document's server URL.
+func ExplorerHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !allowedMethod(w, r) {
+			return
+		}
+
+		header := w.Header()
+		header.Set("Content-Type", "text/html; charset=utf-8")
+		header.Set("Cache-Control", "no-cache")
+		header.Set("Content-Security-Policy",
+			"default-src 'none'; script-src 'unsafe-inline'; style-src 'unsafe-inline'; connect-src 'self'; base-uri 'none'; frame-ancestors 'none'")
+
+		_, _ = w.Write(explorerPage)
+	})
+}
+
+func (d *Docs) documentHandler(document []byte, contentType string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !allowedMethod(w, r) {
+			return
+		}
+
+		w.Header().Set("Content-Type", contentType)
+		w.Header().Set("Cache-Control", "no-cache")
+
+		_, _ = w.Write(bytes.ReplaceAll(document, []byte(serverURLPlaceholder), []byte(d.serverURL(r))))
+	})
+}
+
+// serverURL is the URL of the version as the client reached the service. A
+// Host header that cannot be spliced in safely falls back to a relative URL.
+func (d *Docs) serverURL(r *http.Request) string {
+	switch {
+	case !hostPattern.MatchString(r.Host) && d.prefix == "":
+		return "/"
+	case !hostPattern.MatchString(r.Host):
+		return d.prefix
+	case r.TLS != nil:
+		return "https://" + r.Host + d.prefix
+	default:
+		return "http://" + r.Host + d.prefix
+	}
+}
+
+func setServers(root *yaml.Node) {
+	servers := &yaml.Node{Kind: yaml.SequenceNode, Content: []*yaml.Node{{
+		Kind: yaml.MappingNode,
+		Content: []*yaml.Node{
+			{Kind: yaml.ScalarNode, Value: "url"},
+			{Kind: yaml.ScalarNode, Value: serverURLPlaceholder},
+		},
+	}}}
+
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value == "servers" {
+			root.Content[i+1] = servers
+			return
+		}
+	}
+
+	root.Content = append(root.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: "servers"}, servers)
+}
+
+func allowedMethod(w http.ResponseWriter, r *http.Request) bool {
+	if r.Method == http.MethodGet || r.Method == http.MethodHead {
+		return true
+	}
+
+	w.Header().Set("Allow", "GET, HEAD")
+	w.WriteHeader(http.StatusMethodNotAllowed)
+
+	return false
+}
@@ -0,0 +1,176 @@
+package health
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	xerrors "github.com/go-faster/errors"
+	"github.com/go-faster/jx"
+)
+
+const (
+	statusOK       = "ok"
+	statusFailing  = "failing"
+	statusDraining = "draining"
+)
+
+var errNilLogger = xerrors.New("nil logger dependency")
+
+type Check func(ctx context.Context) error
+
+type namedCheck struct {
+	name  string
+	check Check
+}
+
+// Checker serves liveness and readiness probes. Readiness runs every
+// registered check concurrently, each bounded by the configured timeout, and
+// reports failure unconditionally once draining has started. The probes are
+// unauthenticated, so responses name failing checks without their errors,
+// which are logged instead.
+type Checker struct {
+	timeout  time.Duration
+	logger   *slog.Logger
+	draining atomic.Bool
+
+	mu     sync.RWMutex
+	checks []namedCheck
+}
+
+type checkResult struct {
+	name string
+	err  error
+}
+
+func NewChecker(timeout time.Duration, logger *slog.Logger) (*Checker, error) {
+	if logger == nil {
+		return nil, xerrors.Wrap(errNilLogger, "health.NewChecker")
+	}
+
+	return &Checker{timeout: timeout, logger: logger}, nil
+}
+
+func (c *Checker) Register(name string, check Check) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.checks = append(c.checks, namedCheck{name: name, check: check})
+}
+
+func (c *Checker) StartDraining() {
+	c.draining.Store(true)
+}
+
+func (c *Checker) Draining() bool {
+	return c.draining.Load()
+}
+
+func (c *Checker) LivenessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		writeStatus(w, http.StatusOK, statusOK, nil)
+	})
+}
+
+func (c *Checker) ReadinessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if c.Draining() {
+			writeStatus(w, http.StatusServiceUnavailable, statusDraining, nil)
+
+			return
+		}
+
+		results := c.run(r.Context())
+		code, status := http.StatusOK, statusOK
+
+		for _, result := range results {
+			if result.err != nil {
+				c.logger.WarnContext(r.Context(), "readiness check failed",
+					slog.String("check", result.name), slog.Any("error", result.err))
+
+				code, status = http.StatusServiceUnavailable, statusFailing
+			}
+		}
+
+		writeStatus(w, code, status, results)
+	})
+}
+
+func (c *Checker) run(ctx context.Context) []checkResult {
+	c.mu.RLock()
+	checks := c.checks
+	c.mu.RUnlock()
+
+	results := make([]checkResult, len(checks))
+
+	var wg sync.WaitGroup
+
+	for i, check := range checks {
+		wg.Go(func() {
+			checkCtx, cancel := context.WithTimeout(ctx, c.timeout)
+			defer cancel()
+
+			results[i] = checkResult{name: check.name, err: runCheck(checkCtx, check.check)}
+		})
+	}
+
+	wg.Wait()
+
+	return results
+}
+
+// runCheck returns when the check does or when its context expires, so a
+// check that ignores cancellation cannot stall the probe.
+func runCheck(ctx context.Context, check Check) error {
+	done := make(chan error, 1)
+
+	go func() {
+		done <- check(ctx)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func writeStatus(w http.ResponseWriter, code int, status string, results []checkResult) {
+	var e jx.Encoder
+
+	e.ObjStart()
+	e.FieldStart("status")
+	e.Str(status)
+
+	if len(results) > 0 {
+		e.FieldStart("checks")
+		e.ObjStart()
+
+		for _, result := range results {
+			e.FieldStart(result.name)
+			e.ObjStart()
+			e.FieldStart("status")
+
+			if result.err != nil {
+				e.Str(statusFailing)
+			} else {
+				e.Str(statusOK)
+			}
+
+			e.ObjEnd()
+		}
+
+		e.ObjEnd()
+	}
+
+	e.ObjEnd()
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(code)
+	_, _ = w.Write(e.Bytes())
+}
@@ -0,0 +1,43 @@
+package health
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReadinessHidesCheckErrors(t *testing.T) {
+	var logs bytes.Buffer
+
+	checker, err := NewChecker(time.Second, slog.New(slog.NewTextHandler(&logs, nil)))
+	if err != nil {
+		t.Fatalf("NewChecker: %v", err)
+	}
+
+	checker.Register("repository", func(context.Context) error { return nil })
+	checker.Register("storage_writable", func(context.Context) error {
+		return errors.New("open /var/lib/users/users.json: read-only file system")
+	})
+
+	rec := httptest.NewRecorder()
+	checker.ReadinessHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusServiceUnavailable)
+	}
+
+	want := `{"status":"failing","checks":{"repository":{"status":"ok"},"storage_writable":{"status":"failing"}}}`
+	if body := rec.Body.String(); body != want {
+		t.Errorf("body = %s, want %s", body, want)
+	}
+
+	if !strings.Contains(logs.String(), "check=storage_writable") || !strings.Contains(logs.String(), "read-only file system") {
+		t.Errorf("log = %q, want the failing check and its error", logs.String())
+	}
+}
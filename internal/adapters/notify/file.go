@@ -0,0 +1,74 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"sync"
+	"time"
+
+	xerrors "github.com/go-faster/errors"
+	"github.com/google/uuid"
+
+	"github.com/flexer2006/t-t-ogen-go/internal/domain"
+	"github.com/flexer2006/t-t-ogen-go/internal/ports"
+)
+
+// FileNotifier appends every message as a line of JSON to a file, where a
+// developer or a test harness can pick up verification tokens. The file
+// holds secrets and is created readable by its owner only.
+type FileNotifier struct {
+	mu   sync.Mutex
+	path string
+}
+
+var _ ports.Notifier = (*FileNotifier)(nil)
+
+type fileMessage struct {
+	Kind      string    `json:"kind"`
+	Tenant    string    `json:"tenant"`
+	UserID    uuid.UUID `json:"user_id"`
+	Email     string    `json:"email"`
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+func NewFileNotifier(path string) *FileNotifier {
+	return &FileNotifier{path: path}
+}
+
+func (n *FileNotifier) SendEmailVerification(ctx context.Context, verification domain.EmailVerification) error {
+	if err := ctx.Err(); err != nil {
+		return xerrors.Wrap(err, "notify.FileNotifier.SendEmailVerification")
+	}
+
+	line, err := json.Marshal(fileMessage{
+		Kind:      "email_verification",
+		Tenant:    verification.TenantID,
+		UserID:    verification.UserID,
+		Email:     verification.Email,
+		Token:     verification.Token,
+		ExpiresAt: verification.ExpiresAt.UTC(),
+	})
+	if err != nil {
+		return xerrors.Wrap(err, "notify.FileNotifier.SendEmailVerification: marshal")
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	file, err := os.OpenFile(n.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return xerrors.Wrap(err, "notify.FileNotifier.SendEmailVerification")
+	}
+
+	_, writeErr := file.Write(append(line, '\n'))
+	closeErr := file.Close()
+
+	if err := errors.Join(writeErr, closeErr); err != nil {
+		return xerrors.Wrap(err, "notify.FileNotifier.SendEmailVerification")
+	}
+
+	return nil
+}
@@ -0,0 +1,54 @@
+package notify
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	xerrors "github.com/go-faster/errors"
+
+	"github.com/flexer2006/t-t-ogen-go/internal/domain"
+	"github.com/flexer2006/t-t-ogen-go/internal/ports"
+)
+
+// memoryLimit bounds the messages a MemoryNotifier keeps; older ones are
+// dropped first.
+const memoryLimit = 1000
+
+// MemoryNotifier keeps the messages it is asked to send instead of sending
+// them, for tests and local development.
+type MemoryNotifier struct {
+	mu            sync.Mutex
+	verifications []domain.EmailVerification
+}
+
+var _ ports.Notifier = (*MemoryNotifier)(nil)
+
+func NewMemoryNotifier() *MemoryNotifier {
+	return &MemoryNotifier{}
+}
+
+func (n *MemoryNotifier) SendEmailVerification(ctx context.Context, verification domain.EmailVerification) error {
+	if err := ctx.Err(); err != nil {
+		return xerrors.Wrap(err, "notify.MemoryNotifier.SendEmailVerification")
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if len(n.verifications) == memoryLimit {
+		n.verifications = slices.Delete(n.verifications, 0, 1)
+	}
+
+	n.verifications = append(n.verifications, verification)
+
+	return nil
+}
+
+// EmailVerifications returns the verifications sent so far, oldest first.
+func (n *MemoryNotifier) EmailVerifications() []domain.EmailVerification {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return slices.Clone(n.verifications)
+}
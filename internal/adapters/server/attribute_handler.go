@@ -0,0 +1,170 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	xerrors "github.com/go-faster/errors"
+
+	api "github.com/flexer2006/t-t-ogen-go/generated"
+	"github.com/flexer2006/t-t-ogen-go/internal/domain"
+	"github.com/flexer2006/t-t-ogen-go/internal/ports"
+)
+
+func (h *UserHandler) GetAttributeSchema(ctx context.Context, _ api.GetAttributeSchemaParams) (*api.AttributeSchema, error) {
+	schema, err := h.attributes.GetAttributeSchema(ctx)
+	if err != nil {
+		return nil, xerrors.Wrap(err, "server.UserHandler.GetAttributeSchema")
+	}
+
+	return toAPIAttributeSchema(schema), nil
+}
+
+func (h *UserHandler) PutAttributeSchema(ctx context.Context, req *api.AttributeSchema, _ api.PutAttributeSchemaParams) (api.PutAttributeSchemaRes, error) {
+	if req == nil {
+		return nil, errNilRequest
+	}
+
+	schema := make([]domain.AttributeDefinition, len(req.Attributes))
+
+	for i, definition := range req.Attributes {
+		schema[i] = domain.AttributeDefinition{
+			Name:        definition.Name,
+			Type:        domain.AttributeType(definition.Type),
+			Required:    definition.Required.Or(false),
+			Unique:      definition.Unique.Or(false),
+			Pattern:     definition.Pattern.Or(""),
+			Description: definition.Description.Or(""),
+		}
+
+		for _, value := range definition.Enum {
+			schema[i].Enum = append(schema[i].Enum, fromAPIAttributeValue(value))
+		}
+	}
+
+	stored, err := h.attributes.PutAttributeSchema(ctx, schema)
+	if err != nil {
+		if errors.Is(err, ports.ErrAttributeNotUnique) {
+			return &api.PutAttributeSchemaConflict{}, nil
+		}
+
+		return nil, xerrors.Wrap(err, "server.UserHandler.PutAttributeSchema")
+	}
+
+	return toAPIAttributeSchema(stored), nil
+}
+
+// attributeFilter reads the attribute parameter of listUsers, whose values
+// the generated server has already matched against "name:value".
+func attributeFilter(params []string) (domain.AttributeFilter, error) {
+	if len(params) == 0 {
+		return nil, nil
+	}
+
+	var problems []string
+
+	filter := make(domain.AttributeFilter, len(params))
+
+	for _, param := range params {
+		name, value, _ := strings.Cut(param, ":")
+		if _, dup := filter[name]; dup {
+			problems = append(problems, fmt.Sprintf("attribute %q: filtered twice", name))
+
+			continue
+		}
+
+		filter[name] = value
+	}
+
+	if len(problems) > 0 {
+		return nil, &ports.ValidationError{Err: ports.ErrInvalidAttributes, Problems: problems}
+	}
+
+	return filter, nil
+}
+
+func toAPIAttributeSchema(schema []domain.AttributeDefinition) *api.AttributeSchema {
+	result := &api.AttributeSchema{Attributes: make([]api.AttributeDefinition, len(schema))}
+
+	for i, definition := range schema {
+		apiDefinition := api.AttributeDefinition{
+			Name:     definition.Name,
+			Type:     api.AttributeDefinitionType(definition.Type),
+			Required: api.NewOptBool(definition.Required),
+			Unique:   api.NewOptBool(definition.Unique),
+		}
+
+		for _, value := range definition.Enum {
+			apiDefinition.Enum = append(apiDefinition.Enum, toAPIAttributeValue(value))
+		}
+
+		if definition.Pattern != "" {
+			apiDefinition.Pattern = api.NewOptString(definition.Pattern)
+		}
+
+		if definition.Description != "" {
+			apiDefinition.Description = api.NewOptString(definition.Description)
+		}
+
+		result.Attributes[i] = apiDefinition
+	}
+
+	return result
+}
+
+func toAPIAttributes(attributes domain.Attributes) api.Attributes {
+	result := make(api.Attributes, len(attributes))
+
+	for name, value := range attributes {
+		result[name] = toAPIAttributeValue(value)
+	}
+
+	return result
+}
+
+// fromAPIAttributes returns nil when the request leaves attributes out, so
+// an update keeps the stored ones.
+func fromAPIAttributes(opt api.OptAttributes) domain.Attributes {
+	attributes, ok := opt.Get()
+	if !ok {
+		return nil
+	}
+
+	result := make(domain.Attributes, len(attributes))
+
+	for name, value := range attributes {
+		result[name] = fromAPIAttributeValue(value)
+	}
+
+	return result
+}
+
+func toAPIAttributeValue(value any) api.AttributeValue {
+	switch value := value.(type) {
+	case string:
+		return api.NewStringAttributeValue(value)
+	case int64:
+		return api.NewFloat64AttributeValue(float64(value))
+	case float64:
+		return api.NewFloat64AttributeValue(value)
+	case bool:
+		return api.NewBoolAttributeValue(value)
+	default:
+		return api.NewStringAttributeValue(fmt.Sprint(value))
+	}
+}
+
+// fromAPIAttributeValue leaves numbers as float64; the service converts them
+// to the type of the attribute's definition.
+func fromAPIAttributeValue(value api.AttributeValue) any {
+	switch value.Type {
+	case api.Float64AttributeValue:
+		return value.Float64
+	case api.BoolAttributeValue:
+		return value.Bool
+	default:
+		return value.String
+	}
+}
@@ -0,0 +1,307 @@
+package server
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	xerrors "github.com/go-faster/errors"
+)
+
+const (
+	EncodingGzip    = "gzip"
+	EncodingDeflate = "deflate"
+
+	supportedEncodings = EncodingGzip + ", " + EncodingDeflate
+)
+
+var errInvalidCompressionLevel = xerrors.New("invalid compression level")
+
+type CompressionOptions struct {
+	// MinSize is the smallest response body that is compressed.
+	MinSize int
+	// Level is a compress/gzip level, also used for deflate.
+	Level int
+	// MaxDecompressedBytes caps the decoded size of a compressed request
+	// body.
+	MaxDecompressedBytes int64
+}
+
+// Compressor negotiates gzip or deflate (zlib, as HTTP defines it) response
+// encoding from Accept-Encoding and decodes compressed request bodies.
+// Encoders are pooled because each one allocates several hundred kilobytes.
+type Compressor struct {
+	options CompressionOptions
+	pools   map[string]*sync.Pool
+}
+
+type encoder interface {
+	io.WriteCloser
+	Flush() error
+	Reset(w io.Writer)
+}
+
+func NewCompressor(options CompressionOptions) (*Compressor, error) {
+	if options.Level < gzip.HuffmanOnly || options.Level > gzip.BestCompression {
+		return nil, xerrors.Wrapf(errInvalidCompressionLevel, "server.NewCompressor: %d", options.Level)
+	}
+
+	// The level is valid for both encoders, so the constructors cannot fail.
+	return &Compressor{
+		options: options,
+		pools: map[string]*sync.Pool{
+			EncodingGzip: {New: func() any {
+				w, _ := gzip.NewWriterLevel(io.Discard, options.Level)
+				return w
+			}},
+			EncodingDeflate: {New: func() any {
+				w, _ := zlib.NewWriterLevel(io.Discard, options.Level)
+				return w
+			}},
+		},
+	}, nil
+}
+
+func (c *Compressor) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Accept-Encoding")
+
+		r, ok := c.decodeRequest(w, r)
+		if !ok {
+			return
+		}
+
+		encoding := negotiateEncoding(r.Header.Values("Accept-Encoding"))
+		if encoding == "" || r.Method == http.MethodHead {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		cw := &compressWriter{ResponseWriter: w, compressor: c, encoding: encoding}
+		defer cw.close()
+
+		next.ServeHTTP(cw, r)
+	})
+}
+
+// decodeRequest replaces a compressed body with its decoded form. It answers
+// the request itself and reports false when the body cannot be decoded.
+func (c *Compressor) decodeRequest(w http.ResponseWriter, r *http.Request) (*http.Request, bool) {
+	var (
+		decoded io.ReadCloser
+		err     error
+	)
+
+	switch strings.ToLower(strings.TrimSpace(r.Header.Get("Content-Encoding"))) {
+	case "", "identity":
+		return r, true
+	case EncodingGzip:
+		decoded, err = gzip.NewReader(r.Body)
+	case EncodingDeflate:
+		decoded, err = zlib.NewReader(r.Body)
+	default:
+		w.Header().Set("Accept-Encoding", supportedEncodings)
+		writeProblem(w, http.StatusUnsupportedMediaType, "unsupported content encoding; use "+supportedEncodings)
+
+		return nil, false
+	}
+
+	if err != nil {
+		writeProblem(w, http.StatusBadRequest, "malformed compressed request body")
+
+		return nil, false
+	}
+
+	decodedRequest := r.Clone(r.Context())
+	decodedRequest.Header.Del("Content-Encoding")
+	decodedRequest.Header.Del("Content-Length")
+	decodedRequest.ContentLength = -1
+	decodedRequest.Body = &decodedBody{
+		Reader:  http.MaxBytesReader(w, decoded, c.options.MaxDecompressedBytes),
+		decoded: decoded,
+		raw:     r.Body,
+	}
+
+	return decodedRequest, true
+}
+
+type decodedBody struct {
+	io.Reader
+	decoded io.Closer
+	raw     io.Closer
+}
+
+func (b *decodedBody) Close() error {
+	return errors.Join(b.decoded.Close(), b.raw.Close())
+}
+
+// negotiateEncoding picks the accepted encoding with the highest quality,
+// preferring gzip on ties, or returns an empty string for identity.
+func negotiateEncoding(headers []string) string {
+	quality := map[string]float64{}
+
+	for _, header := range headers {
+		for part := range strings.SplitSeq(header, ",") {
+			coding, params, _ := strings.Cut(part, ";")
+			coding = strings.ToLower(strings.TrimSpace(coding))
+
+			q := 1.0
+
+			if value, ok := strings.CutPrefix(strings.ReplaceAll(params, " ", ""), "q="); ok {
+				parsed, err := strconv.ParseFloat(value, 64)
+				if err != nil {
+					continue
+				}
+
+				q = parsed
+			}
+
+			quality[coding] = q
+		}
+	}
+
+	best, bestQuality := "", 0.0
+
+	for _, coding := range []string{EncodingGzip, EncodingDeflate} {
+		q, ok := quality[coding]
+		if !ok {
+			q, ok = quality["*"]
+		}
+
+		if ok && q > bestQuality {
+			best, bestQuality = coding, q
+		}
+	}
+
+	return best
+}
+
+// compressWriter holds back the status and the first MinSize bytes, so small
+// responses go out unchanged and Content-Length is dropped only for bodies
+// that are actually compressed.
+type compressWriter struct {
+	http.ResponseWriter
+	compressor *Compressor
+	encoding   string
+
+	status  int
+	buf     []byte
+	started bool
+	encoder encoder
+}
+
+func (w *compressWriter) WriteHeader(status int) {
+	if w.started || w.status != 0 {
+		return
+	}
+
+	if status < http.StatusOK {
+		w.ResponseWriter.WriteHeader(status)
+		return
+	}
+
+	w.status = status
+
+	if status == http.StatusNoContent || status == http.StatusNotModified || w.Header().Get("Content-Encoding") != "" {
+		w.start(false)
+	}
+}
+
+func (w *compressWriter) Write(p []byte) (int, error) {
+	if w.status == 0 {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	if !w.started {
+		w.buf = append(w.buf, p...)
+
+		if len(w.buf) < w.compressor.options.MinSize {
+			return len(p), nil
+		}
+
+		buffered := w.buf
+		w.buf = nil
+		w.start(true)
+
+		if _, err := w.encoder.Write(buffered); err != nil {
+			return 0, err
+		}
+
+		return len(p), nil
+	}
+
+	if w.encoder != nil {
+		return w.encoder.Write(p)
+	}
+
+	return w.ResponseWriter.Write(p)
+}
+
+// Flush commits to compression, since a flushing handler is streaming.
+func (w *compressWriter) Flush() {
+	if w.status == 0 {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	if !w.started {
+		buffered := w.buf
+		w.buf = nil
+		w.start(true)
+
+		_, _ = w.encoder.Write(buffered)
+	}
+
+	if w.encoder != nil {
+		_ = w.encoder.Flush()
+	}
+
+	_ = http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+func (w *compressWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func (w *compressWriter) start(compress bool) {
+	w.started = true
+
+	if compress {
+		header := w.Header()
+		header.Del("Content-Length")
+		header.Set("Content-Encoding", w.encoding)
+
+		w.encoder = w.compressor.pools[w.encoding].Get().(encoder)
+		w.encoder.Reset(w.ResponseWriter)
+	}
+
+	w.ResponseWriter.WriteHeader(w.status)
+}
+
+func (w *compressWriter) close() {
+	if w.status == 0 {
+		// The handler wrote nothing; net/http sends the implicit 200.
+		return
+	}
+
+	if !w.started {
+		buffered := w.buf
+		w.buf = nil
+		w.start(false)
+
+		_, _ = w.ResponseWriter.Write(buffered)
+
+		return
+	}
+
+	if w.encoder != nil {
+		_ = w.encoder.Close()
+		w.encoder.Reset(io.Discard)
+		w.compressor.pools[w.encoding].Put(w.encoder)
+		w.encoder = nil
+	}
+}
@@ -0,0 +1,183 @@
+package server
+
+import (
+	"net/http"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const corsWildcard = "*"
+
+type CORSOptions struct {
+	// AllowedOrigins are origins such as "https://admin.example.com"; "*"
+	// matches any run of characters within the origin, so
+	// "https://*.example.com" admits every subdomain.
+	AllowedOrigins   []string
+	AllowedMethods   []string
+	AllowedHeaders   []string
+	ExposedHeaders   []string
+	AllowCredentials bool
+	MaxAge           time.Duration
+}
+
+// CORS answers preflight requests for routes of the generated server, which
+// would otherwise reject OPTIONS, and marks responses to allowed origins as
+// readable by browsers.
+type CORS struct {
+	options        CORSOptions
+	allowedHeaders []string
+}
+
+func NewCORS(options CORSOptions) *CORS {
+	allowedHeaders := make([]string, len(options.AllowedHeaders))
+
+	for i, header := range options.AllowedHeaders {
+		allowedHeaders[i] = http.CanonicalHeaderKey(header)
+	}
+
+	return &CORS{options: options, allowedHeaders: allowedHeaders}
+}
+
+// Handler wraps next; routes tells which methods a path supports.
+func (c *CORS) Handler(next http.Handler, routes RouteFinder) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		if origin == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		header := w.Header()
+		header.Add("Vary", "Origin")
+
+		requestedMethod := r.Header.Get("Access-Control-Request-Method")
+		if r.Method != http.MethodOptions || requestedMethod == "" {
+			if c.allowOrigin(header, origin) && len(c.options.ExposedHeaders) > 0 {
+				header.Set("Access-Control-Expose-Headers", strings.Join(c.options.ExposedHeaders, ", "))
+			}
+
+			next.ServeHTTP(w, r)
+
+			return
+		}
+
+		methods := c.routeMethods(routes, r.URL.Path)
+		if len(methods) == 0 {
+			// Unknown paths get the router's 404.
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		header.Add("Vary", "Access-Control-Request-Method")
+		header.Add("Vary", "Access-Control-Request-Headers")
+
+		requestedHeaders := splitHeaderList(r.Header.Values("Access-Control-Request-Headers"))
+
+		if !c.allowOrigin(header, origin) {
+			writeProblem(w, http.StatusForbidden, "origin is not allowed")
+			return
+		}
+
+		if !slices.Contains(methods, requestedMethod) {
+			writeProblem(w, http.StatusForbidden, "method is not allowed for this resource")
+			return
+		}
+
+		allowedHeaders, ok := c.allowHeaders(requestedHeaders)
+		if !ok {
+			writeProblem(w, http.StatusForbidden, "request headers are not allowed")
+			return
+		}
+
+		header.Set("Access-Control-Allow-Methods", strings.Join(methods, ", "))
+
+		if len(allowedHeaders) > 0 {
+			header.Set("Access-Control-Allow-Headers", strings.Join(allowedHeaders, ", "))
+		}
+
+		if c.options.MaxAge > 0 {
+			header.Set("Access-Control-Max-Age", strconv.Itoa(int(c.options.MaxAge/time.Second)))
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+// allowOrigin sets the allow headers and reports whether origin may read
+// responses. Credentialed responses name the origin, as browsers require.
+func (c *CORS) allowOrigin(header http.Header, origin string) bool {
+	if !c.originAllowed(origin) {
+		return false
+	}
+
+	if slices.Contains(c.options.AllowedOrigins, corsWildcard) && !c.options.AllowCredentials {
+		header.Set("Access-Control-Allow-Origin", corsWildcard)
+	} else {
+		header.Set("Access-Control-Allow-Origin", origin)
+	}
+
+	if c.options.AllowCredentials {
+		header.Set("Access-Control-Allow-Credentials", "true")
+	}
+
+	return true
+}
+
+func (c *CORS) originAllowed(origin string) bool {
+	for _, pattern := range c.options.AllowedOrigins {
+		if pattern == corsWildcard || pattern == origin {
+			return true
+		}
+
+		if matched, _ := path.Match(pattern, origin); matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+// routeMethods lists the configured methods the generated routers serve at
+// urlPath.
+func (c *CORS) routeMethods(routes RouteFinder, urlPath string) []string {
+	var methods []string
+
+	for _, method := range c.options.AllowedMethods {
+		if _, ok := routes(method, urlPath); ok {
+			methods = append(methods, method)
+		}
+	}
+
+	return methods
+}
+
+func (c *CORS) allowHeaders(requested []string) ([]string, bool) {
+	if slices.Contains(c.allowedHeaders, corsWildcard) {
+		return requested, true
+	}
+
+	for _, header := range requested {
+		if !slices.Contains(c.allowedHeaders, http.CanonicalHeaderKey(header)) {
+			return nil, false
+		}
+	}
+
+	return c.allowedHeaders, true
+}
+
+func splitHeaderList(values []string) []string {
+	var list []string
+
+	for _, value := range values {
+		for item := range strings.SplitSeq(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				list = append(list, item)
+			}
+		}
+	}
+
+	return list
+}
@@ -0,0 +1,86 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"math"
+	"time"
+
+	xerrors "github.com/go-faster/errors"
+
+	api "github.com/flexer2006/t-t-ogen-go/generated"
+	"github.com/flexer2006/t-t-ogen-go/internal/ports"
+)
+
+func (h *UserHandler) SetUserPassword(ctx context.Context, req *api.PasswordSet, params api.SetUserPasswordParams) (api.SetUserPasswordRes, error) {
+	if req == nil {
+		return nil, errNilRequest
+	}
+
+	if err := h.credentials.SetPassword(ctx, params.ID, string(req.GetPassword())); err != nil {
+		if errors.Is(err, ports.ErrUserNotFound) {
+			return &api.SetUserPasswordNotFound{}, nil
+		}
+
+		return nil, xerrors.Wrap(err, "server.UserHandler.SetUserPassword")
+	}
+
+	return &api.SetUserPasswordNoContent{}, nil
+}
+
+func (h *UserHandler) ChangeUserPassword(ctx context.Context, req *api.PasswordChange, params api.ChangeUserPasswordParams) (api.ChangeUserPasswordRes, error) {
+	if req == nil {
+		return nil, errNilRequest
+	}
+
+	err := h.credentials.ChangePassword(ctx, params.ID, string(req.GetCurrentPassword()), string(req.GetNewPassword()))
+	if err != nil {
+		if locked, ok := lockedResponse(err); ok {
+			return locked, nil
+		}
+
+		switch {
+		case errors.Is(err, ports.ErrUserNotFound):
+			return &api.ChangeUserPasswordNotFound{}, nil
+		case errors.Is(err, ports.ErrNoPassword):
+			return &api.ChangeUserPasswordConflict{}, nil
+		case errors.Is(err, ports.ErrInvalidCredentials):
+			return &api.ChangeUserPasswordForbidden{}, nil
+		}
+
+		return nil, xerrors.Wrap(err, "server.UserHandler.ChangeUserPassword")
+	}
+
+	return &api.ChangeUserPasswordNoContent{}, nil
+}
+
+func (h *UserHandler) CreateSession(ctx context.Context, req *api.Login, _ api.CreateSessionParams) (api.CreateSessionRes, error) {
+	if req == nil {
+		return nil, errNilRequest
+	}
+
+	login, err := h.sessions.Login(ctx, req.GetUsername(), string(req.GetPassword()))
+	if err != nil {
+		if errors.Is(err, ports.ErrInvalidCredentials) {
+			return &api.CreateSessionUnauthorized{}, nil
+		}
+
+		return nil, xerrors.Wrap(err, "server.UserHandler.CreateSession")
+	}
+
+	return &api.LoginResult{
+		User:    toAPIUser(login.User),
+		Session: toAPISession(login.Session),
+		Token:   login.Token,
+	}, nil
+}
+
+// lockedResponse answers a locked account with the seconds until it unlocks.
+func lockedResponse(err error) (*api.Locked, bool) {
+	var lockedErr *ports.LockedError
+	if !errors.As(err, &lockedErr) {
+		return nil, false
+	}
+
+	return &api.Locked{RetryAfter: int(math.Ceil(time.Until(lockedErr.Until).Seconds()))}, true
+}
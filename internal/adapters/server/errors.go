@@ -0,0 +1,87 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/go-faster/jx"
+	"github.com/ogen-go/ogen/ogenerrors"
+
+	"github.com/flexer2006/t-t-ogen-go/internal/ports"
+)
+
+const (
+	problemContentType = "application/problem+json"
+	authenticateHeader = `Bearer realm="user-service"`
+)
+
+// ErrorHandler reports authentication, authorization, tenant resolution, rate
+// limiting and read-only storage failures as RFC 9457 problem documents and
+// leaves every other error to the ogen default.
+func ErrorHandler(ctx context.Context, w http.ResponseWriter, r *http.Request, err error) {
+	var (
+		securityErr   *ogenerrors.SecurityError
+		rateLimitErr  *rateLimitError
+		validationErr *ports.ValidationError
+	)
+
+	switch {
+	case errors.As(err, &rateLimitErr):
+		w.Header().Set("Retry-After", ceilSeconds(rateLimitErr.retryAfter))
+		setRateLimitHeaders(w.Header(), rateLimitErr.rateLimitStatus)
+		writeProblem(w, http.StatusTooManyRequests, rateLimitErr.Error())
+	case errors.Is(err, ports.ErrPermissionDenied):
+		recordFailure(ctx, err)
+		writeProblem(w, http.StatusForbidden, securityDetail(err))
+	case errors.Is(err, ports.ErrUnauthenticated) || errors.Is(err, ogenerrors.ErrSecurityRequirementIsNotSatisfied):
+		recordFailure(ctx, err)
+		w.Header().Set("WWW-Authenticate", authenticateHeader)
+		writeProblem(w, http.StatusUnauthorized, securityDetail(err))
+	case errors.Is(err, ports.ErrTenantRequired):
+		writeProblem(w, http.StatusBadRequest, "tenant is required; set the "+TenantHeader+" header")
+	case errors.Is(err, ports.ErrTenantNotFound):
+		writeProblem(w, http.StatusBadRequest, "unknown tenant")
+	case errors.As(err, &validationErr):
+		writeProblem(w, http.StatusBadRequest, validationErr.Error())
+	case errors.Is(err, ports.ErrStorageReadOnly):
+		// The process that takes over the storage serves the retry.
+		w.Header().Set("Retry-After", "1")
+		writeProblem(w, http.StatusServiceUnavailable, "service is restarting; retry the request")
+	case errors.As(err, &securityErr):
+		recordFailure(ctx, err)
+		writeProblem(w, http.StatusInternalServerError, "authentication backend failure")
+	default:
+		ogenerrors.DefaultErrorHandler(ctx, w, r, err)
+	}
+}
+
+// securityDetail reports only the class of a security failure. The error
+// itself may name keys, subjects or internal call sites, so it goes to the
+// access log instead.
+func securityDetail(err error) string {
+	if errors.Is(err, ports.ErrPermissionDenied) {
+		return "caller is not permitted to perform this operation"
+	}
+
+	return "caller is not authenticated"
+}
+
+func writeProblem(w http.ResponseWriter, status int, detail string) {
+	var e jx.Encoder
+
+	e.ObjStart()
+	e.FieldStart("type")
+	e.Str("about:blank")
+	e.FieldStart("title")
+	e.Str(http.StatusText(status))
+	e.FieldStart("status")
+	e.Int(status)
+	e.FieldStart("detail")
+	e.Str(detail)
+	e.ObjEnd()
+
+	w.Header().Set("Content-Type", problemContentType)
+	w.WriteHeader(status)
+	_, _ = w.Write(e.Bytes())
+}
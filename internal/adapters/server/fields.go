@@ -0,0 +1,77 @@
+package server
+
+import (
+	api "github.com/flexer2006/t-t-ogen-go/generated"
+	"github.com/flexer2006/t-t-ogen-go/internal/domain"
+)
+
+// userFields is the set of User properties a response carries. Properties
+// that were not selected are left unset on api.PartialUser, and its encoder
+// omits unset properties from the response.
+type userFields struct {
+	id            bool
+	displayName   bool
+	username      bool
+	email         bool
+	emailVerified bool
+	attributes    bool
+}
+
+// selectUserFields reads the fields parameter, which the generated server
+// has already validated against the User properties.
+func selectUserFields(fields []api.UserField) userFields {
+	if len(fields) == 0 {
+		return userFields{id: true, displayName: true, username: true, email: true, emailVerified: true, attributes: true}
+	}
+
+	var selected userFields
+
+	for _, field := range fields {
+		switch field {
+		case api.UserFieldID:
+			selected.id = true
+		case api.UserFieldDisplayName:
+			selected.displayName = true
+		case api.UserFieldUsername:
+			selected.username = true
+		case api.UserFieldEmail:
+			selected.email = true
+		case api.UserFieldEmailVerified:
+			selected.emailVerified = true
+		case api.UserFieldAttributes:
+			selected.attributes = true
+		}
+	}
+
+	return selected
+}
+
+func (f userFields) toAPIUser(user domain.User) api.PartialUser {
+	var result api.PartialUser
+
+	if f.id {
+		result.ID = api.NewOptUUID(user.ID)
+	}
+
+	if f.displayName {
+		result.DisplayName = api.NewOptString(user.Name)
+	}
+
+	if f.username {
+		result.Username = api.NewOptString(user.Username)
+	}
+
+	if f.email {
+		result.Email = optEmail(user.Email)
+	}
+
+	if f.emailVerified {
+		result.EmailVerified = api.NewOptBool(user.EmailVerified)
+	}
+
+	if f.attributes {
+		result.Attributes = api.NewOptAttributes(toAPIAttributes(user.Attributes))
+	}
+
+	return result
+}
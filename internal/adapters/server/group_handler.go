@@ -0,0 +1,165 @@
+package server
+
+import (
+	"context"
+	"errors"
+
+	xerrors "github.com/go-faster/errors"
+
+	api "github.com/flexer2006/t-t-ogen-go/generated"
+	"github.com/flexer2006/t-t-ogen-go/internal/domain"
+	"github.com/flexer2006/t-t-ogen-go/internal/ports"
+)
+
+func (h *UserHandler) ListGroups(ctx context.Context, _ api.ListGroupsParams) ([]api.Group, error) {
+	groups, err := h.groups.ListGroups(ctx)
+	if err != nil {
+		return nil, xerrors.Wrap(err, "server.UserHandler.ListGroups")
+	}
+
+	return toAPIGroups(groups), nil
+}
+
+func (h *UserHandler) CreateGroup(ctx context.Context, req *api.NewGroup, _ api.CreateGroupParams) (api.CreateGroupRes, error) {
+	if req == nil {
+		return nil, errNilRequest
+	}
+
+	group, err := h.groups.CreateGroup(ctx, req.GetName(), req.GetDescription().Or(""))
+	if err != nil {
+		if errors.Is(err, ports.ErrGroupNameTaken) {
+			return &api.CreateGroupConflict{}, nil
+		}
+
+		return nil, xerrors.Wrap(err, "server.UserHandler.CreateGroup")
+	}
+
+	apiGroup := toAPIGroup(group)
+
+	return &apiGroup, nil
+}
+
+func (h *UserHandler) GetGroup(ctx context.Context, params api.GetGroupParams) (api.GetGroupRes, error) {
+	group, err := h.groups.GetGroup(ctx, params.ID)
+	if err != nil {
+		if errors.Is(err, ports.ErrGroupNotFound) {
+			return &api.GetGroupNotFound{}, nil
+		}
+
+		return nil, xerrors.Wrap(err, "server.UserHandler.GetGroup")
+	}
+
+	apiGroup := toAPIGroup(group)
+
+	return &apiGroup, nil
+}
+
+func (h *UserHandler) UpdateGroup(ctx context.Context, req *api.UpdateGroup, params api.UpdateGroupParams) (api.UpdateGroupRes, error) {
+	if req == nil {
+		return nil, errNilRequest
+	}
+
+	group, err := h.groups.UpdateGroup(ctx, params.ID, optStringToPtr(req.GetName()), optStringToPtr(req.GetDescription()))
+	if err != nil {
+		switch {
+		case errors.Is(err, ports.ErrGroupNotFound):
+			return &api.UpdateGroupNotFound{}, nil
+		case errors.Is(err, ports.ErrGroupNameTaken):
+			return &api.UpdateGroupConflict{}, nil
+		}
+
+		return nil, xerrors.Wrap(err, "server.UserHandler.UpdateGroup")
+	}
+
+	apiGroup := toAPIGroup(group)
+
+	return &apiGroup, nil
+}
+
+func (h *UserHandler) DeleteGroup(ctx context.Context, params api.DeleteGroupParams) (api.DeleteGroupRes, error) {
+	if err := h.groups.DeleteGroup(ctx, params.ID); err != nil {
+		if errors.Is(err, ports.ErrGroupNotFound) {
+			return &api.DeleteGroupNotFound{}, nil
+		}
+
+		return nil, xerrors.Wrap(err, "server.UserHandler.DeleteGroup")
+	}
+
+	return &api.DeleteGroupNoContent{}, nil
+}
+
+func (h *UserHandler) ListGroupMembers(ctx context.Context, params api.ListGroupMembersParams) (api.ListGroupMembersRes, error) {
+	users, err := h.groups.ListGroupMembers(ctx, params.ID)
+	if err != nil {
+		if errors.Is(err, ports.ErrGroupNotFound) {
+			return &api.ListGroupMembersNotFound{}, nil
+		}
+
+		return nil, xerrors.Wrap(err, "server.UserHandler.ListGroupMembers")
+	}
+
+	result := make(api.ListGroupMembersOKApplicationJSON, len(users))
+
+	for i, user := range users {
+		result[i] = toAPIUser(user)
+	}
+
+	return &result, nil
+}
+
+func (h *UserHandler) AddGroupMember(ctx context.Context, params api.AddGroupMemberParams) (api.AddGroupMemberRes, error) {
+	if err := h.groups.AddGroupMember(ctx, params.ID, params.UserId); err != nil {
+		if errors.Is(err, ports.ErrGroupNotFound) || errors.Is(err, ports.ErrUserNotFound) {
+			return &api.AddGroupMemberNotFound{}, nil
+		}
+
+		return nil, xerrors.Wrap(err, "server.UserHandler.AddGroupMember")
+	}
+
+	return &api.AddGroupMemberNoContent{}, nil
+}
+
+func (h *UserHandler) RemoveGroupMember(ctx context.Context, params api.RemoveGroupMemberParams) (api.RemoveGroupMemberRes, error) {
+	if err := h.groups.RemoveGroupMember(ctx, params.ID, params.UserId); err != nil {
+		if errors.Is(err, ports.ErrGroupNotFound) || errors.Is(err, ports.ErrNotGroupMember) {
+			return &api.RemoveGroupMemberNotFound{}, nil
+		}
+
+		return nil, xerrors.Wrap(err, "server.UserHandler.RemoveGroupMember")
+	}
+
+	return &api.RemoveGroupMemberNoContent{}, nil
+}
+
+func (h *UserHandler) ListUserGroups(ctx context.Context, params api.ListUserGroupsParams) (api.ListUserGroupsRes, error) {
+	groups, err := h.groups.ListUserGroups(ctx, params.ID)
+	if err != nil {
+		if errors.Is(err, ports.ErrUserNotFound) {
+			return &api.ListUserGroupsNotFound{}, nil
+		}
+
+		return nil, xerrors.Wrap(err, "server.UserHandler.ListUserGroups")
+	}
+
+	result := api.ListUserGroupsOKApplicationJSON(toAPIGroups(groups))
+
+	return &result, nil
+}
+
+func toAPIGroups(groups []domain.Group) []api.Group {
+	result := make([]api.Group, len(groups))
+
+	for i, group := range groups {
+		result[i] = toAPIGroup(group)
+	}
+
+	return result
+}
+
+func toAPIGroup(group domain.Group) api.Group {
+	return api.Group{
+		ID:          group.ID,
+		Name:        group.Name,
+		Description: group.Description,
+	}
+}
@@ -0,0 +1,115 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+type InFlightRequest struct {
+	Method    string
+	Path      string
+	RequestID string
+	Started   time.Time
+}
+
+// InFlightTracker records requests that have entered the handler chain and
+// not yet returned, so shutdown can wait for them and report stragglers.
+type InFlightTracker struct {
+	mu       sync.Mutex
+	next     uint64
+	requests map[uint64]InFlightRequest
+	idle     chan struct{}
+}
+
+func NewInFlightTracker() *InFlightTracker {
+	idle := make(chan struct{})
+	close(idle)
+
+	return &InFlightTracker{
+		requests: make(map[uint64]InFlightRequest),
+		idle:     idle,
+	}
+}
+
+// Handler tracks requests through next. It records the request ID assigned
+// by RequestID, which must run first.
+func (t *InFlightTracker) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID, _ := RequestIDFromContext(r.Context())
+
+		id := t.begin(InFlightRequest{
+			Method:    r.Method,
+			Path:      r.URL.Path,
+			RequestID: requestID,
+			Started:   time.Now(),
+		})
+		defer t.end(id)
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Wait blocks until no request is in flight or ctx is done.
+func (t *InFlightTracker) Wait(ctx context.Context) error {
+	for {
+		t.mu.Lock()
+		idle := t.idle
+		empty := len(t.requests) == 0
+		t.mu.Unlock()
+
+		if empty {
+			return nil
+		}
+
+		select {
+		case <-idle:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (t *InFlightTracker) Snapshot() []InFlightRequest {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	snapshot := make([]InFlightRequest, 0, len(t.requests))
+
+	for _, request := range t.requests {
+		snapshot = append(snapshot, request)
+	}
+
+	sort.Slice(snapshot, func(i, j int) bool {
+		return snapshot[i].Started.Before(snapshot[j].Started)
+	})
+
+	return snapshot
+}
+
+func (t *InFlightTracker) begin(request InFlightRequest) uint64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if len(t.requests) == 0 {
+		t.idle = make(chan struct{})
+	}
+
+	t.next++
+	t.requests[t.next] = request
+
+	return t.next
+}
+
+func (t *InFlightTracker) end(id uint64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.requests, id)
+
+	if len(t.requests) == 0 {
+		close(t.idle)
+	}
+}
@@ -0,0 +1,291 @@
+package server
+
+import (
+	"errors"
+	"net"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+
+	xerrors "github.com/go-faster/errors"
+)
+
+const (
+	// UnixAddressPrefix marks an address as a Unix socket path.
+	UnixAddressPrefix = "unix:"
+	// SystemdAddress selects the first listener passed with the systemd
+	// LISTEN_FDS protocol; "systemd:NAME" selects one by LISTEN_FDNAMES.
+	SystemdAddress = "systemd"
+
+	envListenFDs     = "LISTEN_FDS"
+	envListenPID     = "LISTEN_PID"
+	envListenFDNames = "LISTEN_FDNAMES"
+	// EnvHandoffListenFD names the descriptor of the listener that a listener
+	// handoff passes to the child. It is separate from LISTEN_FDS because
+	// the parent cannot know the pid of the child to set LISTEN_PID.
+	EnvHandoffListenFD = "USER_SERVICE_HANDOFF_LISTEN_FD"
+	// EnvHandoffReadyFD names the descriptor a child started by a listener
+	// handoff writes to once it serves.
+	EnvHandoffReadyFD = "USER_SERVICE_HANDOFF_READY_FD"
+
+	listenFDsStart = 3
+	// handoffName labels the listener passed by HandoffEnv. The child runs
+	// with its parent's configuration, so the listener replaces whatever
+	// address is configured.
+	handoffName = "handoff"
+)
+
+var (
+	ErrNoInheritedListener = xerrors.New("no inherited listener")
+	errAddressInUse        = xerrors.New("socket is in use by another process")
+	errUnsupportedListener = xerrors.New("listener cannot be passed to another process")
+)
+
+type ListenOptions struct {
+	// SocketMode sets the permissions of a Unix socket file.
+	SocketMode os.FileMode
+}
+
+// Listen returns the listener for address. A listener inherited from a
+// handoff wins over binding a new socket, so the new process keeps serving on
+// its parent's socket.
+func Listen(address string, options ListenOptions) (net.Listener, error) {
+	inherited, err := inheritedListeners()
+	if err != nil {
+		return nil, xerrors.Wrap(err, "server.Listen: inherited listeners")
+	}
+
+	if listener, ok := inherited.lookup(address); ok {
+		return listener, nil
+	}
+
+	if address == SystemdAddress || strings.HasPrefix(address, SystemdAddress+":") {
+		return nil, xerrors.Wrapf(ErrNoInheritedListener, "server.Listen: %q", address)
+	}
+
+	if path, ok := strings.CutPrefix(address, UnixAddressPrefix); ok {
+		listener, err := listenUnix(path, options.SocketMode)
+		if err != nil {
+			return nil, xerrors.Wrap(err, "server.Listen")
+		}
+
+		return listener, nil
+	}
+
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return nil, xerrors.Wrap(err, "server.Listen")
+	}
+
+	return listener, nil
+}
+
+// ListenerAddress formats the address of listener in the syntax accepted by
+// Listen.
+func ListenerAddress(listener net.Listener) string {
+	addr := listener.Addr()
+	if addr.Network() == "unix" {
+		return UnixAddressPrefix + addr.String()
+	}
+
+	return addr.String()
+}
+
+func listenUnix(path string, mode os.FileMode) (net.Listener, error) {
+	if info, err := os.Lstat(path); err == nil && info.Mode()&os.ModeSocket != 0 {
+		// A socket file left behind by a crashed process is removed, one
+		// that still accepts connections is not.
+		if conn, err := net.Dial("unix", path); err == nil {
+			conn.Close()
+
+			return nil, xerrors.Wrapf(errAddressInUse, "%s", path)
+		}
+
+		if err := os.Remove(path); err != nil {
+			return nil, xerrors.Wrap(err, "remove stale socket")
+		}
+	}
+
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, xerrors.Wrap(err, "listen")
+	}
+
+	if mode != 0 {
+		if err := os.Chmod(path, mode); err != nil {
+			listener.Close()
+
+			return nil, xerrors.Wrap(err, "chmod socket")
+		}
+	}
+
+	return listener, nil
+}
+
+type inheritedSet struct {
+	mu        sync.Mutex
+	listeners []net.Listener
+	names     []string
+}
+
+// lookup hands out each inherited listener at most once.
+func (s *inheritedSet) lookup(address string) (net.Listener, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	index := slices.Index(s.names, handoffName)
+
+	if index < 0 {
+		switch name, named := strings.CutPrefix(address, SystemdAddress+":"); {
+		case address == SystemdAddress:
+			index = slices.IndexFunc(s.listeners, func(l net.Listener) bool { return l != nil })
+		case named:
+			index = slices.Index(s.names, name)
+		}
+	}
+
+	if index < 0 || s.listeners[index] == nil {
+		return nil, false
+	}
+
+	listener := s.listeners[index]
+	s.listeners[index] = nil
+
+	return listener, true
+}
+
+// inheritedListeners adopts the listener passed by a handoff, or else the
+// listeners passed with the LISTEN_FDS protocol, once per process. It clears
+// the variables of both so children do not inherit them by accident. As
+// sd_listen_fds requires, LISTEN_FDS counts only when LISTEN_PID names this
+// process; otherwise the descriptors were meant for another one.
+var inheritedListeners = sync.OnceValues(func() (*inheritedSet, error) {
+	set := &inheritedSet{}
+
+	rawFD, handoff := os.LookupEnv(EnvHandoffListenFD)
+	rawCount, systemd := os.LookupEnv(envListenFDs)
+	pid := os.Getenv(envListenPID)
+	names := strings.Split(os.Getenv(envListenFDNames), ":")
+
+	for _, key := range []string{EnvHandoffListenFD, envListenFDs, envListenPID, envListenFDNames} {
+		os.Unsetenv(key)
+	}
+
+	if handoff {
+		fd, err := strconv.Atoi(rawFD)
+		if err != nil || fd < listenFDsStart {
+			return nil, xerrors.Errorf("invalid %s %q", EnvHandoffListenFD, rawFD)
+		}
+
+		if err := set.adopt(fd, handoffName); err != nil {
+			return nil, err
+		}
+
+		return set, nil
+	}
+
+	if !systemd || pid != strconv.Itoa(os.Getpid()) {
+		return set, nil
+	}
+
+	count, err := strconv.Atoi(rawCount)
+	if err != nil || count < 0 {
+		return nil, xerrors.Errorf("invalid %s %q", envListenFDs, rawCount)
+	}
+
+	for i := range count {
+		name := ""
+		if i < len(names) {
+			name = names[i]
+		}
+
+		if err := set.adopt(listenFDsStart+i, name); err != nil {
+			return nil, err
+		}
+	}
+
+	return set, nil
+})
+
+// adopt turns an inherited descriptor into a listener of the set.
+func (s *inheritedSet) adopt(fd int, name string) error {
+	syscall.CloseOnExec(fd)
+
+	file := os.NewFile(uintptr(fd), name)
+
+	listener, err := net.FileListener(file)
+	file.Close()
+
+	if err != nil {
+		return xerrors.Wrapf(err, "descriptor %d", fd)
+	}
+
+	s.listeners = append(s.listeners, listener)
+	s.names = append(s.names, name)
+
+	return nil
+}
+
+// ListenerFile duplicates the descriptor of listener for a child process.
+func ListenerFile(listener net.Listener) (*os.File, error) {
+	switch l := listener.(type) {
+	case *net.TCPListener:
+		return l.File()
+	case *net.UnixListener:
+		return l.File()
+	default:
+		return nil, xerrors.Wrapf(errUnsupportedListener, "%T", listener)
+	}
+}
+
+// ReleaseListener keeps the socket file of a Unix listener when it closes,
+// since a child process serves on it. Call it only once the child is ready:
+// until then, closing should still remove the socket.
+func ReleaseListener(listener net.Listener) {
+	if l, ok := listener.(*net.UnixListener); ok {
+		l.SetUnlinkOnClose(false)
+	}
+}
+
+// HandoffEnv returns environ with the variables that pass a listener as the
+// first extra file and the readiness pipe as the second.
+func HandoffEnv(environ []string) []string {
+	env := slices.DeleteFunc(slices.Clone(environ), func(entry string) bool {
+		key, _, _ := strings.Cut(entry, "=")
+
+		return slices.Contains([]string{envListenFDs, envListenPID, envListenFDNames, EnvHandoffListenFD, EnvHandoffReadyFD}, key)
+	})
+
+	return append(env,
+		EnvHandoffListenFD+"="+strconv.Itoa(listenFDsStart),
+		EnvHandoffReadyFD+"="+strconv.Itoa(listenFDsStart+1),
+	)
+}
+
+// NotifyHandoffReady tells the parent of a handoff that this process serves.
+// It does nothing when the process was not started by a handoff.
+func NotifyHandoffReady() error {
+	raw, ok := os.LookupEnv(EnvHandoffReadyFD)
+	if !ok {
+		return nil
+	}
+
+	os.Unsetenv(EnvHandoffReadyFD)
+
+	fd, err := strconv.Atoi(raw)
+	if err != nil {
+		return xerrors.Errorf("server.NotifyHandoffReady: invalid %s %q", EnvHandoffReadyFD, raw)
+	}
+
+	file := os.NewFile(uintptr(fd), "handoff-ready")
+	defer file.Close()
+
+	if _, err := file.WriteString("ready"); err != nil && !errors.Is(err, os.ErrClosed) {
+		return xerrors.Wrap(err, "server.NotifyHandoffReady")
+	}
+
+	return nil
+}
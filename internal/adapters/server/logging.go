@@ -0,0 +1,212 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+	"math/rand/v2"
+	"net/http"
+	"time"
+
+	xerrors "github.com/go-faster/errors"
+	"github.com/google/uuid"
+	"github.com/ogen-go/ogen/middleware"
+	"go.opentelemetry.io/otel/trace"
+
+	api "github.com/flexer2006/t-t-ogen-go/generated"
+	"github.com/flexer2006/t-t-ogen-go/internal/domain"
+)
+
+const (
+	RequestIDHeader    = "X-Request-ID"
+	maxRequestIDLength = 128
+)
+
+var ErrNilLogger = xerrors.New("nil logger")
+
+// AccessLogger writes one structured line per request. Handler owns the
+// request ID, status and latency; Middleware runs inside the generated server
+// after authentication and contributes the principal and trace ID.
+type AccessLogger struct {
+	logger     *slog.Logger
+	sampleRate float64
+}
+
+type accessEntry struct {
+	operation string
+	principal string
+	traceID   string
+	// failure is the error behind a response whose detail withholds it.
+	failure string
+}
+
+type accessEntryKey struct{}
+
+type requestIDKey struct{}
+
+func NewAccessLogger(logger *slog.Logger, successSampleRate float64) (*AccessLogger, error) {
+	if logger == nil {
+		return nil, ErrNilLogger
+	}
+
+	return &AccessLogger{logger: logger, sampleRate: successSampleRate}, nil
+}
+
+func RequestIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(requestIDKey{}).(string)
+
+	return id, ok
+}
+
+// RequestID assigns the request ID that the handlers after it log: the
+// client's X-Request-ID if it is valid, a new one otherwise. The response
+// echoes it.
+func RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := r.Header.Get(RequestIDHeader)
+		if !validRequestID(requestID) {
+			requestID = uuid.NewString()
+		}
+
+		w.Header().Set(RequestIDHeader, requestID)
+
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), requestIDKey{}, requestID)))
+	})
+}
+
+func (l *AccessLogger) Middleware() api.Middleware {
+	return func(req middleware.Request, next middleware.Next) (middleware.Response, error) {
+		if entry, ok := req.Context.Value(accessEntryKey{}).(*accessEntry); ok {
+			entry.operation = req.OperationName
+
+			if principal, ok := domain.PrincipalFromContext(req.Context); ok {
+				entry.principal = principal.Method + ":" + principal.Subject
+			}
+
+			if spanContext := trace.SpanContextFromContext(req.Context); spanContext.HasTraceID() {
+				entry.traceID = spanContext.TraceID().String()
+			}
+		}
+
+		return next(req)
+	}
+}
+
+// Handler wraps next with access logging. routes resolves the operation name
+// for requests rejected before the middleware runs, e.g. by authentication.
+// The request ID comes from RequestID, which must run first.
+func (l *AccessLogger) Handler(next http.Handler, routes RouteFinder) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+
+		requestID, _ := RequestIDFromContext(r.Context())
+
+		entry := &accessEntry{}
+		ctx := context.WithValue(r.Context(), accessEntryKey{}, entry)
+
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(recorder, r.WithContext(ctx))
+
+		if entry.operation == "" && routes != nil {
+			if operation, ok := routes(r.Method, r.URL.Path); ok {
+				entry.operation = operation
+			}
+		}
+
+		l.log(ctx, r, recorder, entry, requestID, time.Since(start))
+	})
+}
+
+func (l *AccessLogger) log(ctx context.Context, r *http.Request, recorder *statusRecorder, entry *accessEntry, requestID string, elapsed time.Duration) {
+	level := slog.LevelInfo
+
+	switch {
+	case recorder.status >= http.StatusInternalServerError:
+		level = slog.LevelError
+	case recorder.status >= http.StatusBadRequest:
+		level = slog.LevelWarn
+	case l.sampleRate < 1 && rand.Float64() >= l.sampleRate:
+		return
+	}
+
+	if !l.logger.Enabled(ctx, level) {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("request_id", requestID),
+		slog.String("method", r.Method),
+		slog.String("path", r.URL.Path),
+		slog.Int("status", recorder.status),
+		slog.Float64("duration_ms", float64(elapsed)/float64(time.Millisecond)),
+		slog.Int64("bytes", recorder.bytes),
+		slog.String("remote_addr", r.RemoteAddr),
+	}
+
+	if entry.operation != "" {
+		attrs = append(attrs, slog.String("operation", entry.operation))
+	}
+
+	if entry.principal != "" {
+		attrs = append(attrs, slog.String("principal", entry.principal))
+	}
+
+	if entry.traceID != "" {
+		attrs = append(attrs, slog.String("trace_id", entry.traceID))
+	}
+
+	if entry.failure != "" {
+		attrs = append(attrs, slog.String("error", entry.failure))
+	}
+
+	l.logger.LogAttrs(ctx, level, "request", attrs...)
+}
+
+// recordFailure adds err to the access log entry of the request.
+func recordFailure(ctx context.Context, err error) {
+	if entry, ok := ctx.Value(accessEntryKey{}).(*accessEntry); ok {
+		entry.failure = err.Error()
+	}
+}
+
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+
+	for i := range len(id) {
+		if id[i] < '!' || id[i] > '~' {
+			return false
+		}
+	}
+
+	return true
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status      int
+	bytes       int64
+	wroteHeader bool
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	if !r.wroteHeader {
+		r.status = status
+		r.wroteHeader = true
+	}
+
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *statusRecorder) Write(p []byte) (int, error) {
+	r.wroteHeader = true
+
+	n, err := r.ResponseWriter.Write(p)
+	r.bytes += int64(n)
+
+	return n, err
+}
+
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
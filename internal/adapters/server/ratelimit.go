@@ -0,0 +1,243 @@
+package server
+
+import (
+	"container/list"
+	"context"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ogen-go/ogen/middleware"
+
+	api "github.com/flexer2006/t-t-ogen-go/generated"
+	"github.com/flexer2006/t-t-ogen-go/internal/domain"
+)
+
+const (
+	defaultRateLimitGroup = ""
+	// authFailureGroup counts the requests of an IP that fail authentication.
+	authFailureGroup = "authentication failures"
+)
+
+type RateLimit struct {
+	Rate  float64
+	Burst int
+}
+
+type RateLimitOptions struct {
+	Default    RateLimit
+	Operations map[string]RateLimit
+	MaxClients int
+}
+
+// RateLimiter applies token-bucket limits per client and operation group.
+// Clients are identified by API key, then authenticated principal, then
+// remote IP. Buckets are kept in an LRU list capped at MaxClients entries.
+//
+// Middleware runs after authentication, so Handler limits the requests that
+// fail it separately, by remote IP, with the default limit. Handler must wrap
+// the API for Middleware to send the RateLimit headers.
+type RateLimiter struct {
+	options RateLimitOptions
+	now     func() time.Time
+
+	mu      sync.Mutex
+	buckets map[bucketKey]*list.Element
+	order   *list.List
+}
+
+type bucketKey struct {
+	client string
+	group  string
+}
+
+type bucket struct {
+	key    bucketKey
+	tokens float64
+	last   time.Time
+}
+
+// rateLimitStatus is the state of a bucket after a request, as reported by
+// the RateLimit headers.
+type rateLimitStatus struct {
+	limit     RateLimit
+	remaining int
+	reset     time.Duration
+}
+
+type rateLimitError struct {
+	rateLimitStatus
+	retryAfter time.Duration
+}
+
+type rateLimitHeaderKey struct{}
+
+func (e *rateLimitError) Error() string {
+	return "rate limit exceeded"
+}
+
+func NewRateLimiter(options RateLimitOptions) *RateLimiter {
+	return &RateLimiter{
+		options: options,
+		now:     time.Now,
+		buckets: make(map[bucketKey]*list.Element),
+		order:   list.New(),
+	}
+}
+
+func (l *RateLimiter) Middleware() api.Middleware {
+	return func(req middleware.Request, next middleware.Next) (middleware.Response, error) {
+		group := defaultRateLimitGroup
+		limit := l.options.Default
+
+		if override, ok := l.options.Operations[req.OperationName]; ok {
+			group = req.OperationName
+			limit = override
+		}
+
+		status, err := l.take(bucketKey{client: clientKey(req), group: group}, limit)
+		if err != nil {
+			return middleware.Response{}, err
+		}
+
+		if header, ok := req.Context.Value(rateLimitHeaderKey{}).(http.Header); ok {
+			setRateLimitHeaders(header, status)
+		}
+
+		return next(req)
+	}
+}
+
+// Handler rejects requests from an IP whose authentication failures have
+// used up its bucket, and spends a token for each 401 answer.
+func (l *RateLimiter) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := bucketKey{client: "ip:" + remoteHost(r), group: authFailureGroup}
+
+		if err := l.check(key, l.options.Default); err != nil {
+			ErrorHandler(r.Context(), w, r, err)
+
+			return
+		}
+
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		ctx := context.WithValue(r.Context(), rateLimitHeaderKey{}, w.Header())
+
+		next.ServeHTTP(recorder, r.WithContext(ctx))
+
+		if recorder.status == http.StatusUnauthorized {
+			_, _ = l.take(key, l.options.Default)
+		}
+	})
+}
+
+// take spends a token of the bucket and reports its state afterwards. It
+// fails with a *rateLimitError when the bucket is empty.
+func (l *RateLimiter) take(key bucketKey, limit RateLimit) (rateLimitStatus, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b := l.refill(key, limit)
+
+	if b.tokens < 1 {
+		return rateLimitStatus{}, b.exceeded(limit)
+	}
+
+	b.tokens--
+
+	return b.status(limit), nil
+}
+
+// check fails like take when the bucket is empty, but spends nothing.
+func (l *RateLimiter) check(key bucketKey, limit RateLimit) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if b := l.refill(key, limit); b.tokens < 1 {
+		return b.exceeded(limit)
+	}
+
+	return nil
+}
+
+// refill returns the bucket of key with the tokens earned since its last
+// use. l.mu must be held.
+func (l *RateLimiter) refill(key bucketKey, limit RateLimit) *bucket {
+	now := l.now()
+
+	var b *bucket
+
+	if element, ok := l.buckets[key]; ok {
+		l.order.MoveToFront(element)
+		b = element.Value.(*bucket)
+
+		elapsed := now.Sub(b.last).Seconds()
+		b.tokens = math.Min(float64(limit.Burst), b.tokens+elapsed*limit.Rate)
+		b.last = now
+	} else {
+		b = &bucket{key: key, tokens: float64(limit.Burst), last: now}
+		l.buckets[key] = l.order.PushFront(b)
+		l.evict()
+	}
+
+	return b
+}
+
+func (b *bucket) status(limit RateLimit) rateLimitStatus {
+	return rateLimitStatus{
+		limit:     limit,
+		remaining: int(b.tokens),
+		reset:     secondsToDuration((float64(limit.Burst) - b.tokens) / limit.Rate),
+	}
+}
+
+func (b *bucket) exceeded(limit RateLimit) *rateLimitError {
+	return &rateLimitError{
+		rateLimitStatus: b.status(limit),
+		retryAfter:      secondsToDuration((1 - b.tokens) / limit.Rate),
+	}
+}
+
+func (l *RateLimiter) evict() {
+	for l.options.MaxClients > 0 && l.order.Len() > l.options.MaxClients {
+		oldest := l.order.Back()
+		l.order.Remove(oldest)
+		delete(l.buckets, oldest.Value.(*bucket).key)
+	}
+}
+
+func clientKey(req middleware.Request) string {
+	if principal, ok := domain.PrincipalFromContext(req.Context); ok {
+		return principal.Method + ":" + principal.Subject
+	}
+
+	return "ip:" + remoteHost(req.Raw)
+}
+
+func remoteHost(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return host
+}
+
+// setRateLimitHeaders reports status in the RateLimit headers of the
+// IETF httpapi-ratelimit-headers draft.
+func setRateLimitHeaders(header http.Header, status rateLimitStatus) {
+	header.Set("RateLimit-Limit", strconv.Itoa(status.limit.Burst))
+	header.Set("RateLimit-Remaining", strconv.Itoa(status.remaining))
+	header.Set("RateLimit-Reset", ceilSeconds(status.reset))
+}
+
+func secondsToDuration(seconds float64) time.Duration {
+	return time.Duration(seconds * float64(time.Second))
+}
+
+func ceilSeconds(d time.Duration) string {
+	return strconv.FormatInt(int64(math.Ceil(d.Seconds())), 10)
+}
@@ -11,46 +11,108 @@ import (
 	"github.com/flexer2006/t-t-ogen-go/internal/ports"
 )
 
-var ErrNilUserService = xerrors.New("nil user service")
+var (
+	ErrNilUserService   = xerrors.New("nil user service")
+	ErrNilTenantService = xerrors.New("nil tenant service")
+	ErrNilGroupService  = xerrors.New("nil group service")
+	ErrNilAttrService   = xerrors.New("nil attribute service")
+	ErrNilCredService   = xerrors.New("nil credential service")
+	ErrNilSessService   = xerrors.New("nil session service")
+)
 
 var errNilRequest = xerrors.New("nil request")
 
 type UserHandler struct {
-	service ports.UserService
+	service     ports.UserService
+	tenants     ports.TenantService
+	groups      ports.GroupService
+	attributes  ports.AttributeService
+	credentials ports.CredentialService
+	sessions    ports.SessionService
 }
 
 var _ api.Handler = (*UserHandler)(nil)
 
-func NewUserHandler(service ports.UserService) (*UserHandler, error) {
+func NewUserHandler(
+	service ports.UserService,
+	tenants ports.TenantService,
+	groups ports.GroupService,
+	attributes ports.AttributeService,
+	credentials ports.CredentialService,
+	sessions ports.SessionService,
+) (*UserHandler, error) {
 	if service == nil {
 		return nil, ErrNilUserService
 	}
 
-	return &UserHandler{service: service}, nil
+	if tenants == nil {
+		return nil, ErrNilTenantService
+	}
+
+	if groups == nil {
+		return nil, ErrNilGroupService
+	}
+
+	if attributes == nil {
+		return nil, ErrNilAttrService
+	}
+
+	if credentials == nil {
+		return nil, ErrNilCredService
+	}
+
+	if sessions == nil {
+		return nil, ErrNilSessService
+	}
+
+	return &UserHandler{
+		service:     service,
+		tenants:     tenants,
+		groups:      groups,
+		attributes:  attributes,
+		credentials: credentials,
+		sessions:    sessions,
+	}, nil
 }
 
-func (h *UserHandler) ListUsers(ctx context.Context) ([]api.User, error) {
-	users, err := h.service.ListUsers(ctx)
+// The tenant header of user operations is resolved by TenantResolver, which
+// also weighs it against the principal, so handlers read the tenant from the
+// context instead of their parameters.
+
+func (h *UserHandler) ListUsers(ctx context.Context, params api.ListUsersParams) ([]api.PartialUser, error) {
+	filter, err := attributeFilter(params.Attribute)
 	if err != nil {
 		return nil, xerrors.Wrap(err, "server.UserHandler.ListUsers")
 	}
 
-	result := make([]api.User, len(users))
+	users, err := h.service.ListUsers(ctx, filter)
+	if err != nil {
+		return nil, xerrors.Wrap(err, "server.UserHandler.ListUsers")
+	}
+
+	fields := selectUserFields(params.Fields)
+	result := make([]api.PartialUser, len(users))
 
 	for i, user := range users {
-		result[i] = toAPIUser(user)
+		result[i] = fields.toAPIUser(user)
 	}
 
 	return result, nil
 }
 
-func (h *UserHandler) CreateUser(ctx context.Context, req *api.NewUser) (*api.User, error) {
+func (h *UserHandler) CreateUser(ctx context.Context, req *api.NewUser, _ api.CreateUserParams) (api.CreateUserRes, error) {
 	if req == nil {
 		return nil, errNilRequest
 	}
 
-	user, err := h.service.CreateUser(ctx, req.GetName(), req.GetUsername())
+	email := string(req.GetEmail().Or(""))
+
+	user, err := h.service.CreateUser(ctx, req.GetDisplayName(), req.GetUsername(), email, fromAPIAttributes(req.GetAttributes()))
 	if err != nil {
+		if conflict(err) {
+			return &api.CreateUserConflict{}, nil
+		}
+
 		return nil, xerrors.Wrap(err, "server.UserHandler.CreateUser")
 	}
 
@@ -69,7 +131,7 @@ func (h *UserHandler) GetUser(ctx context.Context, params api.GetUserParams) (ap
 		return nil, xerrors.Wrap(err, "server.UserHandler.GetUser")
 	}
 
-	apiUser := toAPIUser(user)
+	apiUser := selectUserFields(params.Fields).toAPIUser(user)
 
 	return &apiUser, nil
 }
@@ -79,15 +141,25 @@ func (h *UserHandler) UpdateUser(ctx context.Context, req *api.UpdateUser, param
 		return nil, errNilRequest
 	}
 
-	name := optStringToPtr(req.GetName())
+	name := optStringToPtr(req.GetDisplayName())
 	username := optStringToPtr(req.GetUsername())
 
-	updated, err := h.service.UpdateUser(ctx, params.ID, name, username)
+	var email *string
+
+	if value, ok := req.GetEmail().Get(); ok {
+		email = (*string)(&value)
+	}
+
+	updated, err := h.service.UpdateUser(ctx, params.ID, name, username, email, fromAPIAttributes(req.GetAttributes()))
 	if err != nil {
 		if errors.Is(err, ports.ErrUserNotFound) {
 			return &api.UpdateUserNotFound{}, nil
 		}
 
+		if conflict(err) {
+			return &api.UpdateUserConflict{}, nil
+		}
+
 		return nil, xerrors.Wrap(err, "server.UserHandler.UpdateUser")
 	}
 
@@ -108,14 +180,72 @@ func (h *UserHandler) DeleteUser(ctx context.Context, params api.DeleteUserParam
 	return &api.DeleteUserNoContent{}, nil
 }
 
+func (h *UserHandler) RequestEmailVerification(ctx context.Context, params api.RequestEmailVerificationParams) (api.RequestEmailVerificationRes, error) {
+	if err := h.service.RequestEmailVerification(ctx, params.ID); err != nil {
+		if errors.Is(err, ports.ErrUserNotFound) {
+			return &api.RequestEmailVerificationNotFound{}, nil
+		}
+
+		if errors.Is(err, ports.ErrNoEmailToVerify) {
+			return &api.RequestEmailVerificationConflict{}, nil
+		}
+
+		return nil, xerrors.Wrap(err, "server.UserHandler.RequestEmailVerification")
+	}
+
+	return &api.RequestEmailVerificationAccepted{}, nil
+}
+
+func (h *UserHandler) VerifyUserEmail(ctx context.Context, req *api.EmailVerification, params api.VerifyUserEmailParams) (api.VerifyUserEmailRes, error) {
+	if req == nil {
+		return nil, errNilRequest
+	}
+
+	user, err := h.service.VerifyEmail(ctx, params.ID, req.GetToken())
+	if err != nil {
+		if errors.Is(err, ports.ErrUserNotFound) {
+			return &api.VerifyUserEmailNotFound{}, nil
+		}
+
+		if errors.Is(err, ports.ErrInvalidVerificationToken) {
+			return &api.VerifyUserEmailBadRequest{}, nil
+		}
+
+		return nil, xerrors.Wrap(err, "server.UserHandler.VerifyUserEmail")
+	}
+
+	apiUser := toAPIUser(user)
+
+	return &apiUser, nil
+}
+
+// conflict reports whether err is one of the uniqueness violations that
+// creating or updating a user answers with 409.
+func conflict(err error) bool {
+	return errors.Is(err, ports.ErrUsernameTaken) ||
+		errors.Is(err, ports.ErrEmailTaken) ||
+		errors.Is(err, ports.ErrAttributeNotUnique)
+}
+
 func toAPIUser(user domain.User) api.User {
 	return api.User{
-		ID:       user.ID,
-		Name:     user.Name,
-		Username: user.Username,
+		ID:            user.ID,
+		DisplayName:   user.Name,
+		Username:      user.Username,
+		Email:         optEmail(user.Email),
+		EmailVerified: user.EmailVerified,
+		Attributes:    toAPIAttributes(user.Attributes),
 	}
 }
 
+func optEmail(email string) api.OptEmail {
+	if email == "" {
+		return api.OptEmail{}
+	}
+
+	return api.NewOptEmail(api.Email(email))
+}
+
 func optStringToPtr(opt api.OptString) *string {
 	if value, ok := opt.Get(); ok {
 		return &value
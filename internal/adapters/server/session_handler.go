@@ -0,0 +1,64 @@
+package server
+
+import (
+	"context"
+	"errors"
+
+	xerrors "github.com/go-faster/errors"
+
+	api "github.com/flexer2006/t-t-ogen-go/generated"
+	"github.com/flexer2006/t-t-ogen-go/internal/domain"
+	"github.com/flexer2006/t-t-ogen-go/internal/ports"
+)
+
+func (h *UserHandler) ListUserSessions(ctx context.Context, params api.ListUserSessionsParams) (api.ListUserSessionsRes, error) {
+	sessions, err := h.sessions.ListSessions(ctx, params.ID)
+	if err != nil {
+		if errors.Is(err, ports.ErrUserNotFound) {
+			return &api.ListUserSessionsNotFound{}, nil
+		}
+
+		return nil, xerrors.Wrap(err, "server.UserHandler.ListUserSessions")
+	}
+
+	result := make(api.ListUserSessionsOKApplicationJSON, len(sessions))
+
+	for i, session := range sessions {
+		result[i] = toAPISession(session)
+	}
+
+	return &result, nil
+}
+
+func (h *UserHandler) RevokeUserSession(ctx context.Context, params api.RevokeUserSessionParams) (api.RevokeUserSessionRes, error) {
+	if err := h.sessions.RevokeSession(ctx, params.ID, params.SessionId); err != nil {
+		if errors.Is(err, ports.ErrUserNotFound) || errors.Is(err, ports.ErrSessionNotFound) {
+			return &api.RevokeUserSessionNotFound{}, nil
+		}
+
+		return nil, xerrors.Wrap(err, "server.UserHandler.RevokeUserSession")
+	}
+
+	return &api.RevokeUserSessionNoContent{}, nil
+}
+
+func (h *UserHandler) RevokeUserSessions(ctx context.Context, params api.RevokeUserSessionsParams) (api.RevokeUserSessionsRes, error) {
+	if err := h.sessions.RevokeSessions(ctx, params.ID); err != nil {
+		if errors.Is(err, ports.ErrUserNotFound) {
+			return &api.RevokeUserSessionsNotFound{}, nil
+		}
+
+		return nil, xerrors.Wrap(err, "server.UserHandler.RevokeUserSessions")
+	}
+
+	return &api.RevokeUserSessionsNoContent{}, nil
+}
+
+func toAPISession(session domain.Session) api.Session {
+	return api.Session{
+		ID:         session.ID,
+		CreatedAt:  session.CreatedAt,
+		LastUsedAt: session.LastUsedAt,
+		ExpiresAt:  session.Expiry(),
+	}
+}
@@ -0,0 +1,67 @@
+package server
+
+import (
+	"slices"
+
+	xerrors "github.com/go-faster/errors"
+	"github.com/ogen-go/ogen/middleware"
+
+	api "github.com/flexer2006/t-t-ogen-go/generated"
+	"github.com/flexer2006/t-t-ogen-go/internal/domain"
+	"github.com/flexer2006/t-t-ogen-go/internal/ports"
+)
+
+const TenantHeader = "X-Tenant-ID"
+
+// TenantResolver stores the tenant of every scoped operation in the request
+// context. With tenancy disabled that is always the default tenant. Otherwise
+// a principal bound to a tenant gets its own tenant and may not name another
+// one; unbound principals must name the tenant in TenantHeader.
+type TenantResolver struct {
+	enabled  bool
+	unscoped []api.OperationName
+}
+
+func NewTenantResolver(enabled bool, unscoped ...api.OperationName) *TenantResolver {
+	return &TenantResolver{enabled: enabled, unscoped: unscoped}
+}
+
+func (t *TenantResolver) Middleware() api.Middleware {
+	return func(req middleware.Request, next middleware.Next) (middleware.Response, error) {
+		if slices.Contains(t.unscoped, req.OperationName) {
+			return next(req)
+		}
+
+		tenantID, err := t.resolve(req)
+		if err != nil {
+			return middleware.Response{}, err
+		}
+
+		req.SetContext(domain.ContextWithTenant(req.Context, tenantID))
+
+		return next(req)
+	}
+}
+
+func (t *TenantResolver) resolve(req middleware.Request) (string, error) {
+	if !t.enabled {
+		return domain.DefaultTenant, nil
+	}
+
+	requested := req.Raw.Header.Get(TenantHeader)
+
+	principal, _ := domain.PrincipalFromContext(req.Context)
+	if principal.Tenant != "" {
+		if requested != "" && requested != principal.Tenant {
+			return "", xerrors.Wrapf(ports.ErrPermissionDenied, "principal is bound to another tenant than %q", requested)
+		}
+
+		return principal.Tenant, nil
+	}
+
+	if requested == "" {
+		return "", xerrors.Wrapf(ports.ErrTenantRequired, "set the %s header", TenantHeader)
+	}
+
+	return requested, nil
+}
@@ -0,0 +1,83 @@
+package server
+
+import (
+	"context"
+	"errors"
+
+	xerrors "github.com/go-faster/errors"
+
+	api "github.com/flexer2006/t-t-ogen-go/generated"
+	"github.com/flexer2006/t-t-ogen-go/internal/domain"
+	"github.com/flexer2006/t-t-ogen-go/internal/ports"
+)
+
+func (h *UserHandler) ListTenants(ctx context.Context) ([]api.Tenant, error) {
+	tenants, err := h.tenants.ListTenants(ctx)
+	if err != nil {
+		return nil, xerrors.Wrap(err, "server.UserHandler.ListTenants")
+	}
+
+	result := make([]api.Tenant, len(tenants))
+
+	for i, tenant := range tenants {
+		result[i] = toAPITenant(tenant)
+	}
+
+	return result, nil
+}
+
+func (h *UserHandler) CreateTenant(ctx context.Context, req *api.Tenant) (api.CreateTenantRes, error) {
+	if req == nil {
+		return nil, errNilRequest
+	}
+
+	tenant, err := h.tenants.CreateTenant(ctx, domain.Tenant{ID: string(req.GetID()), Name: req.GetName()})
+	if err != nil {
+		if errors.Is(err, ports.ErrTenantExists) {
+			return &api.CreateTenantConflict{}, nil
+		}
+
+		return nil, xerrors.Wrap(err, "server.UserHandler.CreateTenant")
+	}
+
+	apiTenant := toAPITenant(tenant)
+
+	return &apiTenant, nil
+}
+
+func (h *UserHandler) GetTenant(ctx context.Context, params api.GetTenantParams) (api.GetTenantRes, error) {
+	tenant, err := h.tenants.GetTenant(ctx, string(params.ID))
+	if err != nil {
+		if errors.Is(err, ports.ErrTenantNotFound) {
+			return &api.GetTenantNotFound{}, nil
+		}
+
+		return nil, xerrors.Wrap(err, "server.UserHandler.GetTenant")
+	}
+
+	apiTenant := toAPITenant(tenant)
+
+	return &apiTenant, nil
+}
+
+func (h *UserHandler) DeleteTenant(ctx context.Context, params api.DeleteTenantParams) (api.DeleteTenantRes, error) {
+	if err := h.tenants.DeleteTenant(ctx, string(params.ID)); err != nil {
+		switch {
+		case errors.Is(err, ports.ErrTenantNotFound):
+			return &api.DeleteTenantNotFound{}, nil
+		case errors.Is(err, ports.ErrTenantNotEmpty), errors.Is(err, ports.ErrTenantProtected):
+			return &api.DeleteTenantConflict{}, nil
+		}
+
+		return nil, xerrors.Wrap(err, "server.UserHandler.DeleteTenant")
+	}
+
+	return &api.DeleteTenantNoContent{}, nil
+}
+
+func toAPITenant(tenant domain.Tenant) api.Tenant {
+	return api.Tenant{
+		ID:   api.TenantID(tenant.ID),
+		Name: tenant.Name,
+	}
+}
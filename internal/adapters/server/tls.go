@@ -0,0 +1,168 @@
+package server
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	xerrors "github.com/go-faster/errors"
+)
+
+const tlsReloadInterval = time.Second
+
+var errNoCertificates = xerrors.New("no certificates found")
+
+type TLSOptions struct {
+	CertFile     string
+	KeyFile      string
+	ClientCAFile string
+	ClientAuth   tls.ClientAuthType
+}
+
+// TLSReloader serves the certificate, key and client CA bundle from disk and
+// picks up rotated files without a restart. Files are checked at most once
+// per second during handshakes; if a reload fails, the previous material is
+// kept and the error is logged.
+type TLSReloader struct {
+	options TLSOptions
+	logger  *slog.Logger
+	now     func() time.Time
+
+	mu       sync.Mutex
+	config   *tls.Config
+	modTimes []time.Time
+	checked  time.Time
+}
+
+func NewTLSReloader(options TLSOptions, logger *slog.Logger) (*TLSReloader, error) {
+	if logger == nil {
+		return nil, xerrors.Wrap(ErrNilLogger, "server.NewTLSReloader")
+	}
+
+	reloader := &TLSReloader{options: options, logger: logger, now: time.Now}
+
+	if _, err := reloader.reload(); err != nil {
+		return nil, xerrors.Wrap(err, "server.NewTLSReloader")
+	}
+
+	return reloader, nil
+}
+
+// Config returns the configuration for http.Server.TLSConfig.
+func (r *TLSReloader) Config() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			return r.current(), nil
+		},
+	}
+}
+
+func (r *TLSReloader) current() *tls.Config {
+	r.mu.Lock()
+	due := r.now().Sub(r.checked) >= tlsReloadInterval
+	config := r.config
+	r.mu.Unlock()
+
+	if !due {
+		return config
+	}
+
+	reloaded, err := r.reload()
+	if err != nil {
+		r.logger.Error("tls reload failed, keeping previous certificates", slog.Any("error", err))
+
+		return config
+	}
+
+	return reloaded
+}
+
+func (r *TLSReloader) reload() (*tls.Config, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.checked = r.now()
+
+	files := []string{r.options.CertFile, r.options.KeyFile}
+	if r.options.ClientCAFile != "" {
+		files = append(files, r.options.ClientCAFile)
+	}
+
+	modTimes := make([]time.Time, len(files))
+
+	for i, file := range files {
+		info, err := os.Stat(file)
+		if err != nil {
+			return nil, xerrors.Wrap(err, "stat")
+		}
+
+		modTimes[i] = info.ModTime()
+	}
+
+	if r.config != nil && equalTimes(modTimes, r.modTimes) {
+		return r.config, nil
+	}
+
+	certificate, err := tls.LoadX509KeyPair(r.options.CertFile, r.options.KeyFile)
+	if err != nil {
+		return nil, xerrors.Wrap(err, "load key pair")
+	}
+
+	config := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{certificate},
+		NextProtos:   []string{"h2", "http/1.1"},
+		ClientAuth:   r.options.ClientAuth,
+	}
+
+	if r.options.ClientCAFile != "" {
+		pool, err := LoadCertPool(r.options.ClientCAFile)
+		if err != nil {
+			return nil, xerrors.Wrap(err, "client ca")
+		}
+
+		config.ClientCAs = pool
+	}
+
+	if r.config != nil {
+		r.logger.Info("tls certificates reloaded")
+	}
+
+	r.config = config
+	r.modTimes = modTimes
+
+	return config, nil
+}
+
+// LoadCertPool reads a PEM bundle of CA certificates.
+func LoadCertPool(path string) (*x509.CertPool, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, xerrors.Wrap(err, "read")
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(content) {
+		return nil, xerrors.Wrapf(errNoCertificates, "%s", path)
+	}
+
+	return pool, nil
+}
+
+func equalTimes(a, b []time.Time) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if !a[i].Equal(b[i]) {
+			return false
+		}
+	}
+
+	return true
+}
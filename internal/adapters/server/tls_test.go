@@ -0,0 +1,170 @@
+package server
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"io"
+	"log/slog"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/flexer2006/t-t-ogen-go/internal/testutil"
+)
+
+// issueKeyPair writes a certificate for localhost with the given serial
+// number and its key as PEM.
+func issueKeyPair(t *testing.T, ca *testutil.CA, serial int64, usage x509.ExtKeyUsage, certFile, keyFile string) {
+	t.Helper()
+
+	cert, key := ca.Issue(t, &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		DNSNames:     []string{"localhost"},
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+	})
+
+	testutil.WriteKeyPair(t, cert, key, certFile, keyFile)
+}
+
+// touch moves the modification time of path forward so the reloader sees a
+// change even on filesystems with a coarse timestamp resolution.
+func touch(t *testing.T, path string, at time.Time) {
+	t.Helper()
+
+	if err := os.Chtimes(path, at, at); err != nil {
+		t.Fatalf("touch %s: %v", path, err)
+	}
+}
+
+// handshake connects to a server using config and returns the serial number
+// of the certificate it presented.
+func handshake(t *testing.T, config *tls.Config, client *tls.Config) int64 {
+	t.Helper()
+
+	serverConn, clientConn := net.Pipe()
+	defer clientConn.Close()
+
+	done := make(chan error, 1)
+
+	go func() {
+		defer serverConn.Close()
+
+		conn := tls.Server(serverConn, config)
+		if err := conn.Handshake(); err != nil {
+			done <- err
+
+			return
+		}
+
+		// Read until the client hangs up so a TLS 1.3 client certificate
+		// is verified before the connection closes.
+		_, _ = io.Copy(io.Discard, conn)
+		done <- nil
+	}()
+
+	conn := tls.Client(clientConn, client)
+	if err := conn.Handshake(); err != nil {
+		t.Fatalf("client handshake: %v", err)
+	}
+
+	serial := conn.ConnectionState().PeerCertificates[0].SerialNumber.Int64()
+
+	conn.Close()
+
+	if err := <-done; err != nil {
+		t.Fatalf("server handshake: %v", err)
+	}
+
+	return serial
+}
+
+func TestTLSReloaderServesRotatedCertificate(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+
+	ca := testutil.NewCA(t)
+	issueKeyPair(t, ca, 100, x509.ExtKeyUsageServerAuth, certFile, keyFile)
+
+	reloader, err := NewTLSReloader(TLSOptions{CertFile: certFile, KeyFile: keyFile}, slog.New(slog.DiscardHandler))
+	if err != nil {
+		t.Fatalf("NewTLSReloader: %v", err)
+	}
+
+	// The reloader made its first check with the real clock.
+	start := time.Now()
+	clock := testutil.NewClock(start)
+	reloader.now = clock.Now
+
+	client := &tls.Config{RootCAs: ca.Pool(), ServerName: "localhost"}
+
+	if serial := handshake(t, reloader.Config(), client); serial != 100 {
+		t.Fatalf("serial = %d before rotation, want 100", serial)
+	}
+
+	issueKeyPair(t, ca, 200, x509.ExtKeyUsageServerAuth, certFile, keyFile)
+	touch(t, certFile, start.Add(time.Minute))
+	touch(t, keyFile, start.Add(time.Minute))
+
+	// Files are checked at most once per interval.
+	clock.Advance(tlsReloadInterval / 2)
+
+	if serial := handshake(t, reloader.Config(), client); serial != 100 {
+		t.Errorf("serial = %d within the reload interval, want 100", serial)
+	}
+
+	clock.Advance(tlsReloadInterval)
+
+	if serial := handshake(t, reloader.Config(), client); serial != 200 {
+		t.Errorf("serial = %d after rotation, want 200", serial)
+	}
+
+	// A broken rotation keeps the certificate that was last loaded.
+	if err := os.WriteFile(keyFile, []byte("not a key"), 0o600); err != nil {
+		t.Fatalf("write key: %v", err)
+	}
+
+	touch(t, keyFile, start.Add(2*time.Minute))
+	clock.Advance(tlsReloadInterval)
+
+	if serial := handshake(t, reloader.Config(), client); serial != 200 {
+		t.Errorf("serial = %d after a failed reload, want 200", serial)
+	}
+}
+
+func TestTLSReloaderVerifiesClientCertificates(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+	caFile := filepath.Join(dir, "ca.crt")
+	clientCertFile, clientKeyFile := filepath.Join(dir, "client.crt"), filepath.Join(dir, "client.key")
+
+	ca := testutil.NewCA(t)
+	issueKeyPair(t, ca, 100, x509.ExtKeyUsageServerAuth, certFile, keyFile)
+	issueKeyPair(t, ca, 300, x509.ExtKeyUsageClientAuth, clientCertFile, clientKeyFile)
+	testutil.WriteCertificate(t, ca.Certificate, caFile)
+
+	reloader, err := NewTLSReloader(TLSOptions{
+		CertFile:     certFile,
+		KeyFile:      keyFile,
+		ClientCAFile: caFile,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+	}, slog.New(slog.DiscardHandler))
+	if err != nil {
+		t.Fatalf("NewTLSReloader: %v", err)
+	}
+
+	clientCert, err := tls.LoadX509KeyPair(clientCertFile, clientKeyFile)
+	if err != nil {
+		t.Fatalf("load client key pair: %v", err)
+	}
+
+	handshake(t, reloader.Config(), &tls.Config{
+		RootCAs:      ca.Pool(),
+		ServerName:   "localhost",
+		Certificates: []tls.Certificate{clientCert},
+	})
+}
@@ -0,0 +1,207 @@
+package server
+
+import (
+	"context"
+	"errors"
+
+	xerrors "github.com/go-faster/errors"
+
+	apiv1 "github.com/flexer2006/t-t-ogen-go/generated/v1"
+	"github.com/flexer2006/t-t-ogen-go/internal/domain"
+	"github.com/flexer2006/t-t-ogen-go/internal/ports"
+)
+
+// UserHandlerV1 serves the frozen version 1 contract from the same services
+// as UserHandler.
+type UserHandlerV1 struct {
+	service ports.UserService
+	tenants ports.TenantService
+}
+
+var _ apiv1.Handler = (*UserHandlerV1)(nil)
+
+func NewUserHandlerV1(service ports.UserService, tenants ports.TenantService) (*UserHandlerV1, error) {
+	if service == nil {
+		return nil, ErrNilUserService
+	}
+
+	if tenants == nil {
+		return nil, ErrNilTenantService
+	}
+
+	return &UserHandlerV1{service: service, tenants: tenants}, nil
+}
+
+func (h *UserHandlerV1) ListUsers(ctx context.Context, _ apiv1.ListUsersParams) ([]apiv1.User, error) {
+	users, err := h.service.ListUsers(ctx, nil)
+	if err != nil {
+		return nil, xerrors.Wrap(err, "server.UserHandlerV1.ListUsers")
+	}
+
+	result := make([]apiv1.User, len(users))
+
+	for i, user := range users {
+		result[i] = toV1User(user)
+	}
+
+	return result, nil
+}
+
+func (h *UserHandlerV1) CreateUser(ctx context.Context, req *apiv1.NewUser, _ apiv1.CreateUserParams) (apiv1.CreateUserRes, error) {
+	if req == nil {
+		return nil, errNilRequest
+	}
+
+	user, err := h.service.CreateUser(ctx, req.GetName(), req.GetUsername(), "", nil)
+	if err != nil {
+		if errors.Is(err, ports.ErrUsernameTaken) {
+			return &apiv1.CreateUserConflict{}, nil
+		}
+
+		return nil, xerrors.Wrap(err, "server.UserHandlerV1.CreateUser")
+	}
+
+	apiUser := toV1User(user)
+
+	return &apiUser, nil
+}
+
+func (h *UserHandlerV1) GetUser(ctx context.Context, params apiv1.GetUserParams) (apiv1.GetUserRes, error) {
+	user, err := h.service.GetUser(ctx, params.ID)
+	if err != nil {
+		if errors.Is(err, ports.ErrUserNotFound) {
+			return &apiv1.GetUserNotFound{}, nil
+		}
+
+		return nil, xerrors.Wrap(err, "server.UserHandlerV1.GetUser")
+	}
+
+	apiUser := toV1User(user)
+
+	return &apiUser, nil
+}
+
+func (h *UserHandlerV1) UpdateUser(ctx context.Context, req *apiv1.UpdateUser, params apiv1.UpdateUserParams) (apiv1.UpdateUserRes, error) {
+	if req == nil {
+		return nil, errNilRequest
+	}
+
+	name := optStringV1ToPtr(req.GetName())
+	username := optStringV1ToPtr(req.GetUsername())
+
+	updated, err := h.service.UpdateUser(ctx, params.ID, name, username, nil, nil)
+	if err != nil {
+		if errors.Is(err, ports.ErrUserNotFound) {
+			return &apiv1.UpdateUserNotFound{}, nil
+		}
+
+		if errors.Is(err, ports.ErrUsernameTaken) {
+			return &apiv1.UpdateUserConflict{}, nil
+		}
+
+		return nil, xerrors.Wrap(err, "server.UserHandlerV1.UpdateUser")
+	}
+
+	apiUser := toV1User(updated)
+
+	return &apiUser, nil
+}
+
+func (h *UserHandlerV1) DeleteUser(ctx context.Context, params apiv1.DeleteUserParams) (apiv1.DeleteUserRes, error) {
+	if err := h.service.DeleteUser(ctx, params.ID); err != nil {
+		if errors.Is(err, ports.ErrUserNotFound) {
+			return &apiv1.DeleteUserNotFound{}, nil
+		}
+
+		return nil, xerrors.Wrap(err, "server.UserHandlerV1.DeleteUser")
+	}
+
+	return &apiv1.DeleteUserNoContent{}, nil
+}
+
+func (h *UserHandlerV1) ListTenants(ctx context.Context) ([]apiv1.Tenant, error) {
+	tenants, err := h.tenants.ListTenants(ctx)
+	if err != nil {
+		return nil, xerrors.Wrap(err, "server.UserHandlerV1.ListTenants")
+	}
+
+	result := make([]apiv1.Tenant, len(tenants))
+
+	for i, tenant := range tenants {
+		result[i] = toV1Tenant(tenant)
+	}
+
+	return result, nil
+}
+
+func (h *UserHandlerV1) CreateTenant(ctx context.Context, req *apiv1.Tenant) (apiv1.CreateTenantRes, error) {
+	if req == nil {
+		return nil, errNilRequest
+	}
+
+	tenant, err := h.tenants.CreateTenant(ctx, domain.Tenant{ID: string(req.GetID()), Name: req.GetName()})
+	if err != nil {
+		if errors.Is(err, ports.ErrTenantExists) {
+			return &apiv1.CreateTenantConflict{}, nil
+		}
+
+		return nil, xerrors.Wrap(err, "server.UserHandlerV1.CreateTenant")
+	}
+
+	apiTenant := toV1Tenant(tenant)
+
+	return &apiTenant, nil
+}
+
+func (h *UserHandlerV1) GetTenant(ctx context.Context, params apiv1.GetTenantParams) (apiv1.GetTenantRes, error) {
+	tenant, err := h.tenants.GetTenant(ctx, string(params.ID))
+	if err != nil {
+		if errors.Is(err, ports.ErrTenantNotFound) {
+			return &apiv1.GetTenantNotFound{}, nil
+		}
+
+		return nil, xerrors.Wrap(err, "server.UserHandlerV1.GetTenant")
+	}
+
+	apiTenant := toV1Tenant(tenant)
+
+	return &apiTenant, nil
+}
+
+func (h *UserHandlerV1) DeleteTenant(ctx context.Context, params apiv1.DeleteTenantParams) (apiv1.DeleteTenantRes, error) {
+	if err := h.tenants.DeleteTenant(ctx, string(params.ID)); err != nil {
+		switch {
+		case errors.Is(err, ports.ErrTenantNotFound):
+			return &apiv1.DeleteTenantNotFound{}, nil
+		case errors.Is(err, ports.ErrTenantNotEmpty), errors.Is(err, ports.ErrTenantProtected):
+			return &apiv1.DeleteTenantConflict{}, nil
+		}
+
+		return nil, xerrors.Wrap(err, "server.UserHandlerV1.DeleteTenant")
+	}
+
+	return &apiv1.DeleteTenantNoContent{}, nil
+}
+
+func toV1User(user domain.User) apiv1.User {
+	return apiv1.User{
+		ID:       user.ID,
+		Name:     user.Name,
+		Username: user.Username,
+	}
+}
+
+func toV1Tenant(tenant domain.Tenant) apiv1.Tenant {
+	return apiv1.Tenant{
+		ID:   apiv1.TenantID(tenant.ID),
+		Name: tenant.Name,
+	}
+}
+
+func optStringV1ToPtr(opt apiv1.OptString) *string {
+	if value, ok := opt.Get(); ok {
+		return &value
+	}
+
+	return nil
+}
@@ -0,0 +1,92 @@
+package server
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RouteFinder names the operation a generated router serves for method and
+// path.
+type RouteFinder func(method, path string) (operation string, ok bool)
+
+// Deprecation announces that an API version is going away, using the
+// Deprecation (RFC 9745), Sunset (RFC 8594) and successor-version link
+// headers.
+type Deprecation struct {
+	Since time.Time
+	// Sunset is when the version stops being served; zero if not yet
+	// scheduled.
+	Sunset time.Time
+	// Successor is the path prefix of the replacing version.
+	Successor string
+}
+
+type APIVersion struct {
+	// Prefix is the path prefix the generated server was built with, such
+	// as "/v2"; an empty prefix mounts the version at the root.
+	Prefix      string
+	Handler     http.Handler
+	Routes      RouteFinder
+	Deprecation *Deprecation
+}
+
+// Versions serves several generated servers side by side, dispatching on
+// the longest matching prefix.
+type Versions struct {
+	versions []APIVersion
+}
+
+func NewVersions(versions ...APIVersion) *Versions {
+	sorted := slices.Clone(versions)
+	slices.SortStableFunc(sorted, func(a, b APIVersion) int {
+		return len(b.Prefix) - len(a.Prefix)
+	})
+
+	return &Versions{versions: sorted}
+}
+
+func (v *Versions) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	version, ok := v.match(r.URL.Path)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	if deprecation := version.Deprecation; deprecation != nil {
+		header := w.Header()
+		header.Set("Deprecation", "@"+strconv.FormatInt(deprecation.Since.Unix(), 10))
+
+		if !deprecation.Sunset.IsZero() {
+			header.Set("Sunset", deprecation.Sunset.UTC().Format(http.TimeFormat))
+		}
+
+		if deprecation.Successor != "" {
+			header.Add("Link", "<"+deprecation.Successor+`>; rel="successor-version"`)
+		}
+	}
+
+	version.Handler.ServeHTTP(w, r)
+}
+
+// FindRoute is a RouteFinder over every version.
+func (v *Versions) FindRoute(method, path string) (string, bool) {
+	version, ok := v.match(path)
+	if !ok || version.Routes == nil {
+		return "", false
+	}
+
+	return version.Routes(method, path)
+}
+
+func (v *Versions) match(path string) (APIVersion, bool) {
+	for _, version := range v.versions {
+		if version.Prefix == "" || path == version.Prefix || strings.HasPrefix(path, version.Prefix+"/") {
+			return version, true
+		}
+	}
+
+	return APIVersion{}, false
+}
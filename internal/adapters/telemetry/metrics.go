@@ -0,0 +1,106 @@
+package telemetry
+
+import (
+	"context"
+	"net/http"
+
+	xerrors "github.com/go-faster/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/otel/attribute"
+	otelprometheus "go.opentelemetry.io/otel/exporters/prometheus"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+
+	"github.com/flexer2006/t-t-ogen-go/internal/ports"
+)
+
+const (
+	meterName   = "github.com/flexer2006/t-t-ogen-go"
+	serviceName = "user-service"
+)
+
+var ErrNilUserCounter = xerrors.New("nil user counter")
+
+// Metrics bridges OpenTelemetry instruments to a Prometheus registry that
+// also carries Go runtime and process collectors, so everything is served
+// from a single scrape endpoint.
+type Metrics struct {
+	registry *prometheus.Registry
+	provider *sdkmetric.MeterProvider
+}
+
+func NewMetrics() (*Metrics, error) {
+	registry := prometheus.NewRegistry()
+
+	if err := registry.Register(collectors.NewGoCollector()); err != nil {
+		return nil, xerrors.Wrap(err, "telemetry.NewMetrics: go collector")
+	}
+
+	if err := registry.Register(collectors.NewProcessCollector(collectors.ProcessCollectorOpts{})); err != nil {
+		return nil, xerrors.Wrap(err, "telemetry.NewMetrics: process collector")
+	}
+
+	exporter, err := otelprometheus.New(otelprometheus.WithRegisterer(registry))
+	if err != nil {
+		return nil, xerrors.Wrap(err, "telemetry.NewMetrics: exporter")
+	}
+
+	return &Metrics{
+		registry: registry,
+		provider: sdkmetric.NewMeterProvider(
+			sdkmetric.WithReader(exporter),
+			sdkmetric.WithResource(serviceResource()),
+		),
+	}, nil
+}
+
+func (m *Metrics) MeterProvider() metric.MeterProvider {
+	return m.provider
+}
+
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+func (m *Metrics) Shutdown(ctx context.Context) error {
+	if err := m.provider.Shutdown(ctx); err != nil {
+		return xerrors.Wrap(err, "telemetry.Metrics.Shutdown")
+	}
+
+	return nil
+}
+
+func RegisterUserGauge(provider metric.MeterProvider, users ports.UserCounter) error {
+	if users == nil {
+		return ErrNilUserCounter
+	}
+
+	meter := provider.Meter(meterName)
+
+	_, err := meter.Int64ObservableGauge("user_service.users",
+		metric.WithDescription("Number of users stored in the repository."),
+		metric.WithUnit("{user}"),
+		metric.WithInt64Callback(func(ctx context.Context, observer metric.Int64Observer) error {
+			count, err := users.CountUsers(ctx)
+			if err != nil {
+				return xerrors.Wrap(err, "count users")
+			}
+
+			observer.Observe(int64(count))
+
+			return nil
+		}),
+	)
+	if err != nil {
+		return xerrors.Wrap(err, "telemetry.RegisterUserGauge")
+	}
+
+	return nil
+}
+
+func serviceResource() *resource.Resource {
+	return resource.NewSchemaless(attribute.String("service.name", serviceName))
+}
@@ -0,0 +1,35 @@
+package telemetry
+
+import (
+	"net/http"
+
+	ht "github.com/ogen-go/ogen/http"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+var propagator = propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{})
+
+// ExtractTraceContext continues traces started by callers that send W3C
+// traceparent and baggage headers; the generated server does not do this.
+func ExtractTraceContext(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := propagator.Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+type propagatingClient struct {
+	next ht.Client
+}
+
+// NewPropagatingClient injects the span of each outgoing request into its
+// headers, complementing ExtractTraceContext on the server side.
+func NewPropagatingClient(next ht.Client) ht.Client {
+	return &propagatingClient{next: next}
+}
+
+func (c *propagatingClient) Do(req *http.Request) (*http.Response, error) {
+	propagator.Inject(req.Context(), propagation.HeaderCarrier(req.Header))
+
+	return c.next.Do(req)
+}
@@ -0,0 +1,109 @@
+package telemetry
+
+import (
+	"context"
+
+	xerrors "github.com/go-faster/errors"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/flexer2006/t-t-ogen-go/internal/domain"
+	"github.com/flexer2006/t-t-ogen-go/internal/ports"
+)
+
+var ErrNilRepository = xerrors.New("nil repository")
+
+type TracedUserRepository struct {
+	next   ports.UserRepository
+	tracer trace.Tracer
+}
+
+var _ ports.UserRepository = (*TracedUserRepository)(nil)
+
+func NewTracedUserRepository(next ports.UserRepository, tracer trace.Tracer) (*TracedUserRepository, error) {
+	if next == nil {
+		return nil, ErrNilRepository
+	}
+
+	return &TracedUserRepository{next: next, tracer: tracer}, nil
+}
+
+func (r *TracedUserRepository) CountUsers(ctx context.Context) (int, error) {
+	ctx, span := r.tracer.Start(ctx, "repository.CountUsers")
+	defer span.End()
+
+	count, err := r.next.CountUsers(ctx)
+	RecordError(span, err)
+
+	return count, err
+}
+
+func (r *TracedUserRepository) ListUsers(ctx context.Context, tenantID string, match domain.Attributes) ([]domain.User, error) {
+	ctx, span := r.tracer.Start(ctx, "repository.ListUsers", trace.WithAttributes(tenantAttribute(tenantID)))
+	defer span.End()
+
+	users, err := r.next.ListUsers(ctx, tenantID, match)
+	RecordError(span, err)
+	span.SetAttributes(attribute.Int("users.count", len(users)))
+
+	return users, err
+}
+
+func (r *TracedUserRepository) CreateUser(ctx context.Context, tenantID, name, username, email string, attributes domain.Attributes) (domain.User, error) {
+	ctx, span := r.tracer.Start(ctx, "repository.CreateUser", trace.WithAttributes(tenantAttribute(tenantID)))
+	defer span.End()
+
+	user, err := r.next.CreateUser(ctx, tenantID, name, username, email, attributes)
+	RecordError(span, err)
+
+	return user, err
+}
+
+func (r *TracedUserRepository) GetUser(ctx context.Context, tenantID string, id uuid.UUID) (domain.User, error) {
+	ctx, span := r.tracer.Start(ctx, "repository.GetUser", trace.WithAttributes(tenantAttribute(tenantID), userIDAttribute(id)))
+	defer span.End()
+
+	user, err := r.next.GetUser(ctx, tenantID, id)
+	RecordError(span, err)
+
+	return user, err
+}
+
+func (r *TracedUserRepository) UpdateUser(ctx context.Context, tenantID string, id uuid.UUID, name *string, username *string, email *string, attributes domain.Attributes) (domain.User, error) {
+	ctx, span := r.tracer.Start(ctx, "repository.UpdateUser", trace.WithAttributes(tenantAttribute(tenantID), userIDAttribute(id)))
+	defer span.End()
+
+	user, err := r.next.UpdateUser(ctx, tenantID, id, name, username, email, attributes)
+	RecordError(span, err)
+
+	return user, err
+}
+
+func (r *TracedUserRepository) DeleteUser(ctx context.Context, tenantID string, id uuid.UUID) error {
+	ctx, span := r.tracer.Start(ctx, "repository.DeleteUser", trace.WithAttributes(tenantAttribute(tenantID), userIDAttribute(id)))
+	defer span.End()
+
+	err := r.next.DeleteUser(ctx, tenantID, id)
+	RecordError(span, err)
+
+	return err
+}
+
+func RecordError(span trace.Span, err error) {
+	if err == nil {
+		return
+	}
+
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
+
+func userIDAttribute(id uuid.UUID) attribute.KeyValue {
+	return attribute.String("user.id", id.String())
+}
+
+func tenantAttribute(tenantID string) attribute.KeyValue {
+	return attribute.String("tenant.id", tenantID)
+}
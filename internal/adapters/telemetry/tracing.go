@@ -0,0 +1,50 @@
+package telemetry
+
+import (
+	"context"
+	"io"
+
+	xerrors "github.com/go-faster/errors"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = meterName
+
+// Tracing exports spans as JSON lines to a writer, which is meant for local
+// debugging rather than production collection.
+type Tracing struct {
+	provider *sdktrace.TracerProvider
+}
+
+func NewTracing(w io.Writer, sampleRatio float64) (*Tracing, error) {
+	exporter, err := stdouttrace.New(stdouttrace.WithWriter(w))
+	if err != nil {
+		return nil, xerrors.Wrap(err, "telemetry.NewTracing: exporter")
+	}
+
+	return &Tracing{
+		provider: sdktrace.NewTracerProvider(
+			sdktrace.WithBatcher(exporter),
+			sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(sampleRatio))),
+			sdktrace.WithResource(serviceResource()),
+		),
+	}, nil
+}
+
+func (t *Tracing) TracerProvider() trace.TracerProvider {
+	return t.provider
+}
+
+func (t *Tracing) Shutdown(ctx context.Context) error {
+	if err := t.provider.Shutdown(ctx); err != nil {
+		return xerrors.Wrap(err, "telemetry.Tracing.Shutdown")
+	}
+
+	return nil
+}
+
+func Tracer(provider trace.TracerProvider) trace.Tracer {
+	return provider.Tracer(tracerName)
+}
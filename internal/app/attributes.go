@@ -0,0 +1,331 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"sync"
+
+	xerrors "github.com/go-faster/errors"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/flexer2006/t-t-ogen-go/internal/adapters/telemetry"
+	"github.com/flexer2006/t-t-ogen-go/internal/domain"
+	"github.com/flexer2006/t-t-ogen-go/internal/ports"
+)
+
+// AttributeService manages the attribute schema of the request's tenant.
+type AttributeService struct {
+	repo     ports.AttributeSchemaRepository
+	patterns *attributePatterns
+	tracer   trace.Tracer
+}
+
+var _ ports.AttributeService = (*AttributeService)(nil)
+
+func newAttributeService(repo ports.AttributeSchemaRepository, patterns *attributePatterns, tracer trace.Tracer) (*AttributeService, error) {
+	if repo == nil || patterns == nil {
+		return nil, xerrors.Wrap(errNilRepository, "app.newAttributeService")
+	}
+
+	if tracer == nil {
+		return nil, xerrors.Wrap(errNilTracer, "app.newAttributeService")
+	}
+
+	return &AttributeService{repo: repo, patterns: patterns, tracer: tracer}, nil
+}
+
+func (s *AttributeService) GetAttributeSchema(ctx context.Context) ([]domain.AttributeDefinition, error) {
+	ctx, span := s.tracer.Start(ctx, "app.AttributeService.GetAttributeSchema")
+	defer span.End()
+
+	tenantID, err := tenantFromContext(ctx, span)
+	if err != nil {
+		return nil, xerrors.Wrap(err, "app.AttributeService.GetAttributeSchema")
+	}
+
+	schema, err := s.repo.GetAttributeSchema(ctx, tenantID)
+	if err != nil {
+		telemetry.RecordError(span, err)
+
+		return nil, xerrors.Wrap(err, "app.AttributeService.GetAttributeSchema")
+	}
+
+	return schema, nil
+}
+
+// PutAttributeSchema replaces the schema. Users keep their attributes; the
+// new rules apply the next time a user is created or updated.
+func (s *AttributeService) PutAttributeSchema(ctx context.Context, schema []domain.AttributeDefinition) ([]domain.AttributeDefinition, error) {
+	ctx, span := s.tracer.Start(ctx, "app.AttributeService.PutAttributeSchema", trace.WithAttributes(attribute.Int("attributes.count", len(schema))))
+	defer span.End()
+
+	tenantID, err := tenantFromContext(ctx, span)
+	if err != nil {
+		return nil, xerrors.Wrap(err, "app.AttributeService.PutAttributeSchema")
+	}
+
+	schema, err = validateSchema(schema, s.patterns)
+	if err != nil {
+		telemetry.RecordError(span, err)
+
+		return nil, xerrors.Wrap(err, "app.AttributeService.PutAttributeSchema")
+	}
+
+	if err := s.repo.PutAttributeSchema(ctx, tenantID, schema); err != nil {
+		telemetry.RecordError(span, err)
+
+		return nil, xerrors.Wrap(err, "app.AttributeService.PutAttributeSchema")
+	}
+
+	return schema, nil
+}
+
+// attributePatterns holds the compiled attribute patterns by their source, so
+// a pattern compiles once when its schema is stored instead of on every write
+// of a user.
+type attributePatterns struct {
+	mu       sync.Mutex
+	compiled map[string]*regexp.Regexp
+}
+
+func newAttributePatterns() *attributePatterns {
+	return &attributePatterns{compiled: make(map[string]*regexp.Regexp)}
+}
+
+// compile returns the compiled pattern, compiling it on first use. Schemas
+// stored before the process started reach it first from validateAttributes.
+func (p *attributePatterns) compile(pattern string) (*regexp.Regexp, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if compiled, ok := p.compiled[pattern]; ok {
+		return compiled, nil
+	}
+
+	compiled, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	p.compiled[pattern] = compiled
+
+	return compiled, nil
+}
+
+// validateSchema reports every problem of the schema at once and returns it
+// with enum values converted to the attribute's type.
+func validateSchema(schema []domain.AttributeDefinition, patterns *attributePatterns) ([]domain.AttributeDefinition, error) {
+	var problems []string
+
+	result := make([]domain.AttributeDefinition, len(schema))
+	seen := make(map[string]struct{}, len(schema))
+
+	for i, definition := range schema {
+		if !domain.ValidAttributeName(definition.Name) {
+			problems = append(problems, fmt.Sprintf("attribute %q: invalid name", definition.Name))
+		}
+
+		if _, dup := seen[definition.Name]; dup {
+			problems = append(problems, fmt.Sprintf("attribute %q: defined twice", definition.Name))
+		}
+
+		seen[definition.Name] = struct{}{}
+
+		if !slices.Contains([]domain.AttributeType{
+			domain.AttributeString, domain.AttributeInteger, domain.AttributeNumber, domain.AttributeBoolean,
+		}, definition.Type) {
+			problems = append(problems, fmt.Sprintf("attribute %q: unknown type %q", definition.Name, definition.Type))
+			result[i] = definition
+
+			continue
+		}
+
+		if definition.Pattern != "" {
+			if definition.Type != domain.AttributeString {
+				problems = append(problems, fmt.Sprintf("attribute %q: pattern requires type string", definition.Name))
+			} else if _, err := patterns.compile(definition.Pattern); err != nil {
+				problems = append(problems, fmt.Sprintf("attribute %q: invalid pattern: %v", definition.Name, err))
+			}
+		}
+
+		enum := make([]any, 0, len(definition.Enum))
+
+		for _, value := range definition.Enum {
+			converted, ok := convertAttribute(definition.Type, value)
+			if !ok {
+				problems = append(problems, fmt.Sprintf("attribute %q: enum value %v is not of type %s", definition.Name, value, definition.Type))
+
+				continue
+			}
+
+			enum = append(enum, converted)
+		}
+
+		if len(enum) == 0 {
+			enum = nil
+		}
+
+		definition.Enum = enum
+		result[i] = definition
+	}
+
+	if len(problems) > 0 {
+		return nil, &ports.ValidationError{Err: ports.ErrInvalidAttributeSchema, Problems: problems}
+	}
+
+	return result, nil
+}
+
+// validateAttributes checks attributes against the schema and returns them
+// with values converted to the types of their definitions. Every attribute
+// must be defined; required ones must be present. A stored pattern that does
+// not compile fails the call rather than letting every value through.
+func validateAttributes(schema []domain.AttributeDefinition, patterns *attributePatterns, attributes domain.Attributes) (domain.Attributes, error) {
+	var problems []string
+
+	result := make(domain.Attributes, len(attributes))
+
+	for _, definition := range schema {
+		value, ok := attributes[definition.Name]
+		if !ok {
+			if definition.Required {
+				problems = append(problems, fmt.Sprintf("attribute %q: required", definition.Name))
+			}
+
+			continue
+		}
+
+		converted, ok := convertAttribute(definition.Type, value)
+		if !ok {
+			problems = append(problems, fmt.Sprintf("attribute %q: must be of type %s", definition.Name, definition.Type))
+
+			continue
+		}
+
+		if len(definition.Enum) > 0 && !slices.Contains(definition.Enum, converted) {
+			problems = append(problems, fmt.Sprintf("attribute %q: %v is not an allowed value", definition.Name, converted))
+
+			continue
+		}
+
+		if definition.Pattern != "" {
+			pattern, err := patterns.compile(definition.Pattern)
+			if err != nil {
+				return nil, xerrors.Wrapf(err, "stored pattern of attribute %q", definition.Name)
+			}
+
+			if !pattern.MatchString(converted.(string)) {
+				problems = append(problems, fmt.Sprintf("attribute %q: does not match %s", definition.Name, definition.Pattern))
+
+				continue
+			}
+		}
+
+		result[definition.Name] = converted
+	}
+
+	for name := range attributes {
+		if !slices.ContainsFunc(schema, func(definition domain.AttributeDefinition) bool { return definition.Name == name }) {
+			problems = append(problems, fmt.Sprintf("attribute %q: not defined", name))
+		}
+	}
+
+	if len(problems) > 0 {
+		slices.Sort(problems)
+
+		return nil, &ports.ValidationError{Err: ports.ErrInvalidAttributes, Problems: problems}
+	}
+
+	return result, nil
+}
+
+// parseAttributeFilter turns the text values of filter into the types of
+// their definitions, so the repository can compare them with stored values.
+func parseAttributeFilter(schema []domain.AttributeDefinition, filter domain.AttributeFilter) (domain.Attributes, error) {
+	if len(filter) == 0 {
+		return nil, nil
+	}
+
+	var problems []string
+
+	match := make(domain.Attributes, len(filter))
+
+	for name, text := range filter {
+		index := slices.IndexFunc(schema, func(definition domain.AttributeDefinition) bool { return definition.Name == name })
+		if index < 0 {
+			problems = append(problems, fmt.Sprintf("attribute %q: not defined", name))
+
+			continue
+		}
+
+		value, err := parseAttribute(schema[index].Type, text)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("attribute %q: %q is not of type %s", name, text, schema[index].Type))
+
+			continue
+		}
+
+		match[name] = value
+	}
+
+	if len(problems) > 0 {
+		slices.Sort(problems)
+
+		return nil, &ports.ValidationError{Err: ports.ErrInvalidAttributes, Problems: problems}
+	}
+
+	return match, nil
+}
+
+// convertAttribute accepts the value as decoded from JSON, where every number
+// is a float64, and returns it in the representation of the type.
+func convertAttribute(attributeType domain.AttributeType, value any) (any, bool) {
+	switch attributeType {
+	case domain.AttributeString:
+		text, ok := value.(string)
+
+		return text, ok
+	case domain.AttributeInteger:
+		switch number := value.(type) {
+		case int64:
+			return number, true
+		case float64:
+			if number != math.Trunc(number) || number < math.MinInt64 || number >= math.MaxInt64 {
+				return nil, false
+			}
+
+			return int64(number), true
+		}
+	case domain.AttributeNumber:
+		switch number := value.(type) {
+		case int64:
+			return float64(number), true
+		case float64:
+			return number, true
+		}
+	case domain.AttributeBoolean:
+		flag, ok := value.(bool)
+
+		return flag, ok
+	}
+
+	return nil, false
+}
+
+func parseAttribute(attributeType domain.AttributeType, text string) (any, error) {
+	switch attributeType {
+	case domain.AttributeInteger:
+		return strconv.ParseInt(text, 10, 64)
+	case domain.AttributeNumber:
+		return strconv.ParseFloat(text, 64)
+	case domain.AttributeBoolean:
+		return strconv.ParseBool(text)
+	default:
+		return text, nil
+	}
+}
@@ -0,0 +1,51 @@
+package app
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/flexer2006/t-t-ogen-go/internal/domain"
+	"github.com/flexer2006/t-t-ogen-go/internal/ports"
+)
+
+func TestAttributePatterns(t *testing.T) {
+	patterns := newAttributePatterns()
+
+	code := domain.AttributeDefinition{Name: "code", Type: domain.AttributeString, Pattern: `^[A-Z]{3}$`}
+
+	if _, err := validateSchema([]domain.AttributeDefinition{code}, patterns); err != nil {
+		t.Fatalf("validateSchema: %v", err)
+	}
+
+	stored := patterns.compiled[code.Pattern]
+	if stored == nil {
+		t.Fatal("the pattern was not compiled when the schema was validated")
+	}
+
+	if _, err := validateAttributes([]domain.AttributeDefinition{code}, patterns, domain.Attributes{"code": "ABC"}); err != nil {
+		t.Errorf("validateAttributes with a matching value: %v", err)
+	}
+
+	if compiled, _ := patterns.compile(code.Pattern); compiled != stored {
+		t.Error("the pattern was compiled again")
+	}
+
+	if _, err := validateAttributes([]domain.AttributeDefinition{code}, patterns, domain.Attributes{"code": "abc"}); !errors.Is(err, ports.ErrInvalidAttributes) {
+		t.Errorf("validateAttributes with a value that does not match: error = %v, want ErrInvalidAttributes", err)
+	}
+
+	broken := domain.AttributeDefinition{Name: "broken", Type: domain.AttributeString, Pattern: `(`}
+
+	if _, err := validateSchema([]domain.AttributeDefinition{broken}, patterns); !errors.Is(err, ports.ErrInvalidAttributeSchema) {
+		t.Errorf("validateSchema with an invalid pattern: error = %v, want ErrInvalidAttributeSchema", err)
+	}
+
+	// A stored schema with a pattern that does not compile must not let
+	// every value through.
+	_, err := validateAttributes([]domain.AttributeDefinition{broken}, patterns, domain.Attributes{"broken": "anything"})
+
+	var validation *ports.ValidationError
+	if err == nil || errors.As(err, &validation) {
+		t.Errorf("validateAttributes with an invalid stored pattern: error = %v, want an internal error", err)
+	}
+}
@@ -0,0 +1,486 @@
+package app
+
+import (
+	"context"
+	"slices"
+
+	xerrors "github.com/go-faster/errors"
+	"github.com/google/uuid"
+
+	api "github.com/flexer2006/t-t-ogen-go/generated"
+	"github.com/flexer2006/t-t-ogen-go/internal/config"
+	"github.com/flexer2006/t-t-ogen-go/internal/domain"
+	"github.com/flexer2006/t-t-ogen-go/internal/ports"
+)
+
+var (
+	errNilUserService   = xerrors.New("nil user service dependency")
+	errNilTenantService = xerrors.New("nil tenant service dependency")
+	errNilGroupService  = xerrors.New("nil group service dependency")
+	errNilAttrService   = xerrors.New("nil attribute service dependency")
+	errNilCredService   = xerrors.New("nil credential service dependency")
+	errNilSessService   = xerrors.New("nil session service dependency")
+	errUnknownOperation = xerrors.New("unknown operation")
+	errSelfNotSupported = xerrors.New("operation has no target user")
+)
+
+var (
+	userOperations = []api.OperationName{
+		api.ListUsersOperation,
+		api.CreateUserOperation,
+		api.GetUserOperation,
+		api.UpdateUserOperation,
+		api.DeleteUserOperation,
+		api.RequestEmailVerificationOperation,
+		api.VerifyUserEmailOperation,
+		api.SetUserPasswordOperation,
+		api.ChangeUserPasswordOperation,
+		api.ListUserSessionsOperation,
+		api.RevokeUserSessionOperation,
+		api.RevokeUserSessionsOperation,
+	}
+	tenantOperations = []api.OperationName{
+		api.ListTenantsOperation,
+		api.CreateTenantOperation,
+		api.GetTenantOperation,
+		api.DeleteTenantOperation,
+	}
+	groupOperations = []api.OperationName{
+		api.ListGroupsOperation,
+		api.CreateGroupOperation,
+		api.GetGroupOperation,
+		api.UpdateGroupOperation,
+		api.DeleteGroupOperation,
+		api.ListGroupMembersOperation,
+		api.AddGroupMemberOperation,
+		api.RemoveGroupMemberOperation,
+		api.ListUserGroupsOperation,
+	}
+	attributeOperations = []api.OperationName{
+		api.GetAttributeSchemaOperation,
+		api.PutAttributeSchemaOperation,
+	}
+	// sessionOperations need no principal; they are listed so rate limits
+	// can name them.
+	sessionOperations = []api.OperationName{
+		api.CreateSessionOperation,
+	}
+	selfOperations = []api.OperationName{
+		api.GetUserOperation,
+		api.UpdateUserOperation,
+		api.DeleteUserOperation,
+		api.RequestEmailVerificationOperation,
+		api.VerifyUserEmailOperation,
+		api.ChangeUserPasswordOperation,
+		api.ListUserSessionsOperation,
+		api.RevokeUserSessionOperation,
+		api.RevokeUserSessionsOperation,
+		api.ListUserGroupsOperation,
+	}
+)
+
+// AuthorizingService enforces an access policy on every call before
+// delegating to the wrapped service.
+type AuthorizingService struct {
+	next   ports.UserService
+	policy domain.AccessPolicy
+}
+
+var _ ports.UserService = (*AuthorizingService)(nil)
+
+func newAuthorizingService(next ports.UserService, policy domain.AccessPolicy) (*AuthorizingService, error) {
+	if next == nil {
+		return nil, xerrors.Wrap(errNilUserService, "app.newAuthorizingService")
+	}
+
+	return &AuthorizingService{next: next, policy: policy}, nil
+}
+
+func (s *AuthorizingService) ListUsers(ctx context.Context, filter domain.AttributeFilter) ([]domain.User, error) {
+	if err := authorize(ctx, s.policy, api.ListUsersOperation, uuid.Nil); err != nil {
+		return nil, xerrors.Wrap(err, "app.AuthorizingService.ListUsers")
+	}
+
+	return s.next.ListUsers(ctx, filter)
+}
+
+func (s *AuthorizingService) CreateUser(ctx context.Context, name, username, email string, attributes domain.Attributes) (domain.User, error) {
+	if err := authorize(ctx, s.policy, api.CreateUserOperation, uuid.Nil); err != nil {
+		return domain.User{}, xerrors.Wrap(err, "app.AuthorizingService.CreateUser")
+	}
+
+	return s.next.CreateUser(ctx, name, username, email, attributes)
+}
+
+func (s *AuthorizingService) GetUser(ctx context.Context, id uuid.UUID) (domain.User, error) {
+	if err := authorize(ctx, s.policy, api.GetUserOperation, id); err != nil {
+		return domain.User{}, xerrors.Wrap(err, "app.AuthorizingService.GetUser")
+	}
+
+	return s.next.GetUser(ctx, id)
+}
+
+func (s *AuthorizingService) UpdateUser(
+	ctx context.Context,
+	id uuid.UUID,
+	name *string,
+	username *string,
+	email *string,
+	attributes domain.Attributes,
+) (domain.User, error) {
+	if err := authorize(ctx, s.policy, api.UpdateUserOperation, id); err != nil {
+		return domain.User{}, xerrors.Wrap(err, "app.AuthorizingService.UpdateUser")
+	}
+
+	return s.next.UpdateUser(ctx, id, name, username, email, attributes)
+}
+
+func (s *AuthorizingService) DeleteUser(ctx context.Context, id uuid.UUID) error {
+	if err := authorize(ctx, s.policy, api.DeleteUserOperation, id); err != nil {
+		return xerrors.Wrap(err, "app.AuthorizingService.DeleteUser")
+	}
+
+	return s.next.DeleteUser(ctx, id)
+}
+
+func (s *AuthorizingService) RequestEmailVerification(ctx context.Context, id uuid.UUID) error {
+	if err := authorize(ctx, s.policy, api.RequestEmailVerificationOperation, id); err != nil {
+		return xerrors.Wrap(err, "app.AuthorizingService.RequestEmailVerification")
+	}
+
+	return s.next.RequestEmailVerification(ctx, id)
+}
+
+func (s *AuthorizingService) VerifyEmail(ctx context.Context, id uuid.UUID, token string) (domain.User, error) {
+	if err := authorize(ctx, s.policy, api.VerifyUserEmailOperation, id); err != nil {
+		return domain.User{}, xerrors.Wrap(err, "app.AuthorizingService.VerifyEmail")
+	}
+
+	return s.next.VerifyEmail(ctx, id, token)
+}
+
+// AuthorizingTenantService enforces the access policy on tenant
+// administration.
+type AuthorizingTenantService struct {
+	next   ports.TenantService
+	policy domain.AccessPolicy
+}
+
+var _ ports.TenantService = (*AuthorizingTenantService)(nil)
+
+func newAuthorizingTenantService(next ports.TenantService, policy domain.AccessPolicy) (*AuthorizingTenantService, error) {
+	if next == nil {
+		return nil, xerrors.Wrap(errNilTenantService, "app.newAuthorizingTenantService")
+	}
+
+	return &AuthorizingTenantService{next: next, policy: policy}, nil
+}
+
+func (s *AuthorizingTenantService) ListTenants(ctx context.Context) ([]domain.Tenant, error) {
+	if err := authorize(ctx, s.policy, api.ListTenantsOperation, uuid.Nil); err != nil {
+		return nil, xerrors.Wrap(err, "app.AuthorizingTenantService.ListTenants")
+	}
+
+	return s.next.ListTenants(ctx)
+}
+
+func (s *AuthorizingTenantService) CreateTenant(ctx context.Context, tenant domain.Tenant) (domain.Tenant, error) {
+	if err := authorize(ctx, s.policy, api.CreateTenantOperation, uuid.Nil); err != nil {
+		return domain.Tenant{}, xerrors.Wrap(err, "app.AuthorizingTenantService.CreateTenant")
+	}
+
+	return s.next.CreateTenant(ctx, tenant)
+}
+
+func (s *AuthorizingTenantService) GetTenant(ctx context.Context, id string) (domain.Tenant, error) {
+	if err := authorize(ctx, s.policy, api.GetTenantOperation, uuid.Nil); err != nil {
+		return domain.Tenant{}, xerrors.Wrap(err, "app.AuthorizingTenantService.GetTenant")
+	}
+
+	return s.next.GetTenant(ctx, id)
+}
+
+func (s *AuthorizingTenantService) DeleteTenant(ctx context.Context, id string) error {
+	if err := authorize(ctx, s.policy, api.DeleteTenantOperation, uuid.Nil); err != nil {
+		return xerrors.Wrap(err, "app.AuthorizingTenantService.DeleteTenant")
+	}
+
+	return s.next.DeleteTenant(ctx, id)
+}
+
+// AuthorizingGroupService enforces the access policy on groups. Listing the
+// groups of a user targets that user, so it can be granted as a self
+// operation.
+type AuthorizingGroupService struct {
+	next   ports.GroupService
+	policy domain.AccessPolicy
+}
+
+var _ ports.GroupService = (*AuthorizingGroupService)(nil)
+
+func newAuthorizingGroupService(next ports.GroupService, policy domain.AccessPolicy) (*AuthorizingGroupService, error) {
+	if next == nil {
+		return nil, xerrors.Wrap(errNilGroupService, "app.newAuthorizingGroupService")
+	}
+
+	return &AuthorizingGroupService{next: next, policy: policy}, nil
+}
+
+func (s *AuthorizingGroupService) ListGroups(ctx context.Context) ([]domain.Group, error) {
+	if err := authorize(ctx, s.policy, api.ListGroupsOperation, uuid.Nil); err != nil {
+		return nil, xerrors.Wrap(err, "app.AuthorizingGroupService.ListGroups")
+	}
+
+	return s.next.ListGroups(ctx)
+}
+
+func (s *AuthorizingGroupService) CreateGroup(ctx context.Context, name, description string) (domain.Group, error) {
+	if err := authorize(ctx, s.policy, api.CreateGroupOperation, uuid.Nil); err != nil {
+		return domain.Group{}, xerrors.Wrap(err, "app.AuthorizingGroupService.CreateGroup")
+	}
+
+	return s.next.CreateGroup(ctx, name, description)
+}
+
+func (s *AuthorizingGroupService) GetGroup(ctx context.Context, id uuid.UUID) (domain.Group, error) {
+	if err := authorize(ctx, s.policy, api.GetGroupOperation, uuid.Nil); err != nil {
+		return domain.Group{}, xerrors.Wrap(err, "app.AuthorizingGroupService.GetGroup")
+	}
+
+	return s.next.GetGroup(ctx, id)
+}
+
+func (s *AuthorizingGroupService) UpdateGroup(ctx context.Context, id uuid.UUID, name *string, description *string) (domain.Group, error) {
+	if err := authorize(ctx, s.policy, api.UpdateGroupOperation, uuid.Nil); err != nil {
+		return domain.Group{}, xerrors.Wrap(err, "app.AuthorizingGroupService.UpdateGroup")
+	}
+
+	return s.next.UpdateGroup(ctx, id, name, description)
+}
+
+func (s *AuthorizingGroupService) DeleteGroup(ctx context.Context, id uuid.UUID) error {
+	if err := authorize(ctx, s.policy, api.DeleteGroupOperation, uuid.Nil); err != nil {
+		return xerrors.Wrap(err, "app.AuthorizingGroupService.DeleteGroup")
+	}
+
+	return s.next.DeleteGroup(ctx, id)
+}
+
+func (s *AuthorizingGroupService) ListGroupMembers(ctx context.Context, groupID uuid.UUID) ([]domain.User, error) {
+	if err := authorize(ctx, s.policy, api.ListGroupMembersOperation, uuid.Nil); err != nil {
+		return nil, xerrors.Wrap(err, "app.AuthorizingGroupService.ListGroupMembers")
+	}
+
+	return s.next.ListGroupMembers(ctx, groupID)
+}
+
+func (s *AuthorizingGroupService) AddGroupMember(ctx context.Context, groupID, userID uuid.UUID) error {
+	if err := authorize(ctx, s.policy, api.AddGroupMemberOperation, uuid.Nil); err != nil {
+		return xerrors.Wrap(err, "app.AuthorizingGroupService.AddGroupMember")
+	}
+
+	return s.next.AddGroupMember(ctx, groupID, userID)
+}
+
+func (s *AuthorizingGroupService) RemoveGroupMember(ctx context.Context, groupID, userID uuid.UUID) error {
+	if err := authorize(ctx, s.policy, api.RemoveGroupMemberOperation, uuid.Nil); err != nil {
+		return xerrors.Wrap(err, "app.AuthorizingGroupService.RemoveGroupMember")
+	}
+
+	return s.next.RemoveGroupMember(ctx, groupID, userID)
+}
+
+func (s *AuthorizingGroupService) ListUserGroups(ctx context.Context, userID uuid.UUID) ([]domain.Group, error) {
+	if err := authorize(ctx, s.policy, api.ListUserGroupsOperation, userID); err != nil {
+		return nil, xerrors.Wrap(err, "app.AuthorizingGroupService.ListUserGroups")
+	}
+
+	return s.next.ListUserGroups(ctx, userID)
+}
+
+// AuthorizingAttributeService enforces the access policy on the attribute
+// schema.
+type AuthorizingAttributeService struct {
+	next   ports.AttributeService
+	policy domain.AccessPolicy
+}
+
+var _ ports.AttributeService = (*AuthorizingAttributeService)(nil)
+
+func newAuthorizingAttributeService(next ports.AttributeService, policy domain.AccessPolicy) (*AuthorizingAttributeService, error) {
+	if next == nil {
+		return nil, xerrors.Wrap(errNilAttrService, "app.newAuthorizingAttributeService")
+	}
+
+	return &AuthorizingAttributeService{next: next, policy: policy}, nil
+}
+
+func (s *AuthorizingAttributeService) GetAttributeSchema(ctx context.Context) ([]domain.AttributeDefinition, error) {
+	if err := authorize(ctx, s.policy, api.GetAttributeSchemaOperation, uuid.Nil); err != nil {
+		return nil, xerrors.Wrap(err, "app.AuthorizingAttributeService.GetAttributeSchema")
+	}
+
+	return s.next.GetAttributeSchema(ctx)
+}
+
+func (s *AuthorizingAttributeService) PutAttributeSchema(ctx context.Context, schema []domain.AttributeDefinition) ([]domain.AttributeDefinition, error) {
+	if err := authorize(ctx, s.policy, api.PutAttributeSchemaOperation, uuid.Nil); err != nil {
+		return nil, xerrors.Wrap(err, "app.AuthorizingAttributeService.PutAttributeSchema")
+	}
+
+	return s.next.PutAttributeSchema(ctx, schema)
+}
+
+// AuthorizingCredentialService enforces the access policy on password
+// management. Login is left to the credentials it checks.
+type AuthorizingCredentialService struct {
+	next   ports.CredentialService
+	policy domain.AccessPolicy
+}
+
+var _ ports.CredentialService = (*AuthorizingCredentialService)(nil)
+
+func newAuthorizingCredentialService(next ports.CredentialService, policy domain.AccessPolicy) (*AuthorizingCredentialService, error) {
+	if next == nil {
+		return nil, xerrors.Wrap(errNilCredService, "app.newAuthorizingCredentialService")
+	}
+
+	return &AuthorizingCredentialService{next: next, policy: policy}, nil
+}
+
+func (s *AuthorizingCredentialService) SetPassword(ctx context.Context, userID uuid.UUID, password string) error {
+	if err := authorize(ctx, s.policy, api.SetUserPasswordOperation, userID); err != nil {
+		return xerrors.Wrap(err, "app.AuthorizingCredentialService.SetPassword")
+	}
+
+	return s.next.SetPassword(ctx, userID, password)
+}
+
+func (s *AuthorizingCredentialService) ChangePassword(ctx context.Context, userID uuid.UUID, current, password string) error {
+	if err := authorize(ctx, s.policy, api.ChangeUserPasswordOperation, userID); err != nil {
+		return xerrors.Wrap(err, "app.AuthorizingCredentialService.ChangePassword")
+	}
+
+	return s.next.ChangePassword(ctx, userID, current, password)
+}
+
+func (s *AuthorizingCredentialService) Login(ctx context.Context, username, password string) (domain.User, error) {
+	return s.next.Login(ctx, username, password)
+}
+
+// AuthorizingSessionService enforces the access policy on the sessions of
+// users. Login is left to the credentials it checks.
+type AuthorizingSessionService struct {
+	next   ports.SessionService
+	policy domain.AccessPolicy
+}
+
+var _ ports.SessionService = (*AuthorizingSessionService)(nil)
+
+func newAuthorizingSessionService(next ports.SessionService, policy domain.AccessPolicy) (*AuthorizingSessionService, error) {
+	if next == nil {
+		return nil, xerrors.Wrap(errNilSessService, "app.newAuthorizingSessionService")
+	}
+
+	return &AuthorizingSessionService{next: next, policy: policy}, nil
+}
+
+func (s *AuthorizingSessionService) Login(ctx context.Context, username, password string) (domain.Login, error) {
+	return s.next.Login(ctx, username, password)
+}
+
+func (s *AuthorizingSessionService) ListSessions(ctx context.Context, userID uuid.UUID) ([]domain.Session, error) {
+	if err := authorize(ctx, s.policy, api.ListUserSessionsOperation, userID); err != nil {
+		return nil, xerrors.Wrap(err, "app.AuthorizingSessionService.ListSessions")
+	}
+
+	return s.next.ListSessions(ctx, userID)
+}
+
+func (s *AuthorizingSessionService) RevokeSession(ctx context.Context, userID, sessionID uuid.UUID) error {
+	if err := authorize(ctx, s.policy, api.RevokeUserSessionOperation, userID); err != nil {
+		return xerrors.Wrap(err, "app.AuthorizingSessionService.RevokeSession")
+	}
+
+	return s.next.RevokeSession(ctx, userID, sessionID)
+}
+
+func (s *AuthorizingSessionService) RevokeSessions(ctx context.Context, userID uuid.UUID) error {
+	if err := authorize(ctx, s.policy, api.RevokeUserSessionsOperation, userID); err != nil {
+		return xerrors.Wrap(err, "app.AuthorizingSessionService.RevokeSessions")
+	}
+
+	return s.next.RevokeSessions(ctx, userID)
+}
+
+func authorize(ctx context.Context, policy domain.AccessPolicy, operation api.OperationName, target uuid.UUID) error {
+	principal, ok := domain.PrincipalFromContext(ctx)
+	if !ok {
+		return ports.ErrUnauthenticated
+	}
+
+	self := target != uuid.Nil && principal.Subject == target.String()
+
+	for _, name := range principal.Roles {
+		role, ok := policy.Roles[name]
+		if !ok {
+			continue
+		}
+
+		if grants(role.Operations, operation) || (self && grants(role.SelfOperations, operation)) {
+			return nil
+		}
+	}
+
+	return xerrors.Wrapf(ports.ErrPermissionDenied, "%s", operation)
+}
+
+func grants(operations []string, operation api.OperationName) bool {
+	return slices.Contains(operations, domain.AnyOperation) || slices.Contains(operations, operation)
+}
+
+func knownOperation(operation api.OperationName) bool {
+	return slices.Contains(userOperations, operation) ||
+		slices.Contains(tenantOperations, operation) ||
+		slices.Contains(groupOperations, operation) ||
+		slices.Contains(attributeOperations, operation) ||
+		slices.Contains(sessionOperations, operation)
+}
+
+func newAccessPolicy(path string) (domain.AccessPolicy, error) {
+	cfg, err := config.ReadPolicyFile(path)
+	if err != nil {
+		return domain.AccessPolicy{}, xerrors.Wrap(err, "app.newAccessPolicy")
+	}
+
+	policy := domain.AccessPolicy{Roles: make(map[string]domain.RolePermissions, len(cfg.Roles))}
+
+	for name, role := range cfg.Roles {
+		for _, operation := range role.Allow {
+			if operation != domain.AnyOperation && !knownOperation(operation) {
+				return domain.AccessPolicy{}, xerrors.Wrapf(errUnknownOperation, "app.newAccessPolicy: role %q: %q", name, operation)
+			}
+		}
+
+		for _, operation := range role.AllowSelf {
+			if operation != domain.AnyOperation && !slices.Contains(selfOperations, operation) {
+				return domain.AccessPolicy{}, xerrors.Wrapf(errSelfNotSupported, "app.newAccessPolicy: role %q: %q", name, operation)
+			}
+		}
+
+		// The wildcard of allow_self stands for the operations a user may
+		// perform on itself, not for every operation: setUserPassword must
+		// not let users skip their current password.
+		self := role.AllowSelf
+		if slices.Contains(self, domain.AnyOperation) {
+			self = slices.Clone(selfOperations)
+		}
+
+		policy.Roles[name] = domain.RolePermissions{
+			Operations:     role.Allow,
+			SelfOperations: self,
+		}
+	}
+
+	return policy, nil
+}
@@ -0,0 +1,229 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	xerrors "github.com/go-faster/errors"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/flexer2006/t-t-ogen-go/internal/adapters/telemetry"
+	"github.com/flexer2006/t-t-ogen-go/internal/config"
+	"github.com/flexer2006/t-t-ogen-go/internal/domain"
+	"github.com/flexer2006/t-t-ogen-go/internal/ports"
+)
+
+// CredentialService checks and changes the passwords of the request's
+// tenant.
+type CredentialService struct {
+	repo      ports.CredentialRepository
+	hasher    *passwordHasher
+	minLength int
+	lockout   domain.LockoutPolicy
+	now       func() time.Time
+	tracer    trace.Tracer
+}
+
+var _ ports.CredentialService = (*CredentialService)(nil)
+
+func newCredentialService(repo ports.CredentialRepository, cfg config.PasswordConfig, tracer trace.Tracer) (*CredentialService, error) {
+	if repo == nil {
+		return nil, xerrors.Wrap(errNilRepository, "app.newCredentialService")
+	}
+
+	if tracer == nil {
+		return nil, xerrors.Wrap(errNilTracer, "app.newCredentialService")
+	}
+
+	return &CredentialService{
+		repo:      repo,
+		hasher:    newPasswordHasher(cfg.Iterations, cfg.MaxConcurrentHashes),
+		minLength: cfg.MinLength,
+		lockout:   domain.LockoutPolicy{MaxFailures: cfg.MaxFailures, Duration: time.Duration(cfg.Lockout)},
+		now:       time.Now,
+		tracer:    tracer,
+	}, nil
+}
+
+func (s *CredentialService) SetPassword(ctx context.Context, userID uuid.UUID, password string) error {
+	ctx, span := s.tracer.Start(ctx, "app.CredentialService.SetPassword", trace.WithAttributes(attribute.String("user.id", userID.String())))
+	defer span.End()
+
+	tenantID, err := tenantFromContext(ctx, span)
+	if err != nil {
+		return xerrors.Wrap(err, "app.CredentialService.SetPassword")
+	}
+
+	if err := s.store(ctx, tenantID, userID, password); err != nil {
+		telemetry.RecordError(span, err)
+
+		return xerrors.Wrap(err, "app.CredentialService.SetPassword")
+	}
+
+	return nil
+}
+
+// ChangePassword counts a wrong current password as a failed login, so it
+// cannot be used to guess past the lockout.
+func (s *CredentialService) ChangePassword(ctx context.Context, userID uuid.UUID, current, password string) error {
+	ctx, span := s.tracer.Start(ctx, "app.CredentialService.ChangePassword", trace.WithAttributes(attribute.String("user.id", userID.String())))
+	defer span.End()
+
+	tenantID, err := tenantFromContext(ctx, span)
+	if err != nil {
+		return xerrors.Wrap(err, "app.CredentialService.ChangePassword")
+	}
+
+	if err := s.validate(password); err != nil {
+		telemetry.RecordError(span, err)
+
+		return xerrors.Wrap(err, "app.CredentialService.ChangePassword")
+	}
+
+	if err := s.check(ctx, tenantID, userID, current); err != nil {
+		telemetry.RecordError(span, err)
+
+		return xerrors.Wrap(err, "app.CredentialService.ChangePassword")
+	}
+
+	if err := s.store(ctx, tenantID, userID, password); err != nil {
+		telemetry.RecordError(span, err)
+
+		return xerrors.Wrap(err, "app.CredentialService.ChangePassword")
+	}
+
+	return nil
+}
+
+// Login answers an unknown username, a user without a password, a wrong
+// password and a locked account alike with ports.ErrInvalidCredentials, after
+// the same amount of hashing, so that logging in reveals neither which
+// usernames exist nor which accounts are locked.
+func (s *CredentialService) Login(ctx context.Context, username, password string) (domain.User, error) {
+	ctx, span := s.tracer.Start(ctx, "app.CredentialService.Login")
+	defer span.End()
+
+	tenantID, err := tenantFromContext(ctx, span)
+	if err != nil {
+		return domain.User{}, xerrors.Wrap(err, "app.CredentialService.Login")
+	}
+
+	user, err := s.repo.GetUserByUsername(ctx, tenantID, username)
+	if errors.Is(err, ports.ErrUserNotFound) {
+		s.hasher.burn(ctx, password)
+
+		return domain.User{}, ports.ErrInvalidCredentials
+	}
+
+	if err != nil {
+		telemetry.RecordError(span, err)
+
+		return domain.User{}, xerrors.Wrap(err, "app.CredentialService.Login")
+	}
+
+	span.SetAttributes(attribute.String("user.id", user.ID.String()))
+
+	err = s.check(ctx, tenantID, user.ID, password)
+	if errors.Is(err, ports.ErrNoPassword) || errors.Is(err, ports.ErrAccountLocked) {
+		return domain.User{}, ports.ErrInvalidCredentials
+	}
+
+	if err != nil {
+		if !errors.Is(err, ports.ErrInvalidCredentials) {
+			telemetry.RecordError(span, err)
+		}
+
+		return domain.User{}, xerrors.Wrap(err, "app.CredentialService.Login")
+	}
+
+	return user, nil
+}
+
+// check verifies password against the user's credential, records the
+// outcome and upgrades a hash made with outdated parameters. The failure
+// that locks the account is reported as the lock. Every outcome costs one
+// hash, so the time taken does not tell them apart.
+//
+// Concurrent checks may all pass the lock test before any of them has
+// hashed, so the repository tests the lock again when it records a success:
+// a correct guess queued behind the failures that locked the account is
+// refused like any other attempt on a locked account.
+func (s *CredentialService) check(ctx context.Context, tenantID string, userID uuid.UUID, password string) error {
+	credential, err := s.repo.GetCredential(ctx, tenantID, userID)
+	if errors.Is(err, ports.ErrNoPassword) {
+		s.hasher.burn(ctx, password)
+
+		return err
+	}
+
+	if err != nil {
+		return err
+	}
+
+	now := s.now()
+
+	if credential.Locked(now) {
+		s.hasher.burn(ctx, password)
+
+		return &ports.LockedError{Until: credential.LockedUntil}
+	}
+
+	ok, stale, err := s.hasher.verify(ctx, password, credential.PasswordHash)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		now = s.now()
+
+		credential, err = s.repo.RecordLoginFailure(ctx, tenantID, userID, now, s.lockout)
+		if err != nil {
+			return err
+		}
+
+		if credential.Locked(now) {
+			return &ports.LockedError{Until: credential.LockedUntil}
+		}
+
+		return ports.ErrInvalidCredentials
+	}
+
+	var rehash string
+
+	if stale {
+		if rehash, err = s.hasher.hash(ctx, password); err != nil {
+			return err
+		}
+	}
+
+	return s.repo.RecordLoginSuccess(ctx, tenantID, userID, s.now(), credential.PasswordHash, rehash)
+}
+
+func (s *CredentialService) store(ctx context.Context, tenantID string, userID uuid.UUID, password string) error {
+	if err := s.validate(password); err != nil {
+		return err
+	}
+
+	hash, err := s.hasher.hash(ctx, password)
+	if err != nil {
+		return err
+	}
+
+	return s.repo.SetPassword(ctx, tenantID, userID, hash)
+}
+
+func (s *CredentialService) validate(password string) error {
+	if utf8.RuneCountInString(password) < s.minLength {
+		return &ports.ValidationError{
+			Err:      ports.ErrInvalidPassword,
+			Problems: []string{fmt.Sprintf("password: must have at least %d characters", s.minLength)},
+		}
+	}
+
+	return nil
+}
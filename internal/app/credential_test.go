@@ -0,0 +1,338 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	"github.com/flexer2006/t-t-ogen-go/internal/adapters/data"
+	"github.com/flexer2006/t-t-ogen-go/internal/config"
+	"github.com/flexer2006/t-t-ogen-go/internal/domain"
+	"github.com/flexer2006/t-t-ogen-go/internal/ports"
+	"github.com/flexer2006/t-t-ogen-go/internal/testutil"
+)
+
+const (
+	testIterations = 1000
+	testPassword   = "correct horse battery staple"
+)
+
+type pausedLogin struct{}
+
+// pausingRepository runs afterRead once a login whose context carries
+// pausedLogin has read the credential, before the login checks it.
+type pausingRepository struct {
+	ports.CredentialRepository
+
+	afterRead func()
+}
+
+func (r pausingRepository) GetCredential(ctx context.Context, tenantID string, userID uuid.UUID) (domain.Credential, error) {
+	credential, err := r.CredentialRepository.GetCredential(ctx, tenantID, userID)
+
+	if ctx.Value(pausedLogin{}) != nil {
+		r.afterRead()
+	}
+
+	return credential, err
+}
+
+func testPasswordConfig() config.PasswordConfig {
+	return config.PasswordConfig{
+		MinLength:           8,
+		Iterations:          testIterations,
+		MaxConcurrentHashes: 1,
+		MaxFailures:         3,
+		Lockout:             config.Duration(time.Hour),
+	}
+}
+
+// newTestCredentials returns a credential service over repo, wrapped by wrap
+// when it is not nil, with a clock that stands still, and a user of the
+// default tenant whose password is testPassword.
+func newTestCredentials(t *testing.T, cfg config.PasswordConfig, wrap func(ports.CredentialRepository) ports.CredentialRepository) (*CredentialService, *data.InMemoryUserStorage, domain.User) {
+	t.Helper()
+
+	storage := data.NewInMemoryUserStorage()
+
+	var repo ports.CredentialRepository = storage
+	if wrap != nil {
+		repo = wrap(storage)
+	}
+
+	service, err := newCredentialService(repo, cfg, noop.NewTracerProvider().Tracer("test"))
+	if err != nil {
+		t.Fatalf("newCredentialService: %v", err)
+	}
+
+	now := time.Unix(1_700_000_000, 0)
+	service.now = func() time.Time { return now }
+
+	user, err := storage.CreateUser(context.Background(), domain.DefaultTenant, "Wile E. Coyote", "wile", "", nil)
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+
+	if err := service.SetPassword(tenantContext(), user.ID, testPassword); err != nil {
+		t.Fatalf("SetPassword: %v", err)
+	}
+
+	return service, storage, user
+}
+
+func tenantContext() context.Context {
+	return domain.ContextWithTenant(context.Background(), domain.DefaultTenant)
+}
+
+// TestLoginLockoutUnderConcurrentGuesses pauses a correct guess after it has
+// passed the lock check and lets enough concurrent wrong guesses fail to lock
+// the account. The correct guess must then be refused, or every login
+// queued for a hashing slot would be a free guess past MaxFailures.
+func TestLoginLockoutUnderConcurrentGuesses(t *testing.T) {
+	cfg := testPasswordConfig()
+
+	var service *CredentialService
+
+	service, storage, user := newTestCredentials(t, cfg, func(repo ports.CredentialRepository) ports.CredentialRepository {
+		return pausingRepository{CredentialRepository: repo, afterRead: func() {
+			var wg sync.WaitGroup
+
+			for range cfg.MaxFailures {
+				wg.Go(func() {
+					if _, err := service.Login(tenantContext(), "wile", "wrong password"); !errors.Is(err, ports.ErrInvalidCredentials) {
+						t.Errorf("wrong guess: error = %v, want ErrInvalidCredentials", err)
+					}
+				})
+			}
+
+			wg.Wait()
+		}}
+	})
+
+	ctx := context.WithValue(tenantContext(), pausedLogin{}, true)
+
+	if _, err := service.Login(ctx, "wile", testPassword); !errors.Is(err, ports.ErrInvalidCredentials) {
+		t.Errorf("correct guess after the lock: error = %v, want ErrInvalidCredentials", err)
+	}
+
+	credential, err := storage.GetCredential(context.Background(), domain.DefaultTenant, user.ID)
+	if err != nil {
+		t.Fatalf("GetCredential: %v", err)
+	}
+
+	if !credential.Locked(service.now()) {
+		t.Errorf("credential = %+v, want the account locked", credential)
+	}
+
+	if _, err := service.Login(tenantContext(), "wile", testPassword); !errors.Is(err, ports.ErrInvalidCredentials) {
+		t.Errorf("correct guess on the locked account: error = %v, want ErrInvalidCredentials", err)
+	}
+}
+
+// TestLoginRacingPasswordReset pauses a login with the old password while the
+// password is reset. The reset ends every session, so the login must not
+// open a new one.
+func TestLoginRacingPasswordReset(t *testing.T) {
+	var (
+		service *CredentialService
+		user    domain.User
+	)
+
+	service, _, user = newTestCredentials(t, testPasswordConfig(), func(repo ports.CredentialRepository) ports.CredentialRepository {
+		return pausingRepository{CredentialRepository: repo, afterRead: func() {
+			if err := service.SetPassword(tenantContext(), user.ID, "a brand new password"); err != nil {
+				t.Errorf("SetPassword: %v", err)
+			}
+		}}
+	})
+
+	ctx := context.WithValue(tenantContext(), pausedLogin{}, true)
+
+	if _, err := service.Login(ctx, "wile", testPassword); !errors.Is(err, ports.ErrInvalidCredentials) {
+		t.Errorf("login with the old password: error = %v, want ErrInvalidCredentials", err)
+	}
+
+	if _, err := service.Login(tenantContext(), "wile", "a brand new password"); err != nil {
+		t.Errorf("login with the new password: %v", err)
+	}
+}
+
+func TestLoginLockout(t *testing.T) {
+	cfg := testPasswordConfig()
+	service, storage, user := newTestCredentials(t, cfg, nil)
+
+	clock := testutil.NewClock(service.now())
+	service.now = clock.Now
+
+	for i := range cfg.MaxFailures {
+		if _, err := service.Login(tenantContext(), "wile", "wrong password"); !errors.Is(err, ports.ErrInvalidCredentials) {
+			t.Fatalf("wrong guess %d: error = %v, want ErrInvalidCredentials", i+1, err)
+		}
+	}
+
+	credential, err := storage.GetCredential(context.Background(), domain.DefaultTenant, user.ID)
+	if err != nil {
+		t.Fatalf("GetCredential: %v", err)
+	}
+
+	if want := clock.Now().Add(time.Duration(cfg.Lockout)); !credential.LockedUntil.Equal(want) {
+		t.Errorf("LockedUntil = %v, want %v", credential.LockedUntil, want)
+	}
+
+	clock.Advance(time.Duration(cfg.Lockout) - time.Second)
+
+	if _, err := service.Login(tenantContext(), "wile", testPassword); !errors.Is(err, ports.ErrInvalidCredentials) {
+		t.Errorf("correct guess while locked: error = %v, want ErrInvalidCredentials", err)
+	}
+
+	if err := service.ChangePassword(tenantContext(), user.ID, testPassword, "a brand new password"); !errors.Is(err, ports.ErrAccountLocked) {
+		t.Errorf("ChangePassword while locked: error = %v, want ErrAccountLocked", err)
+	}
+
+	clock.Advance(time.Second)
+
+	if _, err := service.Login(tenantContext(), "wile", testPassword); err != nil {
+		t.Fatalf("correct guess once the lock has run out: %v", err)
+	}
+
+	credential, err = storage.GetCredential(context.Background(), domain.DefaultTenant, user.ID)
+	if err != nil {
+		t.Fatalf("GetCredential: %v", err)
+	}
+
+	if credential.Failures != 0 {
+		t.Errorf("Failures = %d after a successful login, want 0", credential.Failures)
+	}
+
+	// The count started again, so one more failure does not lock.
+	if _, err := service.Login(tenantContext(), "wile", "wrong password"); !errors.Is(err, ports.ErrInvalidCredentials) {
+		t.Errorf("wrong guess: error = %v, want ErrInvalidCredentials", err)
+	}
+
+	if _, err := service.Login(tenantContext(), "wile", testPassword); err != nil {
+		t.Errorf("correct guess after a single failure: %v", err)
+	}
+}
+
+func TestLoginRehashesStaleHash(t *testing.T) {
+	service, storage, user := newTestCredentials(t, testPasswordConfig(), nil)
+
+	before, err := storage.GetCredential(context.Background(), domain.DefaultTenant, user.ID)
+	if err != nil {
+		t.Fatalf("GetCredential: %v", err)
+	}
+
+	// The same hash verified with the current iterations is not rehashed.
+	if _, err := service.Login(tenantContext(), "wile", testPassword); err != nil {
+		t.Fatalf("Login: %v", err)
+	}
+
+	if current, _ := storage.GetCredential(context.Background(), domain.DefaultTenant, user.ID); current.PasswordHash != before.PasswordHash {
+		t.Errorf("hash = %q after a login with current parameters, want %q", current.PasswordHash, before.PasswordHash)
+	}
+
+	service.hasher = newPasswordHasher(2*testIterations, 1)
+
+	if _, err := service.Login(tenantContext(), "wile", "wrong password"); !errors.Is(err, ports.ErrInvalidCredentials) {
+		t.Fatalf("wrong guess: error = %v, want ErrInvalidCredentials", err)
+	}
+
+	if current, _ := storage.GetCredential(context.Background(), domain.DefaultTenant, user.ID); current.PasswordHash != before.PasswordHash {
+		t.Error("a failed login replaced the hash")
+	}
+
+	if _, err := service.Login(tenantContext(), "wile", testPassword); err != nil {
+		t.Fatalf("Login after raising the iterations: %v", err)
+	}
+
+	after, err := storage.GetCredential(context.Background(), domain.DefaultTenant, user.ID)
+	if err != nil {
+		t.Fatalf("GetCredential: %v", err)
+	}
+
+	if !strings.HasPrefix(after.PasswordHash, "$pbkdf2-sha256$i=2000$") {
+		t.Errorf("hash = %q, want it rehashed with 2000 iterations", after.PasswordHash)
+	}
+
+	if after.Failures != 0 {
+		t.Errorf("Failures = %d after a successful login, want 0", after.Failures)
+	}
+
+	if _, err := service.Login(tenantContext(), "wile", testPassword); err != nil {
+		t.Errorf("Login with the rehashed password: %v", err)
+	}
+}
+
+// TestLoginFailuresLookAlike checks that every way a login can fail gives the
+// same error after waiting for a hashing slot, so neither the answer nor the
+// time taken tells which usernames exist or which accounts are locked.
+func TestLoginFailuresLookAlike(t *testing.T) {
+	cfg := testPasswordConfig()
+
+	tests := []struct {
+		name     string
+		username string
+		password string
+		prepare  func(t *testing.T, service *CredentialService, storage *data.InMemoryUserStorage)
+	}{
+		{name: "unknown username", username: "runner", password: testPassword},
+		{name: "wrong password", username: "wile", password: "wrong password"},
+		{
+			name:     "no password",
+			username: "nopass",
+			password: testPassword,
+			prepare: func(t *testing.T, _ *CredentialService, storage *data.InMemoryUserStorage) {
+				if _, err := storage.CreateUser(context.Background(), domain.DefaultTenant, "No Password", "nopass", "", nil); err != nil {
+					t.Fatalf("CreateUser: %v", err)
+				}
+			},
+		},
+		{
+			name:     "locked account",
+			username: "wile",
+			password: testPassword,
+			prepare: func(t *testing.T, service *CredentialService, _ *data.InMemoryUserStorage) {
+				for range cfg.MaxFailures {
+					_, _ = service.Login(tenantContext(), "wile", "wrong password")
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, storage, _ := newTestCredentials(t, cfg, nil)
+
+			if tt.prepare != nil {
+				tt.prepare(t, service, storage)
+			}
+
+			if _, err := service.Login(tenantContext(), tt.username, tt.password); !errors.Is(err, ports.ErrInvalidCredentials) || errors.Is(err, ports.ErrAccountLocked) {
+				t.Errorf("Login: error = %v, want only ErrInvalidCredentials", err)
+			}
+
+			// With the only slot taken, a login that hashes waits until
+			// its context ends.
+			service.hasher.slots <- struct{}{}
+			defer func() { <-service.hasher.slots }()
+
+			const wait = 20 * time.Millisecond
+
+			ctx, cancel := context.WithTimeout(tenantContext(), wait)
+			defer cancel()
+
+			start := time.Now()
+			_, _ = service.Login(ctx, tt.username, tt.password)
+
+			if elapsed := time.Since(start); elapsed < wait {
+				t.Errorf("Login returned after %v without waiting to hash", elapsed)
+			}
+		})
+	}
+}
@@ -2,79 +2,580 @@ package app
 
 import (
 	"context"
-	"errors"
+	"crypto/tls"
+	"log/slog"
+	"net"
 	"net/http"
+	"os"
+	"slices"
 	"strings"
+	"sync"
 	"time"
 
 	xerrors "github.com/go-faster/errors"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
 
 	api "github.com/flexer2006/t-t-ogen-go/generated"
+	"github.com/flexer2006/t-t-ogen-go/internal/adapters/auth"
 	clientadapter "github.com/flexer2006/t-t-ogen-go/internal/adapters/client"
 	"github.com/flexer2006/t-t-ogen-go/internal/adapters/data"
+	"github.com/flexer2006/t-t-ogen-go/internal/adapters/docs"
+	"github.com/flexer2006/t-t-ogen-go/internal/adapters/health"
+	"github.com/flexer2006/t-t-ogen-go/internal/adapters/notify"
 	serveradapter "github.com/flexer2006/t-t-ogen-go/internal/adapters/server"
+	"github.com/flexer2006/t-t-ogen-go/internal/adapters/telemetry"
+	"github.com/flexer2006/t-t-ogen-go/internal/config"
+	"github.com/flexer2006/t-t-ogen-go/internal/domain"
+	"github.com/flexer2006/t-t-ogen-go/internal/ports"
 )
 
-const (
-	defaultAddress          = ":42873"
-	shutdownTimeout         = 5 * time.Second
-	serverReadTimeout       = 15 * time.Second
-	serverWriteTimeout      = 15 * time.Second
-	serverIdleTimeout       = 60 * time.Second
-	serverReadHeaderTimeout = 5 * time.Second
-	serverMaxHeaderBytes    = http.DefaultMaxHeaderBytes
+var (
+	errUnknownStorageBackend = xerrors.New("unknown storage backend")
+	errUnknownAPIVersion     = xerrors.New("unknown API version")
+	errUnknownNotifier       = xerrors.New("unknown notifier")
 )
 
+// repositories is implemented by every storage backend.
+type repositories interface {
+	ports.UserRepository
+	ports.TenantRepository
+	ports.GroupRepository
+	ports.AttributeSchemaRepository
+	ports.EmailVerificationRepository
+	ports.CredentialRepository
+	ports.SessionRepository
+}
+
 type Application struct {
-	server  *http.Server
-	baseURL string
+	server          *http.Server
+	socketMode      os.FileMode
+	shutdownTimeout time.Duration
+	drainDelay      time.Duration
+	logger          *slog.Logger
+	observability   *observability
+	health          *health.Checker
+	inFlight        *serveradapter.InFlightTracker
+	storage         ports.UserRepository
+	workers         []worker
+
+	mu       sync.Mutex
+	listener net.Listener
+	running  *running
+	baseURL  string
+}
+
+type Option func(*options)
+
+type options struct {
+	logger         *slog.Logger
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+	notifier       ports.Notifier
+}
+
+func WithLogger(logger *slog.Logger) Option {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
+
+// WithTracerProvider takes precedence over the exporter selected in the
+// configuration.
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(o *options) {
+		o.tracerProvider = provider
+	}
 }
 
-func NewApplication(addr string) (*Application, error) {
-	if addr == "" {
-		addr = defaultAddress
+// WithMeterProvider replaces the built-in Prometheus provider for API and
+// repository instruments; the metrics endpoint then only serves runtime
+// statistics.
+func WithMeterProvider(provider metric.MeterProvider) Option {
+	return func(o *options) {
+		o.meterProvider = provider
 	}
+}
 
-	repo := data.NewInMemoryUserStorage()
+// WithNotifier delivers email verification tokens through notifier instead
+// of the one selected in the configuration.
+func WithNotifier(notifier ports.Notifier) Option {
+	return func(o *options) {
+		o.notifier = notifier
+	}
+}
 
-	service, err := newUserService(repo)
+func NewApplication(cfg config.Config, opts ...Option) (*Application, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, xerrors.Wrap(err, "app.NewApplication")
+	}
+
+	var appOptions options
+
+	for _, opt := range opts {
+		opt(&appOptions)
+	}
+
+	logger := appOptions.logger
+	if logger == nil {
+		logger = NewLogger(cfg.Log, os.Stderr)
+	}
+
+	storage, err := newUserRepository(cfg.Storage)
+	if err != nil {
+		return nil, xerrors.Wrap(err, "app.NewApplication: repository")
+	}
+
+	obs, err := newObservability(cfg, appOptions, storage)
+	if err != nil {
+		return nil, xerrors.Wrap(err, "app.NewApplication: observability")
+	}
+
+	repo, err := telemetry.NewTracedUserRepository(storage, obs.tracer())
+	if err != nil {
+		return nil, xerrors.Wrap(err, "app.NewApplication: traced repository")
+	}
+
+	notifier := appOptions.notifier
+	if notifier == nil {
+		notifier, err = newNotifier(cfg.Email)
+		if err != nil {
+			return nil, xerrors.Wrap(err, "app.NewApplication: notifier")
+		}
+	}
+
+	verifier, err := newEmailVerifier(storage, notifier, time.Duration(cfg.Email.VerificationTTL))
+	if err != nil {
+		return nil, xerrors.Wrap(err, "app.NewApplication: email verifier")
+	}
+
+	// The user service checks attributes against the patterns the attribute
+	// service compiled when it stored the schema.
+	patterns := newAttributePatterns()
+
+	service, err := newUserService(repo, storage, verifier, patterns, obs.tracer())
 	if err != nil {
 		return nil, xerrors.Wrap(err, "app.NewApplication: user service")
 	}
 
-	handler, err := serveradapter.NewUserHandler(service)
+	tenants, err := newTenantService(storage, obs.tracer())
+	if err != nil {
+		return nil, xerrors.Wrap(err, "app.NewApplication: tenant service")
+	}
+
+	groups, err := newGroupService(storage, obs.tracer())
+	if err != nil {
+		return nil, xerrors.Wrap(err, "app.NewApplication: group service")
+	}
+
+	attributes, err := newAttributeService(storage, patterns, obs.tracer())
+	if err != nil {
+		return nil, xerrors.Wrap(err, "app.NewApplication: attribute service")
+	}
+
+	credentials, err := newCredentialService(storage, cfg.Password, obs.tracer())
+	if err != nil {
+		return nil, xerrors.Wrap(err, "app.NewApplication: credential service")
+	}
+
+	sessions, err := newSessionService(storage, credentials, cfg.Session, obs.tracer())
+	if err != nil {
+		return nil, xerrors.Wrap(err, "app.NewApplication: session service")
+	}
+
+	policy, err := newAccessPolicy(cfg.Authz.PolicyFile)
+	if err != nil {
+		return nil, xerrors.Wrap(err, "app.NewApplication: access policy")
+	}
+
+	userService, err := newAuthorizingService(service, policy)
+	if err != nil {
+		return nil, xerrors.Wrap(err, "app.NewApplication: authorization")
+	}
+
+	tenantService, err := newAuthorizingTenantService(tenants, policy)
+	if err != nil {
+		return nil, xerrors.Wrap(err, "app.NewApplication: tenant authorization")
+	}
+
+	groupService, err := newAuthorizingGroupService(groups, policy)
+	if err != nil {
+		return nil, xerrors.Wrap(err, "app.NewApplication: group authorization")
+	}
+
+	attributeService, err := newAuthorizingAttributeService(attributes, policy)
+	if err != nil {
+		return nil, xerrors.Wrap(err, "app.NewApplication: attribute authorization")
+	}
+
+	credentialService, err := newAuthorizingCredentialService(credentials, policy)
+	if err != nil {
+		return nil, xerrors.Wrap(err, "app.NewApplication: credential authorization")
+	}
+
+	sessionService, err := newAuthorizingSessionService(sessions, policy)
+	if err != nil {
+		return nil, xerrors.Wrap(err, "app.NewApplication: session authorization")
+	}
+
+	handler, err := serveradapter.NewUserHandler(userService, tenantService, groupService, attributeService, credentialService, sessionService)
 	if err != nil {
 		return nil, xerrors.Wrap(err, "app.NewApplication: handler")
 	}
 
-	httpHandler, err := api.NewServer(handler)
+	security, err := newSecurityHandler(cfg.Auth, sessions, cfg.Server.TLS.ClientCAFile != "")
+	if err != nil {
+		return nil, xerrors.Wrap(err, "app.NewApplication: security handler")
+	}
+
+	accessLogger, err := serveradapter.NewAccessLogger(logger, cfg.Log.SuccessSampleRate)
+	if err != nil {
+		return nil, xerrors.Wrap(err, "app.NewApplication: access logger")
+	}
+
+	middlewares := []api.Middleware{
+		accessLogger.Middleware(),
+		serveradapter.NewTenantResolver(cfg.Tenancy.Enabled, tenantOperations...).Middleware(),
+	}
+
+	var limiter *serveradapter.RateLimiter
+
+	if cfg.RateLimit.Enabled {
+		limiter, err = newRateLimiter(cfg.RateLimit)
+		if err != nil {
+			return nil, xerrors.Wrap(err, "app.NewApplication: rate limiter")
+		}
+
+		middlewares = append(middlewares, limiter.Middleware())
+	}
+
+	handlerV1, err := serveradapter.NewUserHandlerV1(userService, tenantService)
+	if err != nil {
+		return nil, xerrors.Wrap(err, "app.NewApplication: v1 handler")
+	}
+
+	versions, err := newAPIVersions(cfg.API, handler, handlerV1, security, middlewares, obs)
 	if err != nil {
 		return nil, xerrors.Wrap(err, "app.NewApplication: http server")
 	}
 
+	var apiHandler http.Handler = versions
+
+	if limiter != nil {
+		apiHandler = limiter.Handler(apiHandler)
+	}
+
+	if cfg.Compression.Enabled {
+		compressor, err := serveradapter.NewCompressor(serveradapter.CompressionOptions{
+			MinSize:              cfg.Compression.MinSize,
+			Level:                cfg.Compression.Level,
+			MaxDecompressedBytes: int64(cfg.Compression.MaxDecompressedBytes),
+		})
+		if err != nil {
+			return nil, xerrors.Wrap(err, "app.NewApplication: compression")
+		}
+
+		apiHandler = compressor.Handler(apiHandler)
+	}
+
+	if cfg.CORS.Enabled {
+		cors := serveradapter.NewCORS(serveradapter.CORSOptions{
+			AllowedOrigins:   cfg.CORS.AllowedOrigins,
+			AllowedMethods:   cfg.CORS.AllowedMethods,
+			AllowedHeaders:   cfg.CORS.AllowedHeaders,
+			ExposedHeaders:   cfg.CORS.ExposedHeaders,
+			AllowCredentials: cfg.CORS.AllowCredentials,
+			MaxAge:           time.Duration(cfg.CORS.MaxAge),
+		})
+
+		apiHandler = cors.Handler(apiHandler, versions.FindRoute)
+	}
+
+	checker, err := newHealthChecker(cfg.Health, storage, logger)
+	if err != nil {
+		return nil, xerrors.Wrap(err, "app.NewApplication: health checker")
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(livenessPath, checker.LivenessHandler())
+	mux.Handle(readinessPath, checker.ReadinessHandler())
+	mux.Handle("/", accessLogger.Handler(telemetry.ExtractTraceContext(apiHandler), versions.FindRoute))
+
+	if obs.metrics != nil {
+		mux.Handle(cfg.Metrics.Path, obs.metrics.Handler())
+	}
+
+	if cfg.Docs.Enabled {
+		for _, document := range apiDocuments {
+			apiDocs, err := docs.New(document.spec, document.prefix)
+			if err != nil {
+				return nil, xerrors.Wrapf(err, "app.NewApplication: docs for %s", document.prefix)
+			}
+
+			mux.Handle(document.prefix+docs.YAMLPath, apiDocs.YAMLHandler())
+			mux.Handle(document.prefix+docs.JSONPath, apiDocs.JSONHandler())
+			mux.Handle(document.prefix+docs.ExplorerPath, docs.ExplorerHandler())
+
+			// The unprefixed paths describe the current version.
+			if document.prefix == apiV2Prefix {
+				mux.Handle(docs.YAMLPath, apiDocs.YAMLHandler())
+				mux.Handle(docs.JSONPath, apiDocs.JSONHandler())
+				mux.Handle(docs.ExplorerPath, docs.ExplorerHandler())
+			}
+		}
+	}
+
+	inFlight := serveradapter.NewInFlightTracker()
+
 	server := &http.Server{
-		Addr:              addr,
-		Handler:           httpHandler,
-		ReadTimeout:       serverReadTimeout,
-		WriteTimeout:      serverWriteTimeout,
-		IdleTimeout:       serverIdleTimeout,
-		ReadHeaderTimeout: serverReadHeaderTimeout,
-		MaxHeaderBytes:    serverMaxHeaderBytes,
+		Addr:              cfg.Server.Address,
+		Handler:           serveradapter.RequestID(inFlight.Handler(mux)),
+		ReadTimeout:       time.Duration(cfg.Server.ReadTimeout),
+		WriteTimeout:      time.Duration(cfg.Server.WriteTimeout),
+		IdleTimeout:       time.Duration(cfg.Server.IdleTimeout),
+		ReadHeaderTimeout: time.Duration(cfg.Server.ReadHeaderTimeout),
+		MaxHeaderBytes:    cfg.Server.MaxHeaderBytes,
+		ErrorLog:          slog.NewLogLogger(logger.Handler(), slog.LevelWarn),
+	}
+
+	socketMode, err := cfg.Server.UnixSocketMode()
+	if err != nil {
+		return nil, xerrors.Wrap(err, "app.NewApplication: socket mode")
+	}
+
+	if cfg.Server.TLS.Enabled() {
+		reloader, err := newTLSReloader(cfg.Server.TLS, logger)
+		if err != nil {
+			return nil, xerrors.Wrap(err, "app.NewApplication: tls")
+		}
+
+		server.TLSConfig = reloader.Config()
 	}
 
 	return &Application{
-		server:  server,
-		baseURL: inferBaseURL(server.Addr),
+		server:          server,
+		socketMode:      socketMode,
+		baseURL:         inferBaseURL(server.Addr, server.TLSConfig != nil),
+		shutdownTimeout: time.Duration(cfg.Server.ShutdownTimeout),
+		drainDelay:      time.Duration(cfg.Server.DrainDelay),
+		logger:          logger,
+		observability:   obs,
+		health:          checker,
+		inFlight:        inFlight,
+		storage:         storage,
+		workers: []worker{{
+			name: "session-sweeper",
+			run: func(ctx context.Context) error {
+				return sessions.sweep(ctx, time.Duration(cfg.Session.SweepInterval), logger)
+			},
+		}},
 	}, nil
 }
 
-func NewClient(baseURL string) (*clientadapter.Client, error) {
-	invoker, err := api.NewClient(baseURL)
+func newRateLimiter(cfg config.RateLimitConfig) (*serveradapter.RateLimiter, error) {
+	operations := make(map[string]serveradapter.RateLimit, len(cfg.Operations))
+
+	for operation, rule := range cfg.Operations {
+		if !knownOperation(operation) {
+			return nil, xerrors.Wrapf(errUnknownOperation, "app.newRateLimiter: %q", operation)
+		}
+
+		operations[operation] = serveradapter.RateLimit{Rate: rule.Rate, Burst: rule.Burst}
+	}
+
+	return serveradapter.NewRateLimiter(serveradapter.RateLimitOptions{
+		Default:    serveradapter.RateLimit{Rate: cfg.Rate, Burst: cfg.Burst},
+		Operations: operations,
+		MaxClients: cfg.MaxClients,
+	}), nil
+}
+
+func newTLSReloader(cfg config.TLSConfig, logger *slog.Logger) (*serveradapter.TLSReloader, error) {
+	options := serveradapter.TLSOptions{
+		CertFile:     cfg.CertFile,
+		KeyFile:      cfg.KeyFile,
+		ClientCAFile: cfg.ClientCAFile,
+	}
+
+	switch cfg.ClientAuthMode() {
+	case config.ClientAuthRequire:
+		options.ClientAuth = tls.RequireAndVerifyClientCert
+	case config.ClientAuthOptional:
+		options.ClientAuth = tls.VerifyClientCertIfGiven
+	}
+
+	return serveradapter.NewTLSReloader(options, logger)
+}
+
+func newUserRepository(cfg config.StorageConfig) (repositories, error) {
+	switch cfg.Backend {
+	case config.StorageMemory:
+		return data.NewInMemoryUserStorage(), nil
+	case config.StorageFile:
+		return data.NewFileUserStorage(cfg.Path)
+	default:
+		return nil, xerrors.Wrapf(errUnknownStorageBackend, "app.newUserRepository: %q", cfg.Backend)
+	}
+}
+
+func newNotifier(cfg config.EmailConfig) (ports.Notifier, error) {
+	switch cfg.Notifier {
+	case config.NotifierMemory:
+		return notify.NewMemoryNotifier(), nil
+	case config.NotifierFile:
+		return notify.NewFileNotifier(cfg.NotifierFile), nil
+	default:
+		return nil, xerrors.Wrapf(errUnknownNotifier, "app.newNotifier: %q", cfg.Notifier)
+	}
+}
+
+// APIVersion selects the API a client talks to.
+type APIVersion string
+
+const (
+	APIv1 APIVersion = "v1"
+	APIv2 APIVersion = "v2"
+)
+
+type ClientOption func(*clientOptions)
+
+type clientOptions struct {
+	credentials    clientadapter.Credentials
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+	caFile         string
+	certFile       string
+	keyFile        string
+	tlsConfig      *tls.Config
+	socketPath     string
+	tenant         string
+	version        APIVersion
+}
+
+func WithAPIKey(key string) ClientOption {
+	return func(o *clientOptions) {
+		o.credentials.APIKey = key
+	}
+}
+
+func WithBearerToken(token string) ClientOption {
+	return func(o *clientOptions) {
+		o.credentials.BearerToken = token
+	}
+}
+
+// WithSessionToken authenticates with the token of a session opened by a
+// login.
+func WithSessionToken(token string) ClientOption {
+	return func(o *clientOptions) {
+		o.credentials.SessionToken = token
+	}
+}
+
+// WithAPIVersion selects the API version; v2 is the default. The deprecated
+// v1 API cannot represent display names other than as the user's name.
+func WithAPIVersion(version APIVersion) ClientOption {
+	return func(o *clientOptions) {
+		o.version = version
+	}
+}
+
+// WithTenant addresses user operations to tenant, which principals not bound
+// to a tenant need once tenancy is enabled.
+func WithTenant(tenant string) ClientOption {
+	return func(o *clientOptions) {
+		o.tenant = tenant
+	}
+}
+
+// WithCAFile trusts the PEM CA bundle at path instead of the system roots
+// when verifying the server certificate.
+func WithCAFile(path string) ClientOption {
+	return func(o *clientOptions) {
+		o.caFile = path
+	}
+}
+
+// WithClientCertificate presents the certificate for mutual TLS and
+// authenticates with it when no API key or token is set.
+func WithClientCertificate(certFile, keyFile string) ClientOption {
+	return func(o *clientOptions) {
+		o.certFile = certFile
+		o.keyFile = keyFile
+	}
+}
+
+// WithTLSConfig sets the base TLS configuration; WithCAFile and
+// WithClientCertificate are applied on top of a copy.
+func WithTLSConfig(config *tls.Config) ClientOption {
+	return func(o *clientOptions) {
+		o.tlsConfig = config
+	}
+}
+
+func WithClientTracerProvider(provider trace.TracerProvider) ClientOption {
+	return func(o *clientOptions) {
+		o.tracerProvider = provider
+	}
+}
+
+func WithClientMeterProvider(provider metric.MeterProvider) ClientOption {
+	return func(o *clientOptions) {
+		o.meterProvider = provider
+	}
+}
+
+// NewClient connects to baseURL, which may also be a Unix socket address
+// such as "unix:/run/user-service.sock".
+func NewClient(baseURL string, opts ...ClientOption) (*clientadapter.Client, error) {
+	var options clientOptions
+
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	if path, ok := strings.CutPrefix(baseURL, serveradapter.UnixAddressPrefix); ok {
+		options.socketPath = path
+		baseURL = "http://localhost"
+
+		if options.tlsConfig != nil || options.caFile != "" || options.certFile != "" {
+			baseURL = "https://localhost"
+		}
+	}
+
+	httpClient, err := newHTTPClient(&options)
+	if err != nil {
+		return nil, xerrors.Wrap(err, "app.NewClient: http client")
+	}
+
+	baseURL = strings.TrimSuffix(baseURL, "/")
+
+	switch options.version {
+	case APIv1:
+		return newClientV1(baseURL+apiV1Prefix, httpClient, &options)
+	case "", APIv2:
+	default:
+		return nil, xerrors.Wrapf(errUnknownAPIVersion, "app.NewClient: %q", options.version)
+	}
+
+	invokerOptions := []api.ClientOption{
+		api.WithClient(telemetry.NewPropagatingClient(clientadapter.NewDecompressingClient(httpClient))),
+	}
+
+	if options.tracerProvider != nil {
+		invokerOptions = append(invokerOptions, api.WithTracerProvider(options.tracerProvider))
+	}
+
+	if options.meterProvider != nil {
+		invokerOptions = append(invokerOptions, api.WithMeterProvider(options.meterProvider))
+	}
+
+	invoker, err := api.NewClient(baseURL+apiV2Prefix, options.credentials, invokerOptions...)
 	if err != nil {
 		return nil, xerrors.Wrap(err, "app.NewClient: invoker")
 	}
 
-	client, err := clientadapter.New(invoker)
+	client, err := clientadapter.New(invoker, options.tenant)
 	if err != nil {
 		return nil, xerrors.Wrap(err, "app.NewClient: adapter")
 	}
@@ -82,49 +583,155 @@ func NewClient(baseURL string) (*clientadapter.Client, error) {
 	return client, nil
 }
 
-func (a *Application) Client() (*clientadapter.Client, error) {
-	return NewClient(a.baseURL)
+func newHTTPClient(options *clientOptions) (*http.Client, error) {
+	if options.tlsConfig == nil && options.caFile == "" && options.certFile == "" && options.socketPath == "" {
+		return http.DefaultClient, nil
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if options.socketPath != "" {
+		var dialer net.Dialer
+
+		transport.DialContext = func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return dialer.DialContext(ctx, "unix", options.socketPath)
+		}
+	}
+
+	config := &tls.Config{MinVersion: tls.VersionTLS12}
+	if options.tlsConfig != nil {
+		config = options.tlsConfig.Clone()
+	}
+
+	if options.caFile != "" {
+		pool, err := serveradapter.LoadCertPool(options.caFile)
+		if err != nil {
+			return nil, xerrors.Wrap(err, "ca file")
+		}
+
+		config.RootCAs = pool
+	}
+
+	if options.certFile != "" {
+		certificate, err := tls.LoadX509KeyPair(options.certFile, options.keyFile)
+		if err != nil {
+			return nil, xerrors.Wrap(err, "client certificate")
+		}
+
+		config.Certificates = append(config.Certificates, certificate)
+	}
+
+	if len(config.Certificates) > 0 || config.GetClientCertificate != nil {
+		options.credentials.ClientCertificate = true
+	}
+
+	transport.TLSClientConfig = config
+
+	return &http.Client{Transport: transport}, nil
+}
+
+func (a *Application) Client(opts ...ClientOption) (*clientadapter.Client, error) {
+	defaults := []ClientOption{WithClientTracerProvider(a.observability.tracerProvider)}
+
+	a.mu.Lock()
+	baseURL := a.baseURL
+	a.mu.Unlock()
+
+	return NewClient(baseURL, append(defaults, opts...)...)
+}
+
+func newSecurityHandler(cfg config.AuthConfig, sessions ports.SessionAuthenticator, clientCertificates bool) (*auth.SecurityHandler, error) {
+	var (
+		keys   ports.APIKeyStore
+		tokens ports.TokenVerifier
+	)
+
+	if cfg.APIKeysFile != "" || len(cfg.APIKeys) > 0 {
+		store, err := newAPIKeyStore(cfg)
+		if err != nil {
+			return nil, xerrors.Wrap(err, "app.newSecurityHandler: api keys")
+		}
+
+		keys = store
+	}
+
+	if cfg.JWT.JWKSFile != "" {
+		keySet, err := auth.NewKeySet(cfg.JWT.JWKSFile)
+		if err != nil {
+			return nil, xerrors.Wrap(err, "app.newSecurityHandler: jwks")
+		}
+
+		verifier, err := auth.NewJWTVerifier(keySet, auth.JWTOptions{
+			Issuer:    cfg.JWT.Issuer,
+			Audience:  cfg.JWT.Audience,
+			ClockSkew: time.Duration(cfg.JWT.ClockSkew),
+		})
+		if err != nil {
+			return nil, xerrors.Wrap(err, "app.newSecurityHandler: jwt verifier")
+		}
+
+		tokens = verifier
+	}
+
+	return auth.NewSecurityHandler(keys, tokens, sessions, clientCertificates)
 }
 
-func (a *Application) Run(ctx context.Context) error {
-	serverErrors := make(chan error, 1)
+func newAPIKeyStore(cfg config.AuthConfig) (*data.StaticAPIKeyStore, error) {
+	entries := cfg.APIKeys
 
-	go func() {
-		if err := a.server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
-			serverErrors <- xerrors.Wrap(err, "app.Application.Run: listen")
-			return
+	if cfg.APIKeysFile != "" {
+		fromFile, err := config.ReadAPIKeysFile(cfg.APIKeysFile)
+		if err != nil {
+			return nil, xerrors.Wrap(err, "app.newAPIKeyStore")
 		}
 
-		serverErrors <- nil
-	}()
+		entries = append(slices.Clip(entries), fromFile...)
+	}
+
+	keys := make([]domain.APIKey, len(entries))
 
-	select {
-	case err := <-serverErrors:
-		return err
-	case <-ctx.Done():
-		shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), shutdownTimeout)
-		defer cancel()
+	for i, entry := range entries {
+		keys[i] = domain.APIKey{Label: entry.Label, Hash: entry.Hash, Roles: entry.Roles, Tenant: entry.Tenant}
 
-		if err := a.server.Shutdown(shutdownCtx); err != nil {
-			return xerrors.Wrap(err, "app.Application.Run: shutdown")
+		if entry.ExpiresAt != nil {
+			keys[i].ExpiresAt = *entry.ExpiresAt
 		}
+	}
 
-		return <-serverErrors
+	store, err := data.NewStaticAPIKeyStore(keys)
+	if err != nil {
+		return nil, xerrors.Wrap(err, "app.newAPIKeyStore")
 	}
+
+	return store, nil
 }
 
-func inferBaseURL(addr string) string {
-	if addr == "" {
+// inferBaseURL keeps Unix socket addresses, which NewClient dials directly.
+// Inherited listeners have no address until Run resolves them.
+func inferBaseURL(addr string, secure bool) string {
+	if addr == "" || addr == serveradapter.SystemdAddress || strings.HasPrefix(addr, serveradapter.SystemdAddress+":") {
 		return ""
 	}
 
-	if strings.HasPrefix(addr, ":") {
-		return "http://localhost" + addr
+	if strings.HasPrefix(addr, serveradapter.UnixAddressPrefix) {
+		return addr
 	}
 
 	if strings.HasPrefix(addr, "http://") || strings.HasPrefix(addr, "https://") {
 		return addr
 	}
 
-	return "http://" + addr
+	scheme := "http://"
+	if secure {
+		scheme = "https://"
+	}
+
+	// Wildcard hosts are not dialable everywhere, so they map to localhost.
+	if host, port, err := net.SplitHostPort(addr); err == nil {
+		if ip := net.ParseIP(host); host == "" || ip != nil && ip.IsUnspecified() {
+			return scheme + net.JoinHostPort("localhost", port)
+		}
+	}
+
+	return scheme + addr
 }
@@ -0,0 +1,94 @@
+package app
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"time"
+
+	xerrors "github.com/go-faster/errors"
+	"github.com/google/uuid"
+
+	"github.com/flexer2006/t-t-ogen-go/internal/domain"
+	"github.com/flexer2006/t-t-ogen-go/internal/ports"
+)
+
+const verificationTokenBytes = 32
+
+var errNilNotifier = xerrors.New("nil notifier dependency")
+
+// emailVerifier issues and checks email verification tokens. Tokens are
+// random and only their SHA-256 hash is stored: unlike passwords they carry
+// enough entropy that a fast hash cannot be reversed.
+type emailVerifier struct {
+	repo     ports.EmailVerificationRepository
+	notifier ports.Notifier
+	ttl      time.Duration
+	now      func() time.Time
+}
+
+func newEmailVerifier(repo ports.EmailVerificationRepository, notifier ports.Notifier, ttl time.Duration) (*emailVerifier, error) {
+	if repo == nil {
+		return nil, xerrors.Wrap(errNilRepository, "app.newEmailVerifier")
+	}
+
+	if notifier == nil {
+		return nil, xerrors.Wrap(errNilNotifier, "app.newEmailVerifier")
+	}
+
+	return &emailVerifier{repo: repo, notifier: notifier, ttl: ttl, now: time.Now}, nil
+}
+
+// issue replaces the pending verification of the user and sends the new
+// token to the user's address.
+func (v *emailVerifier) issue(ctx context.Context, tenantID string, user domain.User) error {
+	secret := make([]byte, verificationTokenBytes)
+	_, _ = rand.Read(secret)
+
+	token := base64.RawURLEncoding.EncodeToString(secret)
+	expiresAt := v.now().Add(v.ttl)
+
+	if err := v.repo.PutEmailVerification(ctx, tenantID, user.ID, domain.VerificationToken{
+		Hash:      hashToken(token),
+		Email:     user.Email,
+		ExpiresAt: expiresAt,
+	}); err != nil {
+		return xerrors.Wrap(err, "app.emailVerifier.issue")
+	}
+
+	if err := v.notifier.SendEmailVerification(ctx, domain.EmailVerification{
+		TenantID:  tenantID,
+		UserID:    user.ID,
+		Email:     user.Email,
+		Token:     token,
+		ExpiresAt: expiresAt,
+	}); err != nil {
+		return xerrors.Wrap(err, "app.emailVerifier.issue: notify")
+	}
+
+	return nil
+}
+
+func (v *emailVerifier) verify(ctx context.Context, tenantID string, userID uuid.UUID, token string) (domain.User, error) {
+	verified, err := v.repo.ConsumeEmailVerification(ctx, tenantID, userID, hashToken(token), v.now())
+	if err != nil {
+		return domain.User{}, xerrors.Wrap(err, "app.emailVerifier.verify")
+	}
+
+	return verified, nil
+}
+
+func hashToken(token string) []byte {
+	sum := sha256.Sum256([]byte(token))
+
+	return sum[:]
+}
+
+func validateEmail(email string) error {
+	if email == "" || domain.ValidEmail(email) {
+		return nil
+	}
+
+	return &ports.ValidationError{Err: ports.ErrInvalidEmail, Problems: []string{"email: must be a bare address such as ann@example.com"}}
+}
@@ -0,0 +1,210 @@
+package app
+
+import (
+	"context"
+
+	xerrors "github.com/go-faster/errors"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/flexer2006/t-t-ogen-go/internal/adapters/telemetry"
+	"github.com/flexer2006/t-t-ogen-go/internal/domain"
+	"github.com/flexer2006/t-t-ogen-go/internal/ports"
+)
+
+// GroupService manages the groups of the request's tenant.
+type GroupService struct {
+	repo   ports.GroupRepository
+	tracer trace.Tracer
+}
+
+var _ ports.GroupService = (*GroupService)(nil)
+
+func newGroupService(repo ports.GroupRepository, tracer trace.Tracer) (*GroupService, error) {
+	if repo == nil {
+		return nil, xerrors.Wrap(errNilRepository, "app.newGroupService")
+	}
+
+	if tracer == nil {
+		return nil, xerrors.Wrap(errNilTracer, "app.newGroupService")
+	}
+
+	return &GroupService{repo: repo, tracer: tracer}, nil
+}
+
+func (s *GroupService) ListGroups(ctx context.Context) ([]domain.Group, error) {
+	ctx, span := s.tracer.Start(ctx, "app.GroupService.ListGroups")
+	defer span.End()
+
+	tenantID, err := tenantFromContext(ctx, span)
+	if err != nil {
+		return nil, xerrors.Wrap(err, "app.GroupService.ListGroups")
+	}
+
+	groups, err := s.repo.ListGroups(ctx, tenantID)
+	if err != nil {
+		telemetry.RecordError(span, err)
+
+		return nil, xerrors.Wrap(err, "app.GroupService.ListGroups")
+	}
+
+	return groups, nil
+}
+
+func (s *GroupService) CreateGroup(ctx context.Context, name, description string) (domain.Group, error) {
+	ctx, span := s.tracer.Start(ctx, "app.GroupService.CreateGroup")
+	defer span.End()
+
+	tenantID, err := tenantFromContext(ctx, span)
+	if err != nil {
+		return domain.Group{}, xerrors.Wrap(err, "app.GroupService.CreateGroup")
+	}
+
+	group, err := s.repo.CreateGroup(ctx, tenantID, name, description)
+	if err != nil {
+		telemetry.RecordError(span, err)
+
+		return domain.Group{}, xerrors.Wrap(err, "app.GroupService.CreateGroup")
+	}
+
+	span.SetAttributes(groupIDAttribute(group.ID))
+
+	return group, nil
+}
+
+func (s *GroupService) GetGroup(ctx context.Context, id uuid.UUID) (domain.Group, error) {
+	ctx, span := s.tracer.Start(ctx, "app.GroupService.GetGroup", trace.WithAttributes(groupIDAttribute(id)))
+	defer span.End()
+
+	tenantID, err := tenantFromContext(ctx, span)
+	if err != nil {
+		return domain.Group{}, xerrors.Wrap(err, "app.GroupService.GetGroup")
+	}
+
+	group, err := s.repo.GetGroup(ctx, tenantID, id)
+	if err != nil {
+		telemetry.RecordError(span, err)
+
+		return domain.Group{}, xerrors.Wrap(err, "app.GroupService.GetGroup")
+	}
+
+	return group, nil
+}
+
+func (s *GroupService) UpdateGroup(ctx context.Context, id uuid.UUID, name *string, description *string) (domain.Group, error) {
+	ctx, span := s.tracer.Start(ctx, "app.GroupService.UpdateGroup", trace.WithAttributes(groupIDAttribute(id)))
+	defer span.End()
+
+	tenantID, err := tenantFromContext(ctx, span)
+	if err != nil {
+		return domain.Group{}, xerrors.Wrap(err, "app.GroupService.UpdateGroup")
+	}
+
+	group, err := s.repo.UpdateGroup(ctx, tenantID, id, name, description)
+	if err != nil {
+		telemetry.RecordError(span, err)
+
+		return domain.Group{}, xerrors.Wrap(err, "app.GroupService.UpdateGroup")
+	}
+
+	return group, nil
+}
+
+func (s *GroupService) DeleteGroup(ctx context.Context, id uuid.UUID) error {
+	ctx, span := s.tracer.Start(ctx, "app.GroupService.DeleteGroup", trace.WithAttributes(groupIDAttribute(id)))
+	defer span.End()
+
+	tenantID, err := tenantFromContext(ctx, span)
+	if err != nil {
+		return xerrors.Wrap(err, "app.GroupService.DeleteGroup")
+	}
+
+	if err := s.repo.DeleteGroup(ctx, tenantID, id); err != nil {
+		telemetry.RecordError(span, err)
+
+		return xerrors.Wrap(err, "app.GroupService.DeleteGroup")
+	}
+
+	return nil
+}
+
+func (s *GroupService) ListGroupMembers(ctx context.Context, groupID uuid.UUID) ([]domain.User, error) {
+	ctx, span := s.tracer.Start(ctx, "app.GroupService.ListGroupMembers", trace.WithAttributes(groupIDAttribute(groupID)))
+	defer span.End()
+
+	tenantID, err := tenantFromContext(ctx, span)
+	if err != nil {
+		return nil, xerrors.Wrap(err, "app.GroupService.ListGroupMembers")
+	}
+
+	users, err := s.repo.ListGroupMembers(ctx, tenantID, groupID)
+	if err != nil {
+		telemetry.RecordError(span, err)
+
+		return nil, xerrors.Wrap(err, "app.GroupService.ListGroupMembers")
+	}
+
+	return users, nil
+}
+
+func (s *GroupService) AddGroupMember(ctx context.Context, groupID, userID uuid.UUID) error {
+	ctx, span := s.tracer.Start(ctx, "app.GroupService.AddGroupMember",
+		trace.WithAttributes(groupIDAttribute(groupID), attribute.String("user.id", userID.String())))
+	defer span.End()
+
+	tenantID, err := tenantFromContext(ctx, span)
+	if err != nil {
+		return xerrors.Wrap(err, "app.GroupService.AddGroupMember")
+	}
+
+	if err := s.repo.AddGroupMember(ctx, tenantID, groupID, userID); err != nil {
+		telemetry.RecordError(span, err)
+
+		return xerrors.Wrap(err, "app.GroupService.AddGroupMember")
+	}
+
+	return nil
+}
+
+func (s *GroupService) RemoveGroupMember(ctx context.Context, groupID, userID uuid.UUID) error {
+	ctx, span := s.tracer.Start(ctx, "app.GroupService.RemoveGroupMember",
+		trace.WithAttributes(groupIDAttribute(groupID), attribute.String("user.id", userID.String())))
+	defer span.End()
+
+	tenantID, err := tenantFromContext(ctx, span)
+	if err != nil {
+		return xerrors.Wrap(err, "app.GroupService.RemoveGroupMember")
+	}
+
+	if err := s.repo.RemoveGroupMember(ctx, tenantID, groupID, userID); err != nil {
+		telemetry.RecordError(span, err)
+
+		return xerrors.Wrap(err, "app.GroupService.RemoveGroupMember")
+	}
+
+	return nil
+}
+
+func (s *GroupService) ListUserGroups(ctx context.Context, userID uuid.UUID) ([]domain.Group, error) {
+	ctx, span := s.tracer.Start(ctx, "app.GroupService.ListUserGroups", trace.WithAttributes(attribute.String("user.id", userID.String())))
+	defer span.End()
+
+	tenantID, err := tenantFromContext(ctx, span)
+	if err != nil {
+		return nil, xerrors.Wrap(err, "app.GroupService.ListUserGroups")
+	}
+
+	groups, err := s.repo.ListUserGroups(ctx, tenantID, userID)
+	if err != nil {
+		telemetry.RecordError(span, err)
+
+		return nil, xerrors.Wrap(err, "app.GroupService.ListUserGroups")
+	}
+
+	return groups, nil
+}
+
+func groupIDAttribute(id uuid.UUID) attribute.KeyValue {
+	return attribute.String("group.id", id.String())
+}
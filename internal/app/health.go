@@ -0,0 +1,45 @@
+package app
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	xerrors "github.com/go-faster/errors"
+
+	"github.com/flexer2006/t-t-ogen-go/internal/adapters/health"
+	"github.com/flexer2006/t-t-ogen-go/internal/config"
+	"github.com/flexer2006/t-t-ogen-go/internal/ports"
+)
+
+// The metrics path is validated not to collide with the probe paths, so
+// keep config.reservedPaths in step with them.
+const (
+	livenessPath  = "/healthz"
+	readinessPath = "/readyz"
+)
+
+type writableChecker interface {
+	CheckWritable(ctx context.Context) error
+}
+
+func newHealthChecker(cfg config.HealthConfig, repo ports.UserRepository, logger *slog.Logger) (*health.Checker, error) {
+	checker, err := health.NewChecker(time.Duration(cfg.CheckTimeout), logger)
+	if err != nil {
+		return nil, err
+	}
+
+	checker.Register("repository", func(ctx context.Context) error {
+		if _, err := repo.CountUsers(ctx); err != nil {
+			return xerrors.Wrap(err, "repository unreachable")
+		}
+
+		return nil
+	})
+
+	if writable, ok := repo.(writableChecker); ok {
+		checker.Register("storage_writable", writable.CheckWritable)
+	}
+
+	return checker, nil
+}
@@ -0,0 +1,402 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"sync"
+	"time"
+
+	xerrors "github.com/go-faster/errors"
+
+	serveradapter "github.com/flexer2006/t-t-ogen-go/internal/adapters/server"
+)
+
+var (
+	errNotListening   = xerrors.New("application is not listening")
+	errAlreadyStarted = xerrors.New("application already started")
+	errHandoffFailed  = xerrors.New("child process exited before serving")
+	errNoHandoff      = xerrors.New("storage backend cannot be handed off; use the file backend")
+)
+
+// worker is a background task bound to the lifetime of Run. It must return
+// once its context is cancelled.
+type worker struct {
+	name string
+	run  func(ctx context.Context) error
+}
+
+type flusher interface {
+	Flush(ctx context.Context) error
+}
+
+// sealer is storage that a handoff passes to the new process. Seal writes it
+// out and rejects changes from then on; Unseal takes changes again when the
+// handoff fails.
+type sealer interface {
+	Seal(ctx context.Context) error
+	Unseal()
+}
+
+// running holds the state of a started application.
+type running struct {
+	stopWorkers context.CancelFunc
+	workers     *workerGroup
+
+	// served is closed once serving ends, with its failure in serveErr.
+	served   chan struct{}
+	serveErr error
+
+	// stopped makes Stop shut down once; later calls return stopErr.
+	stopped sync.Once
+	stopErr error
+}
+
+// Run starts the application and shuts it down gracefully once ctx is done
+// or serving fails.
+func (a *Application) Run(ctx context.Context) error {
+	if err := a.Start(); err != nil {
+		return xerrors.Wrap(err, "app.Application.Run")
+	}
+
+	a.mu.Lock()
+	state := a.running
+	a.mu.Unlock()
+
+	select {
+	case <-state.served:
+	case <-ctx.Done():
+	}
+
+	return a.Stop(context.WithoutCancel(ctx))
+}
+
+// Start binds the listener and serves in the background. Once it returns,
+// Addr reports the bound address, which matters for port 0.
+func (a *Application) Start() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.running != nil {
+		return xerrors.Wrap(errAlreadyStarted, "app.Application.Start")
+	}
+
+	listener, err := serveradapter.Listen(a.server.Addr, serveradapter.ListenOptions{SocketMode: a.socketMode})
+	if err != nil {
+		return xerrors.Wrap(err, "app.Application.Start")
+	}
+
+	workersCtx, stopWorkers := context.WithCancel(context.Background())
+
+	state := &running{
+		stopWorkers: stopWorkers,
+		workers:     a.startWorkers(workersCtx),
+		served:      make(chan struct{}),
+	}
+
+	a.listener = listener
+	a.running = state
+	a.baseURL = inferBaseURL(serveradapter.ListenerAddress(listener), a.server.TLSConfig != nil)
+
+	a.logger.Info("listening",
+		slog.String("address", serveradapter.ListenerAddress(listener)),
+		slog.Bool("tls", a.server.TLSConfig != nil))
+
+	go func() {
+		defer close(state.served)
+
+		if err := a.serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			state.serveErr = xerrors.Wrap(err, "app.Application.Start: serve")
+		}
+	}()
+
+	if err := serveradapter.NotifyHandoffReady(); err != nil {
+		a.logger.Warn("handoff readiness notification failed", slog.Any("error", err))
+	}
+
+	return nil
+}
+
+// Stop shuts a started application down gracefully. The shutdown timeout
+// applies unless ctx ends earlier. Only the first call shuts down; every call
+// returns its result, including a failure to serve.
+func (a *Application) Stop(ctx context.Context) error {
+	a.mu.Lock()
+	state := a.running
+	a.mu.Unlock()
+
+	if state == nil {
+		return xerrors.Wrap(errNotListening, "app.Application.Stop")
+	}
+
+	state.stopped.Do(func() {
+		err := a.shutdown(ctx, state.stopWorkers, state.workers)
+		<-state.served
+
+		state.stopErr = errors.Join(err, state.serveErr)
+	})
+
+	return state.stopErr
+}
+
+// Addr returns the address the application listens on, in the syntax of
+// server.address, or an empty string before Start.
+func (a *Application) Addr() string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.listener == nil {
+		return ""
+	}
+
+	return serveradapter.ListenerAddress(a.listener)
+}
+
+// Handler returns the complete HTTP handler, including health and metrics
+// endpoints, for mounting into another server or httptest.
+func (a *Application) Handler() http.Handler {
+	return a.server.Handler
+}
+
+func (a *Application) serve(listener net.Listener) error {
+	if a.server.TLSConfig != nil {
+		// Certificates come from TLSConfig, which reloads them from disk.
+		return a.server.ServeTLS(listener, "", "")
+	}
+
+	return a.server.Serve(listener)
+}
+
+// Handoff starts a copy of the current process that inherits the listener
+// and returns once the copy serves on it. The caller then shuts this instance
+// down; connections queued on the socket are accepted by either process, so
+// a restart drops none of them.
+//
+// Storage is sealed before the copy loads it, so writes to this instance fail
+// with ports.ErrStorageReadOnly from then on instead of being lost. Storage
+// that cannot be sealed, such as the memory backend, cannot be handed off.
+func (a *Application) Handoff(ctx context.Context) (_ *os.Process, err error) {
+	a.mu.Lock()
+	listener := a.listener
+	a.mu.Unlock()
+
+	if listener == nil {
+		return nil, xerrors.Wrap(errNotListening, "app.Application.Handoff")
+	}
+
+	storage, ok := a.storage.(sealer)
+	if !ok {
+		return nil, xerrors.Wrap(errNoHandoff, "app.Application.Handoff")
+	}
+
+	if err := storage.Seal(ctx); err != nil {
+		return nil, xerrors.Wrap(err, "app.Application.Handoff: storage")
+	}
+
+	defer func() {
+		if err != nil {
+			storage.Unseal()
+		}
+	}()
+
+	file, err := serveradapter.ListenerFile(listener)
+	if err != nil {
+		return nil, xerrors.Wrap(err, "app.Application.Handoff: listener")
+	}
+	defer file.Close()
+
+	ready, readyWriter, err := os.Pipe()
+	if err != nil {
+		return nil, xerrors.Wrap(err, "app.Application.Handoff: pipe")
+	}
+	defer ready.Close()
+
+	executable, err := os.Executable()
+	if err != nil {
+		readyWriter.Close()
+
+		return nil, xerrors.Wrap(err, "app.Application.Handoff: executable")
+	}
+
+	cmd := exec.Command(executable, os.Args[1:]...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.ExtraFiles = []*os.File{file, readyWriter}
+	cmd.Env = serveradapter.HandoffEnv(os.Environ())
+
+	err = cmd.Start()
+	readyWriter.Close()
+
+	if err != nil {
+		return nil, xerrors.Wrap(err, "app.Application.Handoff: start")
+	}
+
+	signalled := make(chan error, 1)
+
+	go func() {
+		// The child writes once it serves; EOF alone means it exited first.
+		buf := make([]byte, 1)
+		_, err := ready.Read(buf)
+		signalled <- err
+	}()
+
+	select {
+	case err := <-signalled:
+		if err != nil {
+			_ = cmd.Wait()
+
+			return nil, xerrors.Wrap(errHandoffFailed, "app.Application.Handoff")
+		}
+	case <-ctx.Done():
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+
+		return nil, xerrors.Wrap(ctx.Err(), "app.Application.Handoff")
+	}
+
+	serveradapter.ReleaseListener(listener)
+	a.logger.Info("listener handed off", slog.Int("pid", cmd.Process.Pid))
+
+	return cmd.Process, nil
+}
+
+// shutdown runs the graceful shutdown sequence. Readiness fails first and the
+// listener stays open for the drain delay so load balancers stop routing
+// here; only then are new connections refused and in-flight requests,
+// background workers and storage brought to rest within the shutdown timeout.
+func (a *Application) shutdown(ctx context.Context, stopWorkers context.CancelFunc, workers *workerGroup) error {
+	a.health.StartDraining()
+	a.logger.Info("shutdown: readiness failing", slog.String("drain_delay", a.drainDelay.String()))
+
+	if a.drainDelay > 0 {
+		timer := time.NewTimer(a.drainDelay)
+
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+		}
+	}
+
+	deadlineCtx, cancel := context.WithTimeout(ctx, a.shutdownTimeout)
+	defer cancel()
+
+	var errs []error
+
+	a.logger.Info("shutdown: closing listener", slog.String("timeout", a.shutdownTimeout.String()))
+
+	if err := a.server.Shutdown(deadlineCtx); err != nil {
+		a.logInFlight()
+		errs = append(errs, xerrors.Wrap(err, "app.Application.shutdown: server"))
+
+		if err := a.server.Close(); err != nil {
+			errs = append(errs, xerrors.Wrap(err, "app.Application.shutdown: close"))
+		}
+	}
+
+	a.logger.Info("shutdown: waiting for in-flight requests")
+
+	if err := a.inFlight.Wait(deadlineCtx); err != nil {
+		a.logInFlight()
+		errs = append(errs, xerrors.Wrap(err, "app.Application.shutdown: in-flight requests"))
+	}
+
+	a.logger.Info("shutdown: stopping background workers")
+	stopWorkers()
+
+	if err := workers.wait(deadlineCtx); err != nil {
+		a.logger.Warn("shutdown: workers still running at deadline", slog.Any("workers", workers.running()))
+		errs = append(errs, xerrors.Wrap(err, "app.Application.shutdown: workers"))
+	}
+
+	if storage, ok := a.storage.(flusher); ok {
+		a.logger.Info("shutdown: flushing storage")
+
+		if err := storage.Flush(deadlineCtx); err != nil {
+			errs = append(errs, xerrors.Wrap(err, "app.Application.shutdown: storage"))
+		}
+	}
+
+	if err := a.observability.shutdown(deadlineCtx); err != nil {
+		errs = append(errs, xerrors.Wrap(err, "app.Application.shutdown: telemetry"))
+	}
+
+	a.logger.Info("shutdown: complete")
+
+	return errors.Join(errs...)
+}
+
+func (a *Application) logInFlight() {
+	for _, request := range a.inFlight.Snapshot() {
+		a.logger.Warn("shutdown: request still running at deadline",
+			slog.String("method", request.Method),
+			slog.String("path", request.Path),
+			slog.String("request_id", request.RequestID),
+			slog.String("elapsed", time.Since(request.Started).String()))
+	}
+}
+
+type workerGroup struct {
+	wg sync.WaitGroup
+
+	mu      sync.Mutex
+	pending map[string]struct{}
+}
+
+func (a *Application) startWorkers(ctx context.Context) *workerGroup {
+	group := &workerGroup{pending: make(map[string]struct{}, len(a.workers))}
+
+	for _, w := range a.workers {
+		group.pending[w.name] = struct{}{}
+
+		group.wg.Go(func() {
+			defer group.done(w.name)
+
+			if err := w.run(ctx); err != nil && !errors.Is(err, context.Canceled) {
+				a.logger.Error("background worker failed", slog.String("worker", w.name), slog.Any("error", err))
+			}
+		})
+	}
+
+	return group
+}
+
+func (g *workerGroup) done(name string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	delete(g.pending, name)
+}
+
+func (g *workerGroup) wait(ctx context.Context) error {
+	done := make(chan struct{})
+
+	go func() {
+		g.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (g *workerGroup) running() []string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	names := make([]string, 0, len(g.pending))
+
+	for name := range g.pending {
+		names = append(names, name)
+	}
+
+	return names
+}
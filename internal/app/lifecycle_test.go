@@ -0,0 +1,169 @@
+package app_test
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/flexer2006/t-t-ogen-go/internal/adapters/auth"
+	"github.com/flexer2006/t-t-ogen-go/internal/app"
+	"github.com/flexer2006/t-t-ogen-go/internal/config"
+)
+
+// TestGracefulShutdownUnderLoad keeps the service under load and stops it
+// halfway through. Every request must either complete or be refused at
+// connect time; a request that was accepted and then dropped is lost.
+func TestGracefulShutdownUnderLoad(t *testing.T) {
+	const (
+		workers = 32
+		load    = 2 * time.Second
+	)
+
+	key := newKey(t)
+
+	cfg := config.Default()
+	cfg.Server.Address = "127.0.0.1:0"
+	cfg.Server.DrainDelay = config.Duration(300 * time.Millisecond)
+	cfg.Auth.APIKeys = []config.APIKeyConfig{{Label: "load", Hash: auth.HashAPIKey(key), Roles: []string{"admin"}}}
+	cfg.Authz.PolicyFile = writePolicy(t)
+	cfg.Log.Level = "warn"
+	cfg.Metrics.Enabled = false
+
+	application, err := app.NewApplication(cfg)
+	if err != nil {
+		t.Fatalf("NewApplication: %v", err)
+	}
+
+	if err := application.Start(); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+
+	baseURL := "http://" + application.Addr()
+	waitReady(t, baseURL)
+
+	var (
+		ok, refused, lost atomic.Int64
+		wg                sync.WaitGroup
+		deadline          = time.Now().Add(load)
+	)
+
+	for range workers {
+		wg.Go(func() {
+			client := &http.Client{Transport: &http.Transport{MaxIdleConnsPerHost: 1}}
+
+			for time.Now().Before(deadline) {
+				switch err := get(client, baseURL+"/v2/users", key); {
+				case err == nil:
+					ok.Add(1)
+				case errors.Is(err, syscall.ECONNREFUSED):
+					refused.Add(1)
+				default:
+					t.Logf("lost request: %v", err)
+					lost.Add(1)
+				}
+			}
+		})
+	}
+
+	time.Sleep(load / 2)
+
+	if err := application.Stop(context.Background()); err != nil {
+		t.Errorf("Stop: %v", err)
+	}
+
+	wg.Wait()
+
+	t.Logf("ok=%d refused=%d lost=%d", ok.Load(), refused.Load(), lost.Load())
+
+	if ok.Load() == 0 {
+		t.Error("no request completed")
+	}
+
+	if n := lost.Load(); n != 0 {
+		t.Errorf("%d requests lost during shutdown", n)
+	}
+
+	// A second Stop returns the result of the first instead of blocking.
+	if err := application.Stop(context.Background()); err != nil {
+		t.Errorf("second Stop: %v", err)
+	}
+}
+
+func get(client *http.Client, url, key string) error {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("X-API-Key", key)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if _, err := io.Copy(io.Discard, resp.Body); err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return errors.New(resp.Status)
+	}
+
+	return nil
+}
+
+func waitReady(t *testing.T, baseURL string) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+
+	for time.Now().Before(deadline) {
+		resp, err := http.Get(baseURL + "/readyz")
+		if err == nil {
+			resp.Body.Close()
+
+			if resp.StatusCode == http.StatusOK {
+				return
+			}
+		}
+
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	t.Fatal("service did not become ready")
+}
+
+func writePolicy(t *testing.T) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "policy.yaml")
+
+	if err := os.WriteFile(path, []byte("roles:\n  admin:\n    allow: [\"*\"]\n"), 0o600); err != nil {
+		t.Fatalf("write policy: %v", err)
+	}
+
+	return path
+}
+
+func newKey(t *testing.T) string {
+	t.Helper()
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(secret)
+}
@@ -0,0 +1,18 @@
+package app
+
+import (
+	"io"
+	"log/slog"
+
+	"github.com/flexer2006/t-t-ogen-go/internal/config"
+)
+
+func NewLogger(cfg config.LogConfig, w io.Writer) *slog.Logger {
+	options := &slog.HandlerOptions{Level: cfg.SlogLevel()}
+
+	if cfg.Format == config.LogFormatText {
+		return slog.New(slog.NewTextHandler(w, options))
+	}
+
+	return slog.New(slog.NewJSONHandler(w, options))
+}
@@ -0,0 +1,122 @@
+package app
+
+import (
+	"context"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"sync"
+
+	xerrors "github.com/go-faster/errors"
+)
+
+const (
+	// passwordScheme names the algorithm in the PHC string format, which
+	// also records the parameters: $pbkdf2-sha256$i=<iterations>$<salt>$<key>.
+	passwordScheme  = "pbkdf2-sha256"
+	passwordSaltLen = 16
+	passwordKeyLen  = 32
+)
+
+var errMalformedPasswordHash = xerrors.New("malformed password hash")
+
+// passwordHasher hashes new passwords with the configured iterations and
+// verifies hashes made with any earlier setting. At most cap(slots) hashes
+// run at once, so a flood of logins cannot take every CPU from the rest of
+// the service; the others wait for a slot.
+type passwordHasher struct {
+	iterations int
+	slots      chan struct{}
+	// dummy is verified against when there is no hash to check, so a login
+	// for an unknown user takes as long as one with a wrong password.
+	dummy func() string
+}
+
+func newPasswordHasher(iterations, concurrency int) *passwordHasher {
+	hasher := &passwordHasher{iterations: iterations, slots: make(chan struct{}, concurrency)}
+	hasher.dummy = sync.OnceValue(func() string {
+		hash, _ := hasher.hash(context.Background(), "")
+
+		return hash
+	})
+
+	return hasher
+}
+
+func (h *passwordHasher) hash(ctx context.Context, password string) (string, error) {
+	salt := make([]byte, passwordSaltLen)
+	_, _ = rand.Read(salt)
+
+	key, err := h.key(ctx, password, salt, h.iterations, passwordKeyLen)
+	if err != nil {
+		return "", xerrors.Wrap(err, "app.passwordHasher.hash")
+	}
+
+	return fmt.Sprintf("$%s$i=%d$%s$%s", passwordScheme, h.iterations,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+// verify reports whether password matches encoded, and whether encoded was
+// made with other parameters than new hashes get.
+func (h *passwordHasher) verify(ctx context.Context, password, encoded string) (ok, stale bool, err error) {
+	iterations, salt, want, err := parsePasswordHash(encoded)
+	if err != nil {
+		return false, false, xerrors.Wrap(err, "app.passwordHasher.verify")
+	}
+
+	got, err := h.key(ctx, password, salt, iterations, len(want))
+	if err != nil {
+		return false, false, xerrors.Wrap(err, "app.passwordHasher.verify")
+	}
+
+	if subtle.ConstantTimeCompare(got, want) != 1 {
+		return false, false, nil
+	}
+
+	return true, iterations != h.iterations || len(want) != passwordKeyLen, nil
+}
+
+// burn spends the time of a verification without a hash to verify.
+func (h *passwordHasher) burn(ctx context.Context, password string) {
+	_, _, _ = h.verify(ctx, password, h.dummy())
+}
+
+// key derives a key once a slot is free, or fails when ctx ends first.
+func (h *passwordHasher) key(ctx context.Context, password string, salt []byte, iterations, length int) ([]byte, error) {
+	select {
+	case h.slots <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	defer func() { <-h.slots }()
+
+	return pbkdf2.Key(sha256.New, password, salt, iterations, length)
+}
+
+func parsePasswordHash(encoded string) (iterations int, salt, key []byte, err error) {
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 5 || parts[0] != "" || parts[1] != passwordScheme {
+		return 0, nil, nil, errMalformedPasswordHash
+	}
+
+	if _, err := fmt.Sscanf(parts[2], "i=%d", &iterations); err != nil || iterations < 1 {
+		return 0, nil, nil, errMalformedPasswordHash
+	}
+
+	salt, err = base64.RawStdEncoding.DecodeString(parts[3])
+	if err != nil {
+		return 0, nil, nil, errMalformedPasswordHash
+	}
+
+	key, err = base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil || len(key) == 0 {
+		return 0, nil, nil, errMalformedPasswordHash
+	}
+
+	return iterations, salt, key, nil
+}
@@ -0,0 +1,112 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestPasswordHasherVerify(t *testing.T) {
+	ctx := context.Background()
+	hasher := newPasswordHasher(testIterations, 1)
+
+	encoded, err := hasher.hash(ctx, testPassword)
+	if err != nil {
+		t.Fatalf("hash: %v", err)
+	}
+
+	if !strings.HasPrefix(encoded, "$pbkdf2-sha256$i=1000$") {
+		t.Errorf("hash = %q, want the PHC format with the configured iterations", encoded)
+	}
+
+	if again, _ := hasher.hash(ctx, testPassword); again == encoded {
+		t.Error("two hashes of the same password share a salt")
+	}
+
+	tests := []struct {
+		name      string
+		hasher    *passwordHasher
+		password  string
+		wantOK    bool
+		wantStale bool
+	}{
+		{name: "correct password", hasher: hasher, password: testPassword, wantOK: true},
+		{name: "wrong password", hasher: hasher, password: "wrong password"},
+		{name: "empty password", hasher: hasher, password: ""},
+		{name: "iterations raised since", hasher: newPasswordHasher(2*testIterations, 1), password: testPassword, wantOK: true, wantStale: true},
+		{name: "wrong password with iterations raised since", hasher: newPasswordHasher(2*testIterations, 1), password: "wrong password"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ok, stale, err := tt.hasher.verify(ctx, tt.password, encoded)
+			if err != nil {
+				t.Fatalf("verify: %v", err)
+			}
+
+			if ok != tt.wantOK || stale != tt.wantStale {
+				t.Errorf("verify = %v, %v; want %v, %v", ok, stale, tt.wantOK, tt.wantStale)
+			}
+		})
+	}
+}
+
+func TestPasswordHasherWaitsForSlot(t *testing.T) {
+	hasher := newPasswordHasher(testIterations, 1)
+	hasher.slots <- struct{}{}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := hasher.hash(ctx, testPassword); !errors.Is(err, context.Canceled) {
+		t.Errorf("hash without a free slot: error = %v, want context.Canceled", err)
+	}
+}
+
+func TestParsePasswordHash(t *testing.T) {
+	valid := "$pbkdf2-sha256$i=1000$c2FsdHNhbHRzYWx0c2FsdA$a2V5a2V5a2V5a2V5a2V5a2V5a2V5a2V5a2V5a2V5a2U"
+
+	iterations, salt, key, err := parsePasswordHash(valid)
+	if err != nil {
+		t.Fatalf("parsePasswordHash(%q): %v", valid, err)
+	}
+
+	if iterations != 1000 || string(salt) != "saltsaltsaltsalt" || len(key) != 32 {
+		t.Errorf("parsePasswordHash = %d, %q, %d bytes; want 1000, saltsaltsaltsalt, 32 bytes", iterations, salt, len(key))
+	}
+
+	tests := []struct {
+		name    string
+		encoded string
+	}{
+		{name: "empty", encoded: ""},
+		{name: "plain text", encoded: testPassword},
+		{name: "other scheme", encoded: "$argon2id$i=1000$c2FsdA$a2V5"},
+		{name: "text before the scheme", encoded: "x$pbkdf2-sha256$i=1000$c2FsdA$a2V5"},
+		{name: "missing key", encoded: "$pbkdf2-sha256$i=1000$c2FsdA"},
+		{name: "extra part", encoded: "$pbkdf2-sha256$i=1000$c2FsdA$a2V5$a2V5"},
+		{name: "missing iterations", encoded: "$pbkdf2-sha256$$c2FsdA$a2V5"},
+		{name: "iterations not a number", encoded: "$pbkdf2-sha256$i=many$c2FsdA$a2V5"},
+		{name: "zero iterations", encoded: "$pbkdf2-sha256$i=0$c2FsdA$a2V5"},
+		{name: "negative iterations", encoded: "$pbkdf2-sha256$i=-1$c2FsdA$a2V5"},
+		{name: "other parameter", encoded: "$pbkdf2-sha256$m=1000$c2FsdA$a2V5"},
+		{name: "salt not base64", encoded: "$pbkdf2-sha256$i=1000$!!!$a2V5"},
+		{name: "padded key", encoded: "$pbkdf2-sha256$i=1000$c2FsdA$a2V5a2U="},
+		{name: "key not base64", encoded: "$pbkdf2-sha256$i=1000$c2FsdA$!!!"},
+		{name: "empty key", encoded: "$pbkdf2-sha256$i=1000$c2FsdA$"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, _, err := parsePasswordHash(tt.encoded); !errors.Is(err, errMalformedPasswordHash) {
+				t.Errorf("parsePasswordHash(%q): error = %v, want errMalformedPasswordHash", tt.encoded, err)
+			}
+
+			ok, _, err := newPasswordHasher(testIterations, 1).verify(context.Background(), testPassword, tt.encoded)
+			if ok || !errors.Is(err, errMalformedPasswordHash) {
+				t.Errorf("verify(%q) = %v, %v; want false, errMalformedPasswordHash", tt.encoded, ok, err)
+			}
+		})
+	}
+}
@@ -5,77 +5,283 @@ import (
 
 	xerrors "github.com/go-faster/errors"
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 
+	"github.com/flexer2006/t-t-ogen-go/internal/adapters/telemetry"
 	"github.com/flexer2006/t-t-ogen-go/internal/domain"
 	"github.com/flexer2006/t-t-ogen-go/internal/ports"
 )
 
 var (
 	errNilRepository = xerrors.New("nil repository dependency")
+	errNilTracer     = xerrors.New("nil tracer dependency")
 )
 
 type Service struct {
-	repo ports.UserRepository
+	repo     ports.UserRepository
+	schemas  ports.AttributeSchemaRepository
+	verifier *emailVerifier
+	patterns *attributePatterns
+	tracer   trace.Tracer
 }
 
 var _ ports.UserService = (*Service)(nil)
 
-func newUserService(repo ports.UserRepository) (*Service, error) {
-	if repo == nil {
+func newUserService(repo ports.UserRepository, schemas ports.AttributeSchemaRepository, verifier *emailVerifier, patterns *attributePatterns, tracer trace.Tracer) (*Service, error) {
+	if repo == nil || schemas == nil || verifier == nil || patterns == nil {
 		return nil, xerrors.Wrap(errNilRepository, "app.newUserService")
 	}
 
-	return &Service{repo: repo}, nil
+	if tracer == nil {
+		return nil, xerrors.Wrap(errNilTracer, "app.newUserService")
+	}
+
+	return &Service{repo: repo, schemas: schemas, verifier: verifier, patterns: patterns, tracer: tracer}, nil
 }
 
-func (s *Service) ListUsers(ctx context.Context) ([]domain.User, error) {
-	users, err := s.repo.ListUsers(ctx)
+func (s *Service) ListUsers(ctx context.Context, filter domain.AttributeFilter) ([]domain.User, error) {
+	ctx, span := s.tracer.Start(ctx, "app.Service.ListUsers")
+	defer span.End()
+
+	tenantID, err := tenantFromContext(ctx, span)
+	if err != nil {
+		return nil, xerrors.Wrap(err, "app.Service.ListUsers")
+	}
+
+	var match domain.Attributes
+
+	if len(filter) > 0 {
+		schema, err := s.schemas.GetAttributeSchema(ctx, tenantID)
+		if err != nil {
+			telemetry.RecordError(span, err)
+
+			return nil, xerrors.Wrap(err, "app.Service.ListUsers")
+		}
+
+		if match, err = parseAttributeFilter(schema, filter); err != nil {
+			telemetry.RecordError(span, err)
+
+			return nil, xerrors.Wrap(err, "app.Service.ListUsers")
+		}
+	}
+
+	users, err := s.repo.ListUsers(ctx, tenantID, match)
 	if err != nil {
+		telemetry.RecordError(span, err)
+
 		return nil, xerrors.Wrap(err, "app.Service.ListUsers")
 	}
 
 	return users, nil
 }
 
-func (s *Service) CreateUser(ctx context.Context, name, username string) (domain.User, error) {
+// CreateUser sends a verification token to the user's email address. The
+// user is created even if sending fails; RequestEmailVerification retries.
+func (s *Service) CreateUser(ctx context.Context, name, username, email string, attributes domain.Attributes) (domain.User, error) {
+	ctx, span := s.tracer.Start(ctx, "app.Service.CreateUser")
+	defer span.End()
+
 	if err := ctx.Err(); err != nil {
+		telemetry.RecordError(span, err)
+
+		return domain.User{}, xerrors.Wrap(err, "app.Service.CreateUser")
+	}
+
+	tenantID, err := tenantFromContext(ctx, span)
+	if err != nil {
+		return domain.User{}, xerrors.Wrap(err, "app.Service.CreateUser")
+	}
+
+	if err := validateEmail(email); err != nil {
+		telemetry.RecordError(span, err)
+
 		return domain.User{}, xerrors.Wrap(err, "app.Service.CreateUser")
 	}
 
-	user, err := s.repo.CreateUser(ctx, name, username)
+	attributes, err = s.validateAttributes(ctx, tenantID, attributes)
 	if err != nil {
+		telemetry.RecordError(span, err)
+
+		return domain.User{}, xerrors.Wrap(err, "app.Service.CreateUser")
+	}
+
+	user, err := s.repo.CreateUser(ctx, tenantID, name, username, email, attributes)
+	if err != nil {
+		telemetry.RecordError(span, err)
+
 		return domain.User{}, xerrors.Wrap(err, "app.Service.CreateUser")
 	}
 
+	span.SetAttributes(attribute.String("user.id", user.ID.String()))
+
+	if user.Email != "" {
+		if err := s.verifier.issue(ctx, tenantID, user); err != nil {
+			telemetry.RecordError(span, err)
+		}
+	}
+
 	return user, nil
 }
 
 func (s *Service) GetUser(ctx context.Context, id uuid.UUID) (domain.User, error) {
-	user, err := s.repo.GetUser(ctx, id)
+	ctx, span := s.tracer.Start(ctx, "app.Service.GetUser", trace.WithAttributes(attribute.String("user.id", id.String())))
+	defer span.End()
+
+	tenantID, err := tenantFromContext(ctx, span)
+	if err != nil {
+		return domain.User{}, xerrors.Wrap(err, "app.Service.GetUser")
+	}
+
+	user, err := s.repo.GetUser(ctx, tenantID, id)
 	if err != nil {
+		telemetry.RecordError(span, err)
+
 		return domain.User{}, xerrors.Wrap(err, "app.Service.GetUser")
 	}
 
 	return user, nil
 }
 
-func (s *Service) UpdateUser(ctx context.Context, userID uuid.UUID, name *string, username *string) (domain.User, error) {
+// UpdateUser replaces every attribute of the user unless attributes is nil.
+// Setting an unverified address sends a token to it, as CreateUser does.
+func (s *Service) UpdateUser(
+	ctx context.Context,
+	userID uuid.UUID,
+	name *string,
+	username *string,
+	email *string,
+	attributes domain.Attributes,
+) (domain.User, error) {
+	ctx, span := s.tracer.Start(ctx, "app.Service.UpdateUser", trace.WithAttributes(attribute.String("user.id", userID.String())))
+	defer span.End()
+
 	if err := ctx.Err(); err != nil {
+		telemetry.RecordError(span, err)
+
+		return domain.User{}, xerrors.Wrap(err, "app.Service.UpdateUser")
+	}
+
+	tenantID, err := tenantFromContext(ctx, span)
+	if err != nil {
 		return domain.User{}, xerrors.Wrap(err, "app.Service.UpdateUser")
 	}
 
-	user, err := s.repo.UpdateUser(ctx, userID, name, username)
+	if email != nil {
+		if err := validateEmail(*email); err != nil {
+			telemetry.RecordError(span, err)
+
+			return domain.User{}, xerrors.Wrap(err, "app.Service.UpdateUser")
+		}
+	}
+
+	if attributes != nil {
+		if attributes, err = s.validateAttributes(ctx, tenantID, attributes); err != nil {
+			telemetry.RecordError(span, err)
+
+			return domain.User{}, xerrors.Wrap(err, "app.Service.UpdateUser")
+		}
+	}
+
+	user, err := s.repo.UpdateUser(ctx, tenantID, userID, name, username, email, attributes)
 	if err != nil {
+		telemetry.RecordError(span, err)
+
 		return domain.User{}, xerrors.Wrap(err, "app.Service.UpdateUser")
 	}
 
+	if email != nil && user.Email != "" && !user.EmailVerified {
+		if err := s.verifier.issue(ctx, tenantID, user); err != nil {
+			telemetry.RecordError(span, err)
+		}
+	}
+
 	return user, nil
 }
 
 func (s *Service) DeleteUser(ctx context.Context, id uuid.UUID) error {
-	if err := s.repo.DeleteUser(ctx, id); err != nil {
+	ctx, span := s.tracer.Start(ctx, "app.Service.DeleteUser", trace.WithAttributes(attribute.String("user.id", id.String())))
+	defer span.End()
+
+	tenantID, err := tenantFromContext(ctx, span)
+	if err != nil {
+		return xerrors.Wrap(err, "app.Service.DeleteUser")
+	}
+
+	if err := s.repo.DeleteUser(ctx, tenantID, id); err != nil {
+		telemetry.RecordError(span, err)
+
 		return xerrors.Wrap(err, "app.Service.DeleteUser")
 	}
 
 	return nil
 }
+
+func (s *Service) RequestEmailVerification(ctx context.Context, id uuid.UUID) error {
+	ctx, span := s.tracer.Start(ctx, "app.Service.RequestEmailVerification", trace.WithAttributes(attribute.String("user.id", id.String())))
+	defer span.End()
+
+	tenantID, err := tenantFromContext(ctx, span)
+	if err != nil {
+		return xerrors.Wrap(err, "app.Service.RequestEmailVerification")
+	}
+
+	user, err := s.repo.GetUser(ctx, tenantID, id)
+	if err != nil {
+		telemetry.RecordError(span, err)
+
+		return xerrors.Wrap(err, "app.Service.RequestEmailVerification")
+	}
+
+	if err := s.verifier.issue(ctx, tenantID, user); err != nil {
+		telemetry.RecordError(span, err)
+
+		return xerrors.Wrap(err, "app.Service.RequestEmailVerification")
+	}
+
+	return nil
+}
+
+func (s *Service) VerifyEmail(ctx context.Context, id uuid.UUID, token string) (domain.User, error) {
+	ctx, span := s.tracer.Start(ctx, "app.Service.VerifyEmail", trace.WithAttributes(attribute.String("user.id", id.String())))
+	defer span.End()
+
+	tenantID, err := tenantFromContext(ctx, span)
+	if err != nil {
+		return domain.User{}, xerrors.Wrap(err, "app.Service.VerifyEmail")
+	}
+
+	user, err := s.verifier.verify(ctx, tenantID, id, token)
+	if err != nil {
+		telemetry.RecordError(span, err)
+
+		return domain.User{}, xerrors.Wrap(err, "app.Service.VerifyEmail")
+	}
+
+	return user, nil
+}
+
+func (s *Service) validateAttributes(ctx context.Context, tenantID string, attributes domain.Attributes) (domain.Attributes, error) {
+	schema, err := s.schemas.GetAttributeSchema(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	return validateAttributes(schema, s.patterns, attributes)
+}
+
+// tenantFromContext returns the tenant resolved for the request. Without one
+// the call fails instead of falling back to any tenant, so a missing resolver
+// can never widen a query.
+func tenantFromContext(ctx context.Context, span trace.Span) (string, error) {
+	tenantID, ok := domain.TenantFromContext(ctx)
+	if !ok {
+		telemetry.RecordError(span, ports.ErrTenantRequired)
+
+		return "", ports.ErrTenantRequired
+	}
+
+	span.SetAttributes(attribute.String("tenant.id", tenantID))
+
+	return tenantID, nil
+}
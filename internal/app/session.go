@@ -0,0 +1,256 @@
+package app
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"log/slog"
+	"slices"
+	"time"
+
+	xerrors "github.com/go-faster/errors"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/flexer2006/t-t-ogen-go/internal/adapters/telemetry"
+	"github.com/flexer2006/t-t-ogen-go/internal/config"
+	"github.com/flexer2006/t-t-ogen-go/internal/domain"
+	"github.com/flexer2006/t-t-ogen-go/internal/ports"
+)
+
+const (
+	sessionTokenBytes = 32
+	// maxSessionTouchInterval limits how often a session records its use.
+	// Each recorded use is written to durable storage, so a busy session
+	// must not record every request.
+	maxSessionTouchInterval = time.Minute
+)
+
+var errNilCredentials = xerrors.New("nil credential service dependency")
+
+// SessionService opens sessions for logins and authenticates their tokens.
+// Like email verification tokens, session tokens are random enough that
+// storing their SHA-256 hash suffices.
+type SessionService struct {
+	repo        ports.SessionRepository
+	credentials ports.CredentialService
+	idleTimeout time.Duration
+	maxLifetime time.Duration
+	// touchInterval is short enough that a session in use never idles out.
+	touchInterval time.Duration
+	roles         []string
+	now           func() time.Time
+	tracer        trace.Tracer
+}
+
+var (
+	_ ports.SessionService       = (*SessionService)(nil)
+	_ ports.SessionAuthenticator = (*SessionService)(nil)
+)
+
+func newSessionService(
+	repo ports.SessionRepository,
+	credentials ports.CredentialService,
+	cfg config.SessionConfig,
+	tracer trace.Tracer,
+) (*SessionService, error) {
+	if repo == nil {
+		return nil, xerrors.Wrap(errNilRepository, "app.newSessionService")
+	}
+
+	if credentials == nil {
+		return nil, xerrors.Wrap(errNilCredentials, "app.newSessionService")
+	}
+
+	if tracer == nil {
+		return nil, xerrors.Wrap(errNilTracer, "app.newSessionService")
+	}
+
+	return &SessionService{
+		repo:          repo,
+		credentials:   credentials,
+		idleTimeout:   time.Duration(cfg.IdleTimeout),
+		maxLifetime:   time.Duration(cfg.MaxLifetime),
+		touchInterval: min(maxSessionTouchInterval, time.Duration(cfg.IdleTimeout)/2),
+		roles:         slices.Clone(cfg.Roles),
+		now:           time.Now,
+		tracer:        tracer,
+	}, nil
+}
+
+func (s *SessionService) Login(ctx context.Context, username, password string) (domain.Login, error) {
+	ctx, span := s.tracer.Start(ctx, "app.SessionService.Login")
+	defer span.End()
+
+	tenantID, err := tenantFromContext(ctx, span)
+	if err != nil {
+		return domain.Login{}, xerrors.Wrap(err, "app.SessionService.Login")
+	}
+
+	user, err := s.credentials.Login(ctx, username, password)
+	if err != nil {
+		return domain.Login{}, xerrors.Wrap(err, "app.SessionService.Login")
+	}
+
+	secret := make([]byte, sessionTokenBytes)
+	_, _ = rand.Read(secret)
+
+	token := base64.RawURLEncoding.EncodeToString(secret)
+	now := s.now()
+
+	session := domain.Session{
+		ID:            uuid.New(),
+		TenantID:      tenantID,
+		UserID:        user.ID,
+		TokenHash:     hashToken(token),
+		CreatedAt:     now,
+		LastUsedAt:    now,
+		IdleExpiresAt: now.Add(s.idleTimeout),
+		ExpiresAt:     now.Add(s.maxLifetime),
+	}
+
+	span.SetAttributes(attribute.String("user.id", user.ID.String()), attribute.String("session.id", session.ID.String()))
+
+	if err := s.repo.CreateSession(ctx, session); err != nil {
+		telemetry.RecordError(span, err)
+
+		return domain.Login{}, xerrors.Wrap(err, "app.SessionService.Login")
+	}
+
+	return domain.Login{User: user, Session: session, Token: token}, nil
+}
+
+func (s *SessionService) ListSessions(ctx context.Context, userID uuid.UUID) ([]domain.Session, error) {
+	ctx, span := s.tracer.Start(ctx, "app.SessionService.ListSessions", trace.WithAttributes(attribute.String("user.id", userID.String())))
+	defer span.End()
+
+	tenantID, err := tenantFromContext(ctx, span)
+	if err != nil {
+		return nil, xerrors.Wrap(err, "app.SessionService.ListSessions")
+	}
+
+	sessions, err := s.repo.ListSessions(ctx, tenantID, userID, s.now())
+	if err != nil {
+		telemetry.RecordError(span, err)
+
+		return nil, xerrors.Wrap(err, "app.SessionService.ListSessions")
+	}
+
+	span.SetAttributes(attribute.Int("sessions.count", len(sessions)))
+
+	return sessions, nil
+}
+
+func (s *SessionService) RevokeSession(ctx context.Context, userID, sessionID uuid.UUID) error {
+	ctx, span := s.tracer.Start(ctx, "app.SessionService.RevokeSession", trace.WithAttributes(
+		attribute.String("user.id", userID.String()),
+		attribute.String("session.id", sessionID.String()),
+	))
+	defer span.End()
+
+	tenantID, err := tenantFromContext(ctx, span)
+	if err != nil {
+		return xerrors.Wrap(err, "app.SessionService.RevokeSession")
+	}
+
+	if err := s.repo.DeleteSession(ctx, tenantID, userID, sessionID); err != nil {
+		telemetry.RecordError(span, err)
+
+		return xerrors.Wrap(err, "app.SessionService.RevokeSession")
+	}
+
+	return nil
+}
+
+func (s *SessionService) RevokeSessions(ctx context.Context, userID uuid.UUID) error {
+	ctx, span := s.tracer.Start(ctx, "app.SessionService.RevokeSessions", trace.WithAttributes(attribute.String("user.id", userID.String())))
+	defer span.End()
+
+	tenantID, err := tenantFromContext(ctx, span)
+	if err != nil {
+		return xerrors.Wrap(err, "app.SessionService.RevokeSessions")
+	}
+
+	if err := s.repo.DeleteUserSessions(ctx, tenantID, userID); err != nil {
+		telemetry.RecordError(span, err)
+
+		return xerrors.Wrap(err, "app.SessionService.RevokeSessions")
+	}
+
+	return nil
+}
+
+// AuthenticateSession runs before the tenant is resolved, so it finds the
+// session by its token alone and binds the principal to the session's
+// tenant.
+func (s *SessionService) AuthenticateSession(ctx context.Context, token string) (domain.Principal, error) {
+	ctx, span := s.tracer.Start(ctx, "app.SessionService.AuthenticateSession")
+	defer span.End()
+
+	now := s.now()
+
+	session, err := s.repo.GetSessionByToken(ctx, hashToken(token), now)
+	if errors.Is(err, ports.ErrSessionNotFound) {
+		return domain.Principal{}, xerrors.Wrap(ports.ErrUnauthenticated, "invalid or expired session token")
+	}
+
+	if err != nil {
+		telemetry.RecordError(span, err)
+
+		return domain.Principal{}, xerrors.Wrap(err, "app.SessionService.AuthenticateSession")
+	}
+
+	span.SetAttributes(attribute.String("session.id", session.ID.String()))
+
+	if now.Sub(session.LastUsedAt) >= s.touchInterval {
+		err := s.repo.TouchSession(ctx, session.ID, now, now.Add(s.idleTimeout))
+		if errors.Is(err, ports.ErrSessionNotFound) {
+			return domain.Principal{}, xerrors.Wrap(ports.ErrUnauthenticated, "session was revoked")
+		}
+
+		if err != nil {
+			telemetry.RecordError(span, err)
+
+			return domain.Principal{}, xerrors.Wrap(err, "app.SessionService.AuthenticateSession")
+		}
+	}
+
+	return domain.Principal{
+		Subject: session.UserID.String(),
+		Method:  domain.AuthMethodSession,
+		Roles:   slices.Clone(s.roles),
+		Tenant:  session.TenantID,
+	}, nil
+}
+
+// sweep removes expired sessions every interval until ctx ends. A failed
+// pass is logged and retried at the next tick.
+func (s *SessionService) sweep(ctx context.Context, interval time.Duration, logger *slog.Logger) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+
+		deleted, err := s.repo.DeleteExpiredSessions(ctx, s.now())
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			logger.Warn("session sweep failed", slog.Any("error", err))
+
+			continue
+		}
+
+		if deleted > 0 {
+			logger.Debug("expired sessions removed", slog.Int("sessions", deleted))
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+
+	xerrors "github.com/go-faster/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/flexer2006/t-t-ogen-go/internal/adapters/telemetry"
+	"github.com/flexer2006/t-t-ogen-go/internal/config"
+	"github.com/flexer2006/t-t-ogen-go/internal/ports"
+)
+
+const tracingFilePerm = 0o600
+
+// observability holds the providers used by the server, service and
+// repository together with the exporters the application owns and must
+// flush on shutdown.
+type observability struct {
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+	metrics        *telemetry.Metrics
+	tracing        *telemetry.Tracing
+	tracingOutput  io.Closer
+}
+
+func newObservability(cfg config.Config, opts options, users ports.UserCounter) (*observability, error) {
+	obs := &observability{
+		tracerProvider: opts.tracerProvider,
+		meterProvider:  opts.meterProvider,
+	}
+
+	if cfg.Metrics.Enabled {
+		metrics, err := telemetry.NewMetrics()
+		if err != nil {
+			return nil, xerrors.Wrap(err, "app.newObservability: metrics")
+		}
+
+		obs.metrics = metrics
+
+		if obs.meterProvider == nil {
+			obs.meterProvider = metrics.MeterProvider()
+		}
+	}
+
+	if obs.meterProvider == nil {
+		obs.meterProvider = otel.GetMeterProvider()
+	}
+
+	if err := telemetry.RegisterUserGauge(obs.meterProvider, users); err != nil {
+		return nil, xerrors.Wrap(err, "app.newObservability: user gauge")
+	}
+
+	if obs.tracerProvider == nil && cfg.Tracing.Exporter != config.TracingExporterNone {
+		output := io.Writer(os.Stdout)
+
+		if cfg.Tracing.Exporter == config.TracingExporterFile {
+			file, err := os.OpenFile(cfg.Tracing.File, os.O_CREATE|os.O_WRONLY|os.O_APPEND, tracingFilePerm)
+			if err != nil {
+				return nil, xerrors.Wrap(err, "app.newObservability: tracing file")
+			}
+
+			output = file
+			obs.tracingOutput = file
+		}
+
+		tracing, err := telemetry.NewTracing(output, cfg.Tracing.SampleRatio)
+		if err != nil {
+			return nil, xerrors.Wrap(err, "app.newObservability: tracing")
+		}
+
+		obs.tracing = tracing
+		obs.tracerProvider = tracing.TracerProvider()
+	}
+
+	if obs.tracerProvider == nil {
+		obs.tracerProvider = otel.GetTracerProvider()
+	}
+
+	return obs, nil
+}
+
+func (o *observability) tracer() trace.Tracer {
+	return telemetry.Tracer(o.tracerProvider)
+}
+
+func (o *observability) shutdown(ctx context.Context) error {
+	var errs []error
+
+	if o.tracing != nil {
+		if err := o.tracing.Shutdown(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if o.tracingOutput != nil {
+		if err := o.tracingOutput.Close(); err != nil {
+			errs = append(errs, xerrors.Wrap(err, "close tracing file"))
+		}
+	}
+
+	if o.metrics != nil {
+		if err := o.metrics.Shutdown(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) > 0 {
+		return xerrors.Wrap(errors.Join(errs...), "app.observability.shutdown")
+	}
+
+	return nil
+}
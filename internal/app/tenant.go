@@ -0,0 +1,137 @@
+package app
+
+import (
+	"context"
+
+	xerrors "github.com/go-faster/errors"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/flexer2006/t-t-ogen-go/internal/adapters/telemetry"
+	"github.com/flexer2006/t-t-ogen-go/internal/domain"
+	"github.com/flexer2006/t-t-ogen-go/internal/ports"
+)
+
+var errInvalidTenantID = xerrors.New("invalid tenant id")
+
+// TenantService administers tenants. Principals bound to a tenant are
+// refused whatever their roles grant, so no tenant can see or remove another.
+type TenantService struct {
+	repo   ports.TenantRepository
+	tracer trace.Tracer
+}
+
+var _ ports.TenantService = (*TenantService)(nil)
+
+func newTenantService(repo ports.TenantRepository, tracer trace.Tracer) (*TenantService, error) {
+	if repo == nil {
+		return nil, xerrors.Wrap(errNilRepository, "app.newTenantService")
+	}
+
+	if tracer == nil {
+		return nil, xerrors.Wrap(errNilTracer, "app.newTenantService")
+	}
+
+	return &TenantService{repo: repo, tracer: tracer}, nil
+}
+
+func (s *TenantService) ListTenants(ctx context.Context) ([]domain.Tenant, error) {
+	ctx, span := s.tracer.Start(ctx, "app.TenantService.ListTenants")
+	defer span.End()
+
+	if err := unbound(ctx); err != nil {
+		telemetry.RecordError(span, err)
+
+		return nil, xerrors.Wrap(err, "app.TenantService.ListTenants")
+	}
+
+	tenants, err := s.repo.ListTenants(ctx)
+	if err != nil {
+		telemetry.RecordError(span, err)
+
+		return nil, xerrors.Wrap(err, "app.TenantService.ListTenants")
+	}
+
+	return tenants, nil
+}
+
+func (s *TenantService) CreateTenant(ctx context.Context, tenant domain.Tenant) (domain.Tenant, error) {
+	ctx, span := s.tracer.Start(ctx, "app.TenantService.CreateTenant", trace.WithAttributes(attribute.String("tenant.id", tenant.ID)))
+	defer span.End()
+
+	if err := unbound(ctx); err != nil {
+		telemetry.RecordError(span, err)
+
+		return domain.Tenant{}, xerrors.Wrap(err, "app.TenantService.CreateTenant")
+	}
+
+	if !domain.ValidTenantID(tenant.ID) {
+		telemetry.RecordError(span, errInvalidTenantID)
+
+		return domain.Tenant{}, xerrors.Wrapf(errInvalidTenantID, "app.TenantService.CreateTenant: %q", tenant.ID)
+	}
+
+	created, err := s.repo.CreateTenant(ctx, tenant)
+	if err != nil {
+		telemetry.RecordError(span, err)
+
+		return domain.Tenant{}, xerrors.Wrap(err, "app.TenantService.CreateTenant")
+	}
+
+	return created, nil
+}
+
+func (s *TenantService) GetTenant(ctx context.Context, id string) (domain.Tenant, error) {
+	ctx, span := s.tracer.Start(ctx, "app.TenantService.GetTenant", trace.WithAttributes(attribute.String("tenant.id", id)))
+	defer span.End()
+
+	if err := unbound(ctx); err != nil {
+		telemetry.RecordError(span, err)
+
+		return domain.Tenant{}, xerrors.Wrap(err, "app.TenantService.GetTenant")
+	}
+
+	tenant, err := s.repo.GetTenant(ctx, id)
+	if err != nil {
+		telemetry.RecordError(span, err)
+
+		return domain.Tenant{}, xerrors.Wrap(err, "app.TenantService.GetTenant")
+	}
+
+	return tenant, nil
+}
+
+func (s *TenantService) DeleteTenant(ctx context.Context, id string) error {
+	ctx, span := s.tracer.Start(ctx, "app.TenantService.DeleteTenant", trace.WithAttributes(attribute.String("tenant.id", id)))
+	defer span.End()
+
+	if err := unbound(ctx); err != nil {
+		telemetry.RecordError(span, err)
+
+		return xerrors.Wrap(err, "app.TenantService.DeleteTenant")
+	}
+
+	// Tenancy can be switched off again, and then every request lands in the
+	// default tenant.
+	if id == domain.DefaultTenant {
+		telemetry.RecordError(span, ports.ErrTenantProtected)
+
+		return xerrors.Wrapf(ports.ErrTenantProtected, "app.TenantService.DeleteTenant: %q", id)
+	}
+
+	if err := s.repo.DeleteTenant(ctx, id); err != nil {
+		telemetry.RecordError(span, err)
+
+		return xerrors.Wrap(err, "app.TenantService.DeleteTenant")
+	}
+
+	return nil
+}
+
+func unbound(ctx context.Context) error {
+	if principal, ok := domain.PrincipalFromContext(ctx); ok && principal.Tenant != "" {
+		return xerrors.Wrapf(ports.ErrPermissionDenied, "principal is bound to tenant %q", principal.Tenant)
+	}
+
+	return nil
+}
@@ -0,0 +1,174 @@
+package app_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/flexer2006/t-t-ogen-go/internal/adapters/auth"
+	"github.com/flexer2006/t-t-ogen-go/internal/app"
+	"github.com/flexer2006/t-t-ogen-go/internal/config"
+)
+
+type tenantClient struct {
+	t       *testing.T
+	handler http.Handler
+	key     string
+}
+
+// do sends a request to the v2 API as the client, naming tenant in the
+// X-Tenant-ID header unless it is empty.
+func (c tenantClient) do(method, path, tenant, body string) (int, []byte) {
+	c.t.Helper()
+
+	req := httptest.NewRequest(method, "/v2"+path, strings.NewReader(body))
+	req.Header.Set("X-API-Key", c.key)
+
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	if tenant != "" {
+		req.Header.Set("X-Tenant-ID", tenant)
+	}
+
+	rec := httptest.NewRecorder()
+	c.handler.ServeHTTP(rec, req)
+
+	return rec.Code, rec.Body.Bytes()
+}
+
+func (c tenantClient) createUser(tenant, username string) string {
+	c.t.Helper()
+
+	status, body := c.do(http.MethodPost, "/users", tenant, `{"display_name":"`+username+`","username":"`+username+`"}`)
+	if status != http.StatusCreated {
+		c.t.Fatalf("create user %s: status %d: %s", username, status, body)
+	}
+
+	var user struct {
+		ID string `json:"id"`
+	}
+
+	if err := json.Unmarshal(body, &user); err != nil {
+		c.t.Fatalf("decode user: %v", err)
+	}
+
+	return user.ID
+}
+
+func TestTenantIsolation(t *testing.T) {
+	adminKey, acmeKey := newKey(t), newKey(t)
+
+	cfg := config.Default()
+	cfg.Tenancy.Enabled = true
+	cfg.Auth.APIKeys = []config.APIKeyConfig{
+		{Label: "admin", Hash: auth.HashAPIKey(adminKey), Roles: []string{"admin"}},
+		{Label: "acme", Hash: auth.HashAPIKey(acmeKey), Roles: []string{"admin"}, Tenant: "acme"},
+	}
+	cfg.Authz.PolicyFile = writePolicy(t)
+	cfg.Log.Level = "error"
+	cfg.Metrics.Enabled = false
+
+	application, err := app.NewApplication(cfg)
+	if err != nil {
+		t.Fatalf("NewApplication: %v", err)
+	}
+
+	admin := tenantClient{t: t, handler: application.Handler(), key: adminKey}
+	acme := tenantClient{t: t, handler: application.Handler(), key: acmeKey}
+
+	for _, tenant := range []string{"acme", "globex"} {
+		if status, body := admin.do(http.MethodPost, "/tenants", "", `{"id":"`+tenant+`","name":"`+tenant+`"}`); status != http.StatusCreated {
+			t.Fatalf("create tenant %s: status %d: %s", tenant, status, body)
+		}
+	}
+
+	acmeUser := acme.createUser("", "wile")
+	globexUser := admin.createUser("globex", "hank")
+
+	tests := []struct {
+		name   string
+		client tenantClient
+		method string
+		path   string
+		tenant string
+		body   string
+		want   int
+	}{
+		{name: "bound principal gets own user", client: acme, method: http.MethodGet, path: "/users/" + acmeUser, want: http.StatusOK},
+		{name: "bound principal names own tenant", client: acme, method: http.MethodGet, path: "/users/" + acmeUser, tenant: "acme", want: http.StatusOK},
+		{name: "bound principal names another tenant", client: acme, method: http.MethodGet, path: "/users/" + acmeUser, tenant: "globex", want: http.StatusForbidden},
+		{name: "cross-tenant get", client: acme, method: http.MethodGet, path: "/users/" + globexUser, want: http.StatusNotFound},
+		{
+			name:   "cross-tenant update",
+			client: acme,
+			method: http.MethodPut,
+			path:   "/users/" + globexUser,
+			body:   `{"display_name":"taken over"}`,
+			want:   http.StatusNotFound,
+		},
+		{name: "cross-tenant delete", client: acme, method: http.MethodDelete, path: "/users/" + globexUser, want: http.StatusNotFound},
+		{name: "bound principal administers tenants", client: acme, method: http.MethodGet, path: "/tenants", want: http.StatusForbidden},
+		{name: "unbound principal without tenant", client: admin, method: http.MethodGet, path: "/users", want: http.StatusBadRequest},
+		{name: "unbound principal names unknown tenant", client: admin, method: http.MethodGet, path: "/users", tenant: "initech", want: http.StatusBadRequest},
+		{name: "unbound principal names tenant", client: admin, method: http.MethodGet, path: "/users/" + globexUser, tenant: "globex", want: http.StatusOK},
+		{name: "unbound principal in the wrong tenant", client: admin, method: http.MethodGet, path: "/users/" + globexUser, tenant: "acme", want: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.client.t = t
+
+			if status, body := tt.client.do(tt.method, tt.path, tt.tenant, tt.body); status != tt.want {
+				t.Errorf("%s %s: status %d, want %d: %s", tt.method, tt.path, status, tt.want, body)
+			}
+		})
+	}
+
+	t.Run("cross-tenant list", func(t *testing.T) {
+		for _, client := range []struct {
+			tenantClient
+
+			tenant string
+			want   string
+		}{
+			{tenantClient: acme, want: acmeUser},
+			{tenantClient: admin, tenant: "globex", want: globexUser},
+		} {
+			client.t = t
+
+			status, body := client.do(http.MethodGet, "/users", client.tenant, "")
+			if status != http.StatusOK {
+				t.Fatalf("list users: status %d: %s", status, body)
+			}
+
+			var users []struct {
+				ID string `json:"id"`
+			}
+
+			if err := json.Unmarshal(body, &users); err != nil {
+				t.Fatalf("decode users: %v", err)
+			}
+
+			if len(users) != 1 || users[0].ID != client.want {
+				t.Errorf("users = %+v, want only %s", users, client.want)
+			}
+		}
+	})
+
+	t.Run("cross-tenant writes left the user alone", func(t *testing.T) {
+		admin.t = t
+
+		status, body := admin.do(http.MethodGet, "/users/"+globexUser, "globex", "")
+		if status != http.StatusOK {
+			t.Fatalf("get user: status %d: %s", status, body)
+		}
+
+		if !strings.Contains(string(body), `"display_name":"hank"`) {
+			t.Errorf("user = %s, want display_name hank", body)
+		}
+	})
+}
@@ -0,0 +1,131 @@
+package app
+
+import (
+	"net/http"
+	"time"
+
+	xerrors "github.com/go-faster/errors"
+
+	api "github.com/flexer2006/t-t-ogen-go/generated"
+	apiv1 "github.com/flexer2006/t-t-ogen-go/generated/v1"
+	"github.com/flexer2006/t-t-ogen-go/internal/adapters/auth"
+	clientadapter "github.com/flexer2006/t-t-ogen-go/internal/adapters/client"
+	serveradapter "github.com/flexer2006/t-t-ogen-go/internal/adapters/server"
+	"github.com/flexer2006/t-t-ogen-go/internal/adapters/telemetry"
+	"github.com/flexer2006/t-t-ogen-go/internal/config"
+	"github.com/flexer2006/t-t-ogen-go/openapi"
+)
+
+const (
+	apiV1Prefix = "/v1"
+	apiV2Prefix = "/v2"
+)
+
+// apiV1Deprecated is when v2 superseded v1.
+var apiV1Deprecated = time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC)
+
+// apiDocument is an OpenAPI document and the prefix its version is served
+// under.
+type apiDocument struct {
+	prefix string
+	spec   []byte
+}
+
+var apiDocuments = []apiDocument{
+	{prefix: apiV1Prefix, spec: openapi.SpecV1},
+	{prefix: apiV2Prefix, spec: openapi.Spec},
+}
+
+// newAPIVersions mounts v2 and the deprecated v1 side by side, both serving
+// the same services through the same middlewares.
+func newAPIVersions(
+	cfg config.APIConfig,
+	handler *serveradapter.UserHandler,
+	handlerV1 *serveradapter.UserHandlerV1,
+	security *auth.SecurityHandler,
+	middlewares []api.Middleware,
+	obs *observability,
+) (*serveradapter.Versions, error) {
+	sunset, err := cfg.V1SunsetTime()
+	if err != nil {
+		return nil, xerrors.Wrap(err, "app.newAPIVersions: v1 sunset")
+	}
+
+	v2, err := api.NewServer(handler, security,
+		api.WithPathPrefix(apiV2Prefix),
+		api.WithErrorHandler(serveradapter.ErrorHandler),
+		api.WithMiddleware(middlewares...),
+		api.WithTracerProvider(obs.tracerProvider),
+		api.WithMeterProvider(obs.meterProvider),
+	)
+	if err != nil {
+		return nil, xerrors.Wrap(err, "app.newAPIVersions: v2")
+	}
+
+	versions := []serveradapter.APIVersion{{
+		Prefix:  apiV2Prefix,
+		Handler: v2,
+		Routes: func(method, path string) (string, bool) {
+			route, ok := v2.FindRoute(method, path)
+			return route.Name(), ok
+		},
+	}}
+
+	prefixes := []string{apiV1Prefix}
+	if cfg.LegacyRoot {
+		prefixes = append(prefixes, "")
+	}
+
+	deprecation := &serveradapter.Deprecation{Since: apiV1Deprecated, Sunset: sunset, Successor: apiV2Prefix}
+
+	for _, prefix := range prefixes {
+		v1, err := apiv1.NewServer(handlerV1, security.V1(),
+			apiv1.WithPathPrefix(prefix),
+			apiv1.WithErrorHandler(serveradapter.ErrorHandler),
+			apiv1.WithMiddleware(middlewares...),
+			apiv1.WithTracerProvider(obs.tracerProvider),
+			apiv1.WithMeterProvider(obs.meterProvider),
+		)
+		if err != nil {
+			return nil, xerrors.Wrapf(err, "app.newAPIVersions: v1 at %q", prefix)
+		}
+
+		versions = append(versions, serveradapter.APIVersion{
+			Prefix:  prefix,
+			Handler: v1,
+			Routes: func(method, path string) (string, bool) {
+				route, ok := v1.FindRoute(method, path)
+				return route.Name(), ok
+			},
+			Deprecation: deprecation,
+		})
+	}
+
+	return serveradapter.NewVersions(versions...), nil
+}
+
+func newClientV1(serverURL string, httpClient *http.Client, options *clientOptions) (*clientadapter.Client, error) {
+	invokerOptions := []apiv1.ClientOption{
+		apiv1.WithClient(telemetry.NewPropagatingClient(clientadapter.NewDecompressingClient(httpClient))),
+	}
+
+	if options.tracerProvider != nil {
+		invokerOptions = append(invokerOptions, apiv1.WithTracerProvider(options.tracerProvider))
+	}
+
+	if options.meterProvider != nil {
+		invokerOptions = append(invokerOptions, apiv1.WithMeterProvider(options.meterProvider))
+	}
+
+	invoker, err := apiv1.NewClient(serverURL, clientadapter.CredentialsV1{Credentials: options.credentials}, invokerOptions...)
+	if err != nil {
+		return nil, xerrors.Wrap(err, "app.NewClient: v1 invoker")
+	}
+
+	client, err := clientadapter.NewV1(invoker, options.tenant)
+	if err != nil {
+		return nil, xerrors.Wrap(err, "app.NewClient: v1 adapter")
+	}
+
+	return client, nil
+}
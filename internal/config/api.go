@@ -0,0 +1,42 @@
+package config
+
+import (
+	"time"
+
+	xerrors "github.com/go-faster/errors"
+)
+
+// SunsetLayout is the date format of api.v1_sunset.
+const SunsetLayout = time.DateOnly
+
+// APIConfig controls the deprecated v1 API, which is served next to v2.
+type APIConfig struct {
+	// V1Sunset is the date announced in the Sunset header of v1 responses;
+	// empty announces none.
+	V1Sunset string `json:"v1_sunset,omitempty"`
+	// LegacyRoot keeps serving v1 without a prefix for consumers predating
+	// versioned paths.
+	LegacyRoot bool `json:"legacy_root"`
+}
+
+// V1SunsetTime parses V1Sunset; the zero time means no sunset is announced.
+func (a APIConfig) V1SunsetTime() (time.Time, error) {
+	if a.V1Sunset == "" {
+		return time.Time{}, nil
+	}
+
+	sunset, err := time.Parse(SunsetLayout, a.V1Sunset)
+	if err != nil {
+		return time.Time{}, xerrors.Errorf("invalid date %q, want YYYY-MM-DD", a.V1Sunset)
+	}
+
+	return sunset, nil
+}
+
+func (a APIConfig) validate() []error {
+	if _, err := a.V1SunsetTime(); err != nil {
+		return []error{xerrors.Errorf("api.v1_sunset: %v", err)}
+	}
+
+	return nil
+}
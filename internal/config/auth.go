@@ -0,0 +1,97 @@
+package config
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	xerrors "github.com/go-faster/errors"
+
+	"github.com/flexer2006/t-t-ogen-go/internal/domain"
+)
+
+type AuthConfig struct {
+	APIKeysFile string         `json:"api_keys_file,omitempty"`
+	APIKeys     []APIKeyConfig `json:"api_keys,omitempty"`
+	JWT         JWTConfig      `json:"jwt"`
+}
+
+type JWTConfig struct {
+	JWKSFile  string   `json:"jwks_file,omitempty"`
+	Issuer    string   `json:"issuer,omitempty"`
+	Audience  string   `json:"audience,omitempty"`
+	ClockSkew Duration `json:"clock_skew"`
+}
+
+type APIKeyConfig struct {
+	Label     string     `json:"label"`
+	Hash      string     `json:"hash"`
+	Roles     []string   `json:"roles,omitempty"`
+	Tenant    string     `json:"tenant,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+type apiKeysFile struct {
+	APIKeys []APIKeyConfig `json:"api_keys"`
+}
+
+func ReadAPIKeysFile(path string) ([]APIKeyConfig, error) {
+	var file apiKeysFile
+	if err := decodeFile(path, &file); err != nil {
+		return nil, xerrors.Wrap(err, "config.ReadAPIKeysFile")
+	}
+
+	if problems := validateAPIKeys("api_keys", file.APIKeys); len(problems) > 0 {
+		return nil, fmt.Errorf("config.ReadAPIKeysFile: %s: %w:\n%w", path, ErrInvalidConfig, errors.Join(problems...))
+	}
+
+	return file.APIKeys, nil
+}
+
+// validate takes whether client certificates are verified, since they
+// authenticate requests on their own.
+func (a AuthConfig) validate(clientCertificates bool) []error {
+	var problems []error
+
+	if a.APIKeysFile == "" && len(a.APIKeys) == 0 && a.JWT.JWKSFile == "" && !clientCertificates {
+		problems = append(problems, xerrors.New(
+			"auth: no authentication configured (set auth.api_keys, auth.api_keys_file, auth.jwt.jwks_file or server.tls.client_ca_file)"))
+	}
+
+	if a.JWT.ClockSkew < 0 {
+		problems = append(problems, xerrors.Errorf("auth.jwt.clock_skew: must not be negative, got %s", a.JWT.ClockSkew))
+	}
+
+	return append(problems, validateAPIKeys("auth.api_keys", a.APIKeys)...)
+}
+
+func validateAPIKeys(field string, keys []APIKeyConfig) []error {
+	var problems []error
+
+	labels := make(map[string]struct{}, len(keys))
+
+	for i, key := range keys {
+		entry := fmt.Sprintf("%s[%d]", field, i)
+
+		if strings.TrimSpace(key.Label) == "" {
+			problems = append(problems, xerrors.Errorf("%s.label: must not be empty", entry))
+		} else if _, ok := labels[key.Label]; ok {
+			problems = append(problems, xerrors.Errorf("%s.label: duplicate label %q", entry, key.Label))
+		}
+
+		labels[key.Label] = struct{}{}
+
+		digest, ok := strings.CutPrefix(key.Hash, domain.APIKeyHashPrefix)
+		if decoded, err := hex.DecodeString(digest); !ok || err != nil || len(decoded) != 32 {
+			problems = append(problems, xerrors.Errorf("%s.hash: must be %q followed by 64 hex digits", entry, domain.APIKeyHashPrefix))
+		}
+
+		if key.Tenant != "" && !domain.ValidTenantID(key.Tenant) {
+			problems = append(problems, xerrors.Errorf("%s.tenant: invalid tenant id %q", entry, key.Tenant))
+		}
+	}
+
+	return problems
+}
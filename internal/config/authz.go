@@ -0,0 +1,66 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	xerrors "github.com/go-faster/errors"
+)
+
+// AuthzConfig names the role policy that every authenticated request is
+// checked against. It is required: without it any principal could call any
+// operation.
+type AuthzConfig struct {
+	PolicyFile string `json:"policy_file,omitempty"`
+}
+
+func (a AuthzConfig) validate() []error {
+	if strings.TrimSpace(a.PolicyFile) == "" {
+		return []error{xerrors.New("authz.policy_file: is required; requests are authorized against its roles")}
+	}
+
+	return nil
+}
+
+type PolicyConfig struct {
+	Roles map[string]RoleConfig `json:"roles"`
+}
+
+type RoleConfig struct {
+	Allow     []string `json:"allow,omitempty"`
+	AllowSelf []string `json:"allow_self,omitempty"`
+}
+
+func ReadPolicyFile(path string) (PolicyConfig, error) {
+	var policy PolicyConfig
+	if err := decodeFile(path, &policy); err != nil {
+		return PolicyConfig{}, xerrors.Wrap(err, "config.ReadPolicyFile")
+	}
+
+	if problems := policy.validate(); len(problems) > 0 {
+		return PolicyConfig{}, fmt.Errorf("config.ReadPolicyFile: %s: %w:\n%w", path, ErrInvalidConfig, errors.Join(problems...))
+	}
+
+	return policy, nil
+}
+
+func (p PolicyConfig) validate() []error {
+	var problems []error
+
+	if len(p.Roles) == 0 {
+		problems = append(problems, xerrors.New("roles: must define at least one role"))
+	}
+
+	for name, role := range p.Roles {
+		if strings.TrimSpace(name) == "" {
+			problems = append(problems, xerrors.New("roles: role name must not be empty"))
+		}
+
+		if len(role.Allow) == 0 && len(role.AllowSelf) == 0 {
+			problems = append(problems, xerrors.Errorf("roles.%s: grants no operations", name))
+		}
+	}
+
+	return problems
+}
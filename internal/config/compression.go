@@ -0,0 +1,36 @@
+package config
+
+import (
+	"compress/gzip"
+
+	xerrors "github.com/go-faster/errors"
+)
+
+type CompressionConfig struct {
+	Enabled              bool `json:"enabled"`
+	MinSize              int  `json:"min_size"`
+	Level                int  `json:"level"`
+	MaxDecompressedBytes int  `json:"max_decompressed_bytes"`
+}
+
+func (c CompressionConfig) validate() []error {
+	if !c.Enabled {
+		return nil
+	}
+
+	var problems []error
+
+	if c.MinSize < 0 {
+		problems = append(problems, xerrors.Errorf("compression.min_size: must not be negative, got %d", c.MinSize))
+	}
+
+	if c.Level < gzip.HuffmanOnly || c.Level > gzip.BestCompression {
+		problems = append(problems, xerrors.Errorf("compression.level: must be between %d and %d, got %d", gzip.HuffmanOnly, gzip.BestCompression, c.Level))
+	}
+
+	if c.MaxDecompressedBytes <= 0 {
+		problems = append(problems, xerrors.Errorf("compression.max_decompressed_bytes: must be positive, got %d", c.MaxDecompressedBytes))
+	}
+
+	return problems
+}
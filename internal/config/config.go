@@ -0,0 +1,288 @@
+package config
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ghodss/yaml"
+	xerrors "github.com/go-faster/errors"
+)
+
+const (
+	StorageMemory = "memory"
+	StorageFile   = "file"
+
+	// UnixAddressPrefix marks server.address as a Unix socket path.
+	UnixAddressPrefix = "unix:"
+)
+
+var ErrInvalidConfig = xerrors.New("invalid configuration")
+
+type Config struct {
+	Server      ServerConfig      `json:"server"`
+	Storage     StorageConfig     `json:"storage"`
+	Auth        AuthConfig        `json:"auth"`
+	Authz       AuthzConfig       `json:"authz"`
+	RateLimit   RateLimitConfig   `json:"rate_limit"`
+	Log         LogConfig         `json:"log"`
+	Metrics     MetricsConfig     `json:"metrics"`
+	Tracing     TracingConfig     `json:"tracing"`
+	Health      HealthConfig      `json:"health"`
+	Tenancy     TenancyConfig     `json:"tenancy"`
+	Compression CompressionConfig `json:"compression"`
+	CORS        CORSConfig        `json:"cors"`
+	Docs        DocsConfig        `json:"docs"`
+	API         APIConfig         `json:"api"`
+	Email       EmailConfig       `json:"email"`
+	Password    PasswordConfig    `json:"password"`
+	Session     SessionConfig     `json:"session"`
+}
+
+type ServerConfig struct {
+	Address           string   `json:"address"`
+	ReadTimeout       Duration `json:"read_timeout"`
+	WriteTimeout      Duration `json:"write_timeout"`
+	IdleTimeout       Duration `json:"idle_timeout"`
+	ReadHeaderTimeout Duration `json:"read_header_timeout"`
+	ShutdownTimeout   Duration `json:"shutdown_timeout"`
+	DrainDelay        Duration `json:"drain_delay"`
+	MaxHeaderBytes    int      `json:"max_header_bytes"`
+	// SocketMode holds octal permissions applied to a Unix socket address.
+	SocketMode string    `json:"socket_mode,omitempty"`
+	TLS        TLSConfig `json:"tls"`
+}
+
+// UnixSocketMode parses SocketMode; zero keeps the permissions from the umask.
+func (s ServerConfig) UnixSocketMode() (os.FileMode, error) {
+	if s.SocketMode == "" {
+		return 0, nil
+	}
+
+	mode, err := strconv.ParseUint(s.SocketMode, 8, 32)
+	if err != nil || mode > 0o777 {
+		return 0, xerrors.Errorf("invalid octal permissions %q", s.SocketMode)
+	}
+
+	return os.FileMode(mode), nil
+}
+
+type HealthConfig struct {
+	CheckTimeout Duration `json:"check_timeout"`
+}
+
+// TenancyConfig enables tenant isolation. While disabled every request is
+// served from the default tenant.
+type TenancyConfig struct {
+	Enabled bool `json:"enabled"`
+}
+
+// DocsConfig serves the OpenAPI document and the API explorer without
+// authentication.
+type DocsConfig struct {
+	Enabled bool `json:"enabled"`
+}
+
+type StorageConfig struct {
+	Backend string `json:"backend"`
+	Path    string `json:"path,omitempty"`
+}
+
+func Default() Config {
+	return Config{
+		Server: ServerConfig{
+			Address:           ":42873",
+			ReadTimeout:       Duration(15 * time.Second),
+			WriteTimeout:      Duration(15 * time.Second),
+			IdleTimeout:       Duration(60 * time.Second),
+			ReadHeaderTimeout: Duration(5 * time.Second),
+			ShutdownTimeout:   Duration(5 * time.Second),
+			MaxHeaderBytes:    http.DefaultMaxHeaderBytes,
+		},
+		Storage: StorageConfig{
+			Backend: StorageMemory,
+		},
+		Auth: AuthConfig{
+			JWT: JWTConfig{
+				ClockSkew: Duration(30 * time.Second),
+			},
+		},
+		RateLimit: RateLimitConfig{
+			Rate:       10,
+			Burst:      20,
+			MaxClients: 10000,
+		},
+		Log: LogConfig{
+			Level:             "info",
+			Format:            LogFormatJSON,
+			SuccessSampleRate: 1,
+		},
+		Metrics: MetricsConfig{
+			Enabled: true,
+			Path:    "/metrics",
+		},
+		Tracing: TracingConfig{
+			Exporter:    TracingExporterNone,
+			SampleRatio: 1,
+		},
+		Health: HealthConfig{
+			CheckTimeout: Duration(2 * time.Second),
+		},
+		Compression: CompressionConfig{
+			Enabled:              true,
+			MinSize:              1024,
+			Level:                gzip.DefaultCompression,
+			MaxDecompressedBytes: 10 << 20,
+		},
+		Docs: DocsConfig{
+			Enabled: true,
+		},
+		API: APIConfig{
+			V1Sunset:   "2027-04-19",
+			LegacyRoot: true,
+		},
+		Email: EmailConfig{
+			Notifier:        NotifierMemory,
+			VerificationTTL: Duration(24 * time.Hour),
+		},
+		Password: PasswordConfig{
+			MinLength:           12,
+			Iterations:          600000,
+			MaxConcurrentHashes: max(1, runtime.NumCPU()/2),
+			MaxFailures:         5,
+			Lockout:             Duration(15 * time.Minute),
+		},
+		Session: SessionConfig{
+			IdleTimeout:   Duration(30 * time.Minute),
+			MaxLifetime:   Duration(12 * time.Hour),
+			SweepInterval: Duration(time.Minute),
+		},
+		CORS: CORSConfig{
+			AllowedMethods: []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete},
+			AllowedHeaders: []string{"Authorization", "Content-Type", "Content-Encoding", "X-API-Key", "X-Request-ID", "X-Session-Token", "X-Tenant-ID"},
+			ExposedHeaders: []string{"X-Request-ID", "Retry-After", "RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset"},
+			MaxAge:         Duration(10 * time.Minute),
+		},
+	}
+}
+
+func (c Config) Validate() error {
+	var problems []error
+
+	invalid := func(field, format string, args ...any) {
+		problems = append(problems, xerrors.Errorf("%s: %s", field, fmt.Sprintf(format, args...)))
+	}
+
+	if strings.TrimSpace(c.Server.Address) == "" || c.Server.Address == UnixAddressPrefix {
+		invalid("server.address", "must not be empty")
+	}
+
+	if _, err := c.Server.UnixSocketMode(); err != nil {
+		invalid("server.socket_mode", "%v", err)
+	}
+
+	for _, timeout := range []struct {
+		field string
+		value Duration
+	}{
+		{"server.read_timeout", c.Server.ReadTimeout},
+		{"server.write_timeout", c.Server.WriteTimeout},
+		{"server.idle_timeout", c.Server.IdleTimeout},
+		{"server.read_header_timeout", c.Server.ReadHeaderTimeout},
+		{"server.shutdown_timeout", c.Server.ShutdownTimeout},
+	} {
+		if timeout.value <= 0 {
+			invalid(timeout.field, "must be positive, got %s", timeout.value)
+		}
+	}
+
+	if c.Server.DrainDelay < 0 {
+		invalid("server.drain_delay", "must not be negative, got %s", c.Server.DrainDelay)
+	}
+
+	if c.Health.CheckTimeout <= 0 {
+		invalid("health.check_timeout", "must be positive, got %s", c.Health.CheckTimeout)
+	}
+
+	if c.Server.MaxHeaderBytes <= 0 {
+		invalid("server.max_header_bytes", "must be positive, got %d", c.Server.MaxHeaderBytes)
+	}
+
+	switch c.Storage.Backend {
+	case StorageMemory:
+		if c.Storage.Path != "" {
+			invalid("storage.path", "is not used by the %q backend", StorageMemory)
+		}
+	case StorageFile:
+		if strings.TrimSpace(c.Storage.Path) == "" {
+			invalid("storage.path", "is required by the %q backend", StorageFile)
+		}
+	default:
+		invalid("storage.backend", "unknown backend %q (want %q or %q)", c.Storage.Backend, StorageMemory, StorageFile)
+	}
+
+	problems = append(problems, c.Server.TLS.validate()...)
+	problems = append(problems, c.Auth.validate(c.Server.TLS.ClientCAFile != "")...)
+	problems = append(problems, c.Authz.validate()...)
+	problems = append(problems, c.RateLimit.validate()...)
+	problems = append(problems, c.Compression.validate()...)
+	problems = append(problems, c.CORS.validate()...)
+	problems = append(problems, c.API.validate()...)
+	problems = append(problems, c.Email.validate()...)
+	problems = append(problems, c.Password.validate()...)
+	problems = append(problems, c.Session.validate()...)
+	problems = append(problems, c.Log.validate()...)
+	problems = append(problems, c.Metrics.validate()...)
+	problems = append(problems, c.Tracing.validate()...)
+
+	if len(problems) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("%w:\n%w", ErrInvalidConfig, errors.Join(problems...))
+}
+
+func (c Config) Write(w io.Writer) error {
+	out, err := yaml.Marshal(c)
+	if err != nil {
+		return xerrors.Wrap(err, "config.Config.Write: marshal")
+	}
+
+	if _, err := w.Write(out); err != nil {
+		return xerrors.Wrap(err, "config.Config.Write")
+	}
+
+	return nil
+}
+
+type Duration time.Duration
+
+func (d Duration) String() string {
+	return time.Duration(d).String()
+}
+
+func (d *Duration) Set(value string) error {
+	parsed, err := time.ParseDuration(strings.TrimSpace(value))
+	if err != nil {
+		return xerrors.Errorf("invalid duration %q", value)
+	}
+
+	*d = Duration(parsed)
+
+	return nil
+}
+
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+func (d *Duration) UnmarshalText(text []byte) error {
+	return d.Set(string(text))
+}
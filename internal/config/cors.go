@@ -0,0 +1,55 @@
+package config
+
+import (
+	"net/http"
+	"path"
+	"slices"
+	"strings"
+
+	xerrors "github.com/go-faster/errors"
+)
+
+type CORSConfig struct {
+	Enabled          bool     `json:"enabled"`
+	AllowedOrigins   []string `json:"allowed_origins"`
+	AllowedMethods   []string `json:"allowed_methods"`
+	AllowedHeaders   []string `json:"allowed_headers"`
+	ExposedHeaders   []string `json:"exposed_headers"`
+	AllowCredentials bool     `json:"allow_credentials"`
+	MaxAge           Duration `json:"max_age"`
+}
+
+func (c CORSConfig) validate() []error {
+	if !c.Enabled {
+		return nil
+	}
+
+	var problems []error
+
+	if len(c.AllowedOrigins) == 0 {
+		problems = append(problems, xerrors.New("cors.allowed_origins: must not be empty"))
+	}
+
+	for i, origin := range c.AllowedOrigins {
+		if _, err := path.Match(origin, ""); err != nil || strings.TrimSpace(origin) == "" {
+			problems = append(problems, xerrors.Errorf("cors.allowed_origins[%d]: invalid pattern %q", i, origin))
+		}
+	}
+
+	// Any site could then act with the user's credentials.
+	if c.AllowCredentials && slices.Contains(c.AllowedOrigins, "*") {
+		problems = append(problems, xerrors.New(`cors.allowed_origins: "*" cannot be combined with allow_credentials`))
+	}
+
+	for i, method := range c.AllowedMethods {
+		if method == "" || method != strings.ToUpper(method) || method == http.MethodOptions {
+			problems = append(problems, xerrors.Errorf("cors.allowed_methods[%d]: invalid method %q", i, method))
+		}
+	}
+
+	if c.MaxAge < 0 {
+		problems = append(problems, xerrors.Errorf("cors.max_age: must not be negative, got %s", c.MaxAge))
+	}
+
+	return problems
+}
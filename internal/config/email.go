@@ -0,0 +1,43 @@
+package config
+
+import (
+	"strings"
+
+	xerrors "github.com/go-faster/errors"
+)
+
+const (
+	NotifierMemory = "memory"
+	NotifierFile   = "file"
+)
+
+// EmailConfig controls how email addresses are verified. Neither notifier
+// delivers mail: memory keeps messages in the process and file appends them
+// to NotifierFile, for development and tests. Embedding applications supply
+// a real one with app.WithNotifier.
+type EmailConfig struct {
+	Notifier        string   `json:"notifier"`
+	NotifierFile    string   `json:"notifier_file,omitempty"`
+	VerificationTTL Duration `json:"verification_ttl"`
+}
+
+func (e EmailConfig) validate() []error {
+	var problems []error
+
+	switch e.Notifier {
+	case NotifierMemory:
+	case NotifierFile:
+		if strings.TrimSpace(e.NotifierFile) == "" {
+			problems = append(problems, xerrors.Errorf("email.notifier_file: is required by the %q notifier", NotifierFile))
+		}
+	default:
+		problems = append(problems, xerrors.Errorf("email.notifier: unknown notifier %q (want %q or %q)",
+			e.Notifier, NotifierMemory, NotifierFile))
+	}
+
+	if e.VerificationTTL <= 0 {
+		problems = append(problems, xerrors.Errorf("email.verification_ttl: must be positive, got %s", e.VerificationTTL))
+	}
+
+	return problems
+}
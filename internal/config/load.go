@@ -0,0 +1,320 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	xerrors "github.com/go-faster/errors"
+)
+
+const (
+	EnvPrefix     = "USER_SERVICE_"
+	EnvConfigFile = EnvPrefix + "CONFIG"
+)
+
+type LookupEnvFunc func(key string) (string, bool)
+
+type Result struct {
+	Config Config
+	File   string
+	// PrintConfig reports --print-config. Config is then left unvalidated.
+	PrintConfig bool
+}
+
+type setting struct {
+	flag  string
+	usage string
+	value func(*Config) flag.Value
+}
+
+func (s setting) env() string {
+	return EnvPrefix + strings.ToUpper(strings.ReplaceAll(s.flag, "-", "_"))
+}
+
+func settings() []setting {
+	return []setting{
+		{"address", "listen address: host:port, unix:/path or systemd[:name]", func(c *Config) flag.Value { return (*stringValue)(&c.Server.Address) }},
+		{"read-timeout", "maximum duration for reading an entire request", func(c *Config) flag.Value { return &c.Server.ReadTimeout }},
+		{"write-timeout", "maximum duration before timing out response writes", func(c *Config) flag.Value { return &c.Server.WriteTimeout }},
+		{"idle-timeout", "maximum keep-alive idle time", func(c *Config) flag.Value { return &c.Server.IdleTimeout }},
+		{"read-header-timeout", "maximum duration for reading request headers", func(c *Config) flag.Value { return &c.Server.ReadHeaderTimeout }},
+		{"shutdown-timeout", "maximum duration of graceful shutdown", func(c *Config) flag.Value { return &c.Server.ShutdownTimeout }},
+		{"drain-delay", "time between failing readiness and closing the listener on shutdown", func(c *Config) flag.Value { return &c.Server.DrainDelay }},
+		{"socket-mode", "octal permissions of a Unix socket", func(c *Config) flag.Value { return (*stringValue)(&c.Server.SocketMode) }},
+		{"max-header-bytes", "maximum size of request headers", func(c *Config) flag.Value { return (*intValue)(&c.Server.MaxHeaderBytes) }},
+		{"tls-cert-file", "PEM certificate chain served over TLS", func(c *Config) flag.Value { return (*stringValue)(&c.Server.TLS.CertFile) }},
+		{"tls-key-file", "PEM private key of the TLS certificate", func(c *Config) flag.Value { return (*stringValue)(&c.Server.TLS.KeyFile) }},
+		{"tls-client-ca-file", "PEM CA bundle for verifying client certificates", func(c *Config) flag.Value { return (*stringValue)(&c.Server.TLS.ClientCAFile) }},
+		{"tls-client-auth", "client certificate mode: require or optional", func(c *Config) flag.Value { return (*stringValue)(&c.Server.TLS.ClientAuth) }},
+		{"storage-backend", "storage backend: memory or file", func(c *Config) flag.Value { return (*stringValue)(&c.Storage.Backend) }},
+		{"storage-path", "data file used by the file storage backend", func(c *Config) flag.Value { return (*stringValue)(&c.Storage.Path) }},
+		{"api-keys-file", "YAML or JSON file with hashed API keys", func(c *Config) flag.Value { return (*stringValue)(&c.Auth.APIKeysFile) }},
+		{"jwks-file", "JWKS file with keys for verifying bearer tokens", func(c *Config) flag.Value { return (*stringValue)(&c.Auth.JWT.JWKSFile) }},
+		{"jwt-issuer", "required iss claim of bearer tokens", func(c *Config) flag.Value { return (*stringValue)(&c.Auth.JWT.Issuer) }},
+		{"jwt-audience", "required aud claim of bearer tokens", func(c *Config) flag.Value { return (*stringValue)(&c.Auth.JWT.Audience) }},
+		{"jwt-clock-skew", "tolerated clock skew for exp and nbf", func(c *Config) flag.Value { return &c.Auth.JWT.ClockSkew }},
+		{"policy-file", "YAML or JSON role policy file", func(c *Config) flag.Value { return (*stringValue)(&c.Authz.PolicyFile) }},
+		{"tenancy", "isolate users by the X-Tenant-ID header or the principal's tenant", func(c *Config) flag.Value { return (*boolValue)(&c.Tenancy.Enabled) }},
+		{"compression", "compress responses and accept compressed request bodies", func(c *Config) flag.Value { return (*boolValue)(&c.Compression.Enabled) }},
+		{"compression-min-size", "smallest response body in bytes that is compressed", func(c *Config) flag.Value { return (*intValue)(&c.Compression.MinSize) }},
+		{"compression-level", "gzip and deflate level from -2 (Huffman only) to 9", func(c *Config) flag.Value { return (*intValue)(&c.Compression.Level) }},
+		{"compression-max-decompressed-bytes", "maximum decoded size of a compressed request body", func(c *Config) flag.Value { return (*intValue)(&c.Compression.MaxDecompressedBytes) }},
+		{"cors", "answer CORS preflight requests and allow cross-origin reads", func(c *Config) flag.Value { return (*boolValue)(&c.CORS.Enabled) }},
+		{"cors-allowed-origins", "comma-separated origins allowed to call the API; * matches any characters", func(c *Config) flag.Value { return (*stringListValue)(&c.CORS.AllowedOrigins) }},
+		{"cors-allowed-methods", "comma-separated methods allowed in cross-origin requests", func(c *Config) flag.Value { return (*stringListValue)(&c.CORS.AllowedMethods) }},
+		{"cors-allowed-headers", "comma-separated request headers allowed in cross-origin requests", func(c *Config) flag.Value { return (*stringListValue)(&c.CORS.AllowedHeaders) }},
+		{"cors-exposed-headers", "comma-separated response headers readable by browsers", func(c *Config) flag.Value { return (*stringListValue)(&c.CORS.ExposedHeaders) }},
+		{"cors-allow-credentials", "allow cookies and authorization headers in cross-origin requests", func(c *Config) flag.Value { return (*boolValue)(&c.CORS.AllowCredentials) }},
+		{"cors-max-age", "how long browsers may cache preflight results", func(c *Config) flag.Value { return &c.CORS.MaxAge }},
+		{"docs", "serve the OpenAPI document and the API explorer", func(c *Config) flag.Value { return (*boolValue)(&c.Docs.Enabled) }},
+		{"api-v1-sunset", "date (YYYY-MM-DD) announced in the Sunset header of /v1 responses; empty for none", func(c *Config) flag.Value { return (*stringValue)(&c.API.V1Sunset) }},
+		{"api-legacy-root", "also serve the deprecated v1 API without a version prefix", func(c *Config) flag.Value { return (*boolValue)(&c.API.LegacyRoot) }},
+		{"email-notifier", "delivery of verification tokens: memory or file", func(c *Config) flag.Value { return (*stringValue)(&c.Email.Notifier) }},
+		{"email-notifier-file", "file that receives messages from the file notifier", func(c *Config) flag.Value { return (*stringValue)(&c.Email.NotifierFile) }},
+		{"email-verification-ttl", "how long an email verification token stays valid", func(c *Config) flag.Value { return &c.Email.VerificationTTL }},
+		{"password-min-length", "minimum number of characters in a password", func(c *Config) flag.Value { return (*intValue)(&c.Password.MinLength) }},
+		{"password-iterations", "PBKDF2 iterations of new password hashes", func(c *Config) flag.Value { return (*intValue)(&c.Password.Iterations) }},
+		{"password-max-concurrent-hashes", "password hashes computed at once; further logins wait", func(c *Config) flag.Value { return (*intValue)(&c.Password.MaxConcurrentHashes) }},
+		{"password-max-failures", "failed logins in a row that lock an account; 0 never locks", func(c *Config) flag.Value { return (*intValue)(&c.Password.MaxFailures) }},
+		{"password-lockout", "how long a locked account stays locked", func(c *Config) flag.Value { return &c.Password.Lockout }},
+		{"session-idle-timeout", "how long a session lasts without use", func(c *Config) flag.Value { return &c.Session.IdleTimeout }},
+		{"session-max-lifetime", "how long a session lasts after login at most", func(c *Config) flag.Value { return &c.Session.MaxLifetime }},
+		{"session-sweep-interval", "time between removals of expired sessions", func(c *Config) flag.Value { return &c.Session.SweepInterval }},
+		{"session-roles", "comma-separated roles of principals authenticated by a session token", func(c *Config) flag.Value { return (*stringListValue)(&c.Session.Roles) }},
+		{"rate-limit", "enable per-client rate limiting", func(c *Config) flag.Value { return (*boolValue)(&c.RateLimit.Enabled) }},
+		{"rate-limit-rate", "sustained requests per second per client", func(c *Config) flag.Value { return (*floatValue)(&c.RateLimit.Rate) }},
+		{"rate-limit-burst", "maximum burst of requests per client", func(c *Config) flag.Value { return (*intValue)(&c.RateLimit.Burst) }},
+		{"rate-limit-max-clients", "maximum number of tracked clients", func(c *Config) flag.Value { return (*intValue)(&c.RateLimit.MaxClients) }},
+		{"log-level", "minimum log level: debug, info, warn or error", func(c *Config) flag.Value { return (*stringValue)(&c.Log.Level) }},
+		{"log-format", "log output format: json or text", func(c *Config) flag.Value { return (*stringValue)(&c.Log.Format) }},
+		{"log-success-sample-rate", "fraction of successful requests written to the access log", func(c *Config) flag.Value { return (*floatValue)(&c.Log.SuccessSampleRate) }},
+		{"metrics", "serve Prometheus metrics", func(c *Config) flag.Value { return (*boolValue)(&c.Metrics.Enabled) }},
+		{"metrics-path", "path of the Prometheus metrics endpoint", func(c *Config) flag.Value { return (*stringValue)(&c.Metrics.Path) }},
+		{"tracing-exporter", "span exporter for local debugging: none, stdout or file", func(c *Config) flag.Value { return (*stringValue)(&c.Tracing.Exporter) }},
+		{"tracing-file", "file that receives spans from the file exporter", func(c *Config) flag.Value { return (*stringValue)(&c.Tracing.File) }},
+		{"tracing-sample-ratio", "fraction of new traces that are sampled", func(c *Config) flag.Value { return (*floatValue)(&c.Tracing.SampleRatio) }},
+		{"health-check-timeout", "timeout of each readiness check", func(c *Config) flag.Value { return &c.Health.CheckTimeout }},
+	}
+}
+
+// Load builds the effective configuration. Later sources override earlier
+// ones: built-in defaults, the configuration file, environment variables,
+// command-line flags.
+func Load(args []string, lookupEnv LookupEnvFunc, output io.Writer) (Result, error) {
+	defaults := Default()
+	table := settings()
+
+	fs := flag.NewFlagSet("service", flag.ContinueOnError)
+	fs.SetOutput(output)
+
+	var result Result
+
+	fs.StringVar(&result.File, "config", "", "path to a YAML or JSON configuration file (env "+EnvConfigFile+")")
+	fs.BoolVar(&result.PrintConfig, "print-config", false, "print the effective configuration and exit")
+
+	recorded := make([]*recordedValue, len(table))
+
+	for i, s := range table {
+		probe := defaults
+		recorded[i] = &recordedValue{probe: s.value(&probe)}
+		fs.Var(recorded[i], s.flag, s.usage+" (env "+s.env()+")")
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return Result{}, xerrors.Wrap(err, "config.Load: flags")
+	}
+
+	if fs.NArg() > 0 {
+		return Result{}, xerrors.Errorf("config.Load: unexpected arguments %q", fs.Args())
+	}
+
+	if result.File == "" {
+		result.File, _ = lookupEnv(EnvConfigFile)
+	}
+
+	cfg := defaults
+
+	if result.File != "" {
+		if err := decodeFile(result.File, &cfg); err != nil {
+			return Result{}, xerrors.Wrap(err, "config.Load")
+		}
+	}
+
+	for _, s := range table {
+		raw, ok := lookupEnv(s.env())
+		if !ok {
+			continue
+		}
+
+		if err := s.value(&cfg).Set(raw); err != nil {
+			return Result{}, xerrors.Wrapf(err, "config.Load: env %s", s.env())
+		}
+	}
+
+	for i, s := range table {
+		for _, raw := range recorded[i].raw {
+			if err := s.value(&cfg).Set(raw); err != nil {
+				return Result{}, xerrors.Wrapf(err, "config.Load: flag -%s", s.flag)
+			}
+		}
+	}
+
+	result.Config = cfg
+
+	// A printed configuration is most useful when it is wrong, so the caller
+	// validates it after printing.
+	if result.PrintConfig {
+		return result, nil
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return Result{}, xerrors.Wrap(err, "config.Load")
+	}
+
+	return result, nil
+}
+
+func decodeFile(path string, target any) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return xerrors.Wrap(err, "read")
+	}
+
+	// JSON is a subset of YAML, so both formats share one strict decoding path.
+	converted, err := yaml.YAMLToJSON(content)
+	if err != nil {
+		return xerrors.Wrapf(err, "parse %s", path)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(converted))
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(target); err != nil {
+		return xerrors.Wrapf(err, "decode %s", path)
+	}
+
+	return nil
+}
+
+type recordedValue struct {
+	probe flag.Value
+	raw   []string
+}
+
+func (r *recordedValue) String() string {
+	if r == nil || r.probe == nil {
+		return ""
+	}
+
+	return r.probe.String()
+}
+
+func (r *recordedValue) Set(value string) error {
+	if err := r.probe.Set(value); err != nil {
+		return err
+	}
+
+	r.raw = append(r.raw, value)
+
+	return nil
+}
+
+func (r *recordedValue) IsBoolFlag() bool {
+	boolFlag, ok := r.probe.(interface{ IsBoolFlag() bool })
+
+	return ok && boolFlag.IsBoolFlag()
+}
+
+type stringValue string
+
+func (s *stringValue) String() string {
+	return string(*s)
+}
+
+func (s *stringValue) Set(value string) error {
+	*s = stringValue(value)
+
+	return nil
+}
+
+// stringListValue is a comma-separated list; each Set replaces the list.
+type stringListValue []string
+
+func (s *stringListValue) String() string {
+	return strings.Join(*s, ",")
+}
+
+func (s *stringListValue) Set(value string) error {
+	var list []string
+
+	for item := range strings.SplitSeq(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+
+	*s = list
+
+	return nil
+}
+
+type intValue int
+
+func (i *intValue) String() string {
+	return strconv.Itoa(int(*i))
+}
+
+func (i *intValue) Set(value string) error {
+	parsed, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil {
+		return xerrors.Errorf("invalid integer %q", value)
+	}
+
+	*i = intValue(parsed)
+
+	return nil
+}
+
+type boolValue bool
+
+func (b *boolValue) String() string {
+	return strconv.FormatBool(bool(*b))
+}
+
+func (b *boolValue) Set(value string) error {
+	parsed, err := strconv.ParseBool(strings.TrimSpace(value))
+	if err != nil {
+		return xerrors.Errorf("invalid boolean %q", value)
+	}
+
+	*b = boolValue(parsed)
+
+	return nil
+}
+
+func (b *boolValue) IsBoolFlag() bool {
+	return true
+}
+
+type floatValue float64
+
+func (f *floatValue) String() string {
+	return strconv.FormatFloat(float64(*f), 'g', -1, 64)
+}
+
+func (f *floatValue) Set(value string) error {
+	parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil {
+		return xerrors.Errorf("invalid number %q", value)
+	}
+
+	*f = floatValue(parsed)
+
+	return nil
+}
@@ -0,0 +1,411 @@
+package config_test
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/flexer2006/t-t-ogen-go/internal/config"
+)
+
+// required are the flags without which no configuration validates.
+var required = []string{"--api-keys-file", "keys.yaml", "--policy-file", "policy.yaml"}
+
+func lookupEnv(env map[string]string) config.LookupEnvFunc {
+	return func(key string) (string, bool) {
+		value, ok := env[key]
+
+		return value, ok
+	}
+}
+
+func writeFile(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write config file: %v", err)
+	}
+
+	return path
+}
+
+// validConfig returns the defaults with the settings that have none.
+func validConfig() config.Config {
+	cfg := config.Default()
+	cfg.Auth.APIKeysFile = "keys.yaml"
+	cfg.Authz.PolicyFile = "policy.yaml"
+
+	return cfg
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	file := writeFile(t, `
+log:
+  level: warn
+  format: text
+rate_limit:
+  burst: 7
+`)
+
+	tests := []struct {
+		name       string
+		args       []string
+		env        map[string]string
+		wantLevel  string
+		wantFormat string
+		wantBurst  int
+	}{
+		{
+			name:       "defaults",
+			wantLevel:  "info",
+			wantFormat: config.LogFormatJSON,
+			wantBurst:  20,
+		},
+		{
+			name:       "file over defaults",
+			args:       []string{"--config", file},
+			wantLevel:  "warn",
+			wantFormat: config.LogFormatText,
+			wantBurst:  7,
+		},
+		{
+			name:       "file named by the environment",
+			env:        map[string]string{config.EnvConfigFile: file},
+			wantLevel:  "warn",
+			wantFormat: config.LogFormatText,
+			wantBurst:  7,
+		},
+		{
+			name:       "flag naming the file over the environment",
+			args:       []string{"--config", file},
+			env:        map[string]string{config.EnvConfigFile: filepath.Join(t.TempDir(), "missing.yaml")},
+			wantLevel:  "warn",
+			wantFormat: config.LogFormatText,
+			wantBurst:  7,
+		},
+		{
+			name:       "environment over file",
+			args:       []string{"--config", file},
+			env:        map[string]string{"USER_SERVICE_LOG_LEVEL": "error", "USER_SERVICE_RATE_LIMIT_BURST": "9"},
+			wantLevel:  "error",
+			wantFormat: config.LogFormatText,
+			wantBurst:  9,
+		},
+		{
+			name:       "flags over environment",
+			args:       []string{"--config", file, "--log-level", "debug"},
+			env:        map[string]string{"USER_SERVICE_LOG_LEVEL": "error", "USER_SERVICE_RATE_LIMIT_BURST": "9"},
+			wantLevel:  "debug",
+			wantFormat: config.LogFormatText,
+			wantBurst:  9,
+		},
+		{
+			name:       "last repeated flag",
+			args:       []string{"--log-level", "warn", "--log-level", "error"},
+			wantLevel:  "error",
+			wantFormat: config.LogFormatJSON,
+			wantBurst:  20,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := config.Load(append(slices.Clone(required), tt.args...), lookupEnv(tt.env), io.Discard)
+			if err != nil {
+				t.Fatalf("Load: %v", err)
+			}
+
+			cfg := result.Config
+
+			if cfg.Log.Level != tt.wantLevel || cfg.Log.Format != tt.wantFormat || cfg.RateLimit.Burst != tt.wantBurst {
+				t.Errorf("log.level, log.format, rate_limit.burst = %q, %q, %d; want %q, %q, %d",
+					cfg.Log.Level, cfg.Log.Format, cfg.RateLimit.Burst, tt.wantLevel, tt.wantFormat, tt.wantBurst)
+			}
+
+			// Settings no source names keep their defaults.
+			if cfg.Server.Address != config.Default().Server.Address {
+				t.Errorf("server.address = %q, want the default %q", cfg.Server.Address, config.Default().Server.Address)
+			}
+		})
+	}
+}
+
+func TestLoadValues(t *testing.T) {
+	result, err := config.Load(append(slices.Clone(required),
+		"--read-timeout", "3s",
+		"--cors-allowed-origins", " https://a.example , ,https://b.example",
+		"--tenancy",
+		"--compression=false",
+		"--rate-limit-rate", "2.5",
+	), lookupEnv(nil), io.Discard)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	cfg := result.Config
+
+	if cfg.Server.ReadTimeout != config.Duration(3*time.Second) {
+		t.Errorf("server.read_timeout = %s, want 3s", cfg.Server.ReadTimeout)
+	}
+
+	if want := []string{"https://a.example", "https://b.example"}; !slices.Equal(cfg.CORS.AllowedOrigins, want) {
+		t.Errorf("cors.allowed_origins = %q, want %q", cfg.CORS.AllowedOrigins, want)
+	}
+
+	if !cfg.Tenancy.Enabled || cfg.Compression.Enabled {
+		t.Errorf("tenancy.enabled, compression.enabled = %v, %v; want true, false", cfg.Tenancy.Enabled, cfg.Compression.Enabled)
+	}
+
+	if cfg.RateLimit.Rate != 2.5 {
+		t.Errorf("rate_limit.rate = %g, want 2.5", cfg.RateLimit.Rate)
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		args    []string
+		env     map[string]string
+		wantErr string
+	}{
+		{
+			name:    "unknown file key",
+			file:    "log:\n  levle: debug\n",
+			wantErr: `unknown field "levle"`,
+		},
+		{
+			name:    "unknown file section",
+			file:    "logging:\n  level: debug\n",
+			wantErr: `unknown field "logging"`,
+		},
+		{
+			name:    "file value of the wrong type",
+			file:    "rate_limit:\n  burst: many\n",
+			wantErr: "decode",
+		},
+		{
+			name:    "file duration",
+			file:    "server:\n  read_timeout: soon\n",
+			wantErr: `invalid duration "soon"`,
+		},
+		{
+			name:    "missing file",
+			args:    []string{"--config", filepath.Join(t.TempDir(), "missing.yaml")},
+			wantErr: "read",
+		},
+		{
+			name:    "environment value",
+			env:     map[string]string{"USER_SERVICE_RATE_LIMIT_BURST": "many"},
+			wantErr: `env USER_SERVICE_RATE_LIMIT_BURST: invalid integer "many"`,
+		},
+		{
+			name:    "flag value",
+			args:    []string{"--read-timeout", "soon"},
+			wantErr: `invalid duration "soon"`,
+		},
+		{
+			name:    "unknown flag",
+			args:    []string{"--no-such-flag"},
+			wantErr: "flag provided but not defined",
+		},
+		{
+			name:    "positional argument",
+			args:    []string{"serve"},
+			wantErr: `unexpected arguments ["serve"]`,
+		},
+		{
+			name:    "invalid configuration",
+			args:    []string{"--log-level", "loud"},
+			wantErr: `log.level: unknown level "loud"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := slices.Clone(required)
+			if tt.file != "" {
+				args = append(args, "--config", writeFile(t, tt.file))
+			}
+
+			_, err := config.Load(append(args, tt.args...), lookupEnv(tt.env), io.Discard)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Load: error = %v, want one containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadPrintConfigSkipsValidation(t *testing.T) {
+	result, err := config.Load([]string{"--print-config", "--log-level", "loud"}, lookupEnv(nil), io.Discard)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	if !result.PrintConfig || result.Config.Log.Level != "loud" {
+		t.Errorf("PrintConfig, log.level = %v, %q; want true, loud", result.PrintConfig, result.Config.Log.Level)
+	}
+
+	if err := result.Config.Validate(); !errors.Is(err, config.ErrInvalidConfig) {
+		t.Errorf("Validate: error = %v, want ErrInvalidConfig", err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	if err := validConfig().Validate(); err != nil {
+		t.Fatalf("Validate of the defaults: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		change func(*config.Config)
+		want   []string
+	}{
+		{
+			name:   "no authentication",
+			change: func(c *config.Config) { c.Auth.APIKeysFile = "" },
+			want:   []string{"auth: no authentication configured"},
+		},
+		{
+			name:   "client certificates authenticate",
+			change: func(c *config.Config) { c.Auth.APIKeysFile, c.Server.TLS.ClientCAFile = "", "ca.pem" },
+			want:   []string{"server.tls.client_ca_file: requires server.tls.cert_file"},
+		},
+		{
+			name:   "no policy",
+			change: func(c *config.Config) { c.Authz.PolicyFile = "" },
+			want:   []string{"authz.policy_file: is required"},
+		},
+		{
+			name:   "file storage without a path",
+			change: func(c *config.Config) { c.Storage.Backend = config.StorageFile },
+			want:   []string{`storage.path: is required by the "file" backend`},
+		},
+		{
+			name:   "half a key pair",
+			change: func(c *config.Config) { c.Server.TLS.CertFile = "cert.pem" },
+			want:   []string{"server.tls: cert_file and key_file must be set together"},
+		},
+		{
+			name:   "disabled rate limit is not checked",
+			change: func(c *config.Config) { c.RateLimit.Rate = -1 },
+		},
+		{
+			name: "rate limit operations",
+			change: func(c *config.Config) {
+				c.RateLimit.Enabled = true
+				c.RateLimit.Operations = map[string]config.RateLimitRule{"login": {Rate: 0, Burst: 0}}
+			},
+			want: []string{"rate_limit.operations.login.rate: must be positive", "rate_limit.operations.login.burst: must be at least 1"},
+		},
+		{
+			name:   "password iterations",
+			change: func(c *config.Config) { c.Password.Iterations = 1000 },
+			want:   []string{"password.iterations: must be at least 10000, got 1000"},
+		},
+		{
+			name:   "lockout without a duration",
+			change: func(c *config.Config) { c.Password.Lockout = 0 },
+			want:   []string{"password.lockout: must be positive"},
+		},
+		{
+			name:   "lockout disabled",
+			change: func(c *config.Config) { c.Password.MaxFailures, c.Password.Lockout = 0, 0 },
+		},
+		{
+			name:   "session sweep interval",
+			change: func(c *config.Config) { c.Session.SweepInterval = config.Duration(time.Millisecond) },
+			want:   []string{"session.sweep_interval: must be at least 1s"},
+		},
+		{
+			name:   "metrics path inside the API",
+			change: func(c *config.Config) { c.Metrics.Path = "/v2/metrics" },
+			want:   []string{"metrics.path: must be an absolute path outside the API"},
+		},
+		{
+			name:   "relative metrics path",
+			change: func(c *config.Config) { c.Metrics.Path = "metrics" },
+			want:   []string{"metrics.path: must be an absolute path outside the API"},
+		},
+		{
+			name:   "metrics path of the API explorer",
+			change: func(c *config.Config) { c.Metrics.Path = "/docs" },
+			want:   []string{`metrics.path: "/docs" is already served by the service`},
+		},
+		{
+			name:   "metrics path of the OpenAPI document",
+			change: func(c *config.Config) { c.Metrics.Path = "/openapi.yaml" },
+			want:   []string{`metrics.path: "/openapi.yaml" is already served by the service`},
+		},
+		{
+			name:   "metrics path of the liveness probe",
+			change: func(c *config.Config) { c.Metrics.Path = "/healthz" },
+			want:   []string{`metrics.path: "/healthz" is already served by the service`},
+		},
+		{
+			name:   "metrics path of the readiness probe",
+			change: func(c *config.Config) { c.Metrics.Path = "/readyz" },
+			want:   []string{`metrics.path: "/readyz" is already served by the service`},
+		},
+		{
+			name:   "disabled metrics are not checked",
+			change: func(c *config.Config) { c.Metrics.Enabled, c.Metrics.Path = false, "/healthz" },
+		},
+		{
+			name: "every problem at once",
+			change: func(c *config.Config) {
+				c.Server.Address = ""
+				c.Server.ReadTimeout = 0
+				c.Log.Format = "xml"
+				c.Metrics.Path = "/readyz"
+				c.Tracing.SampleRatio = 2
+			},
+			want: []string{
+				"server.address: must not be empty",
+				"server.read_timeout: must be positive, got 0s",
+				`log.format: unknown format "xml"`,
+				`metrics.path: "/readyz" is already served by the service`,
+				"tracing.sample_ratio",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := validConfig()
+			tt.change(&cfg)
+
+			err := cfg.Validate()
+
+			if len(tt.want) == 0 {
+				if err != nil {
+					t.Errorf("Validate: %v", err)
+				}
+
+				return
+			}
+
+			if !errors.Is(err, config.ErrInvalidConfig) {
+				t.Fatalf("Validate: error = %v, want ErrInvalidConfig", err)
+			}
+
+			// Each problem is on a line of its own.
+			lines := strings.Split(err.Error(), "\n")
+			if len(lines) != len(tt.want)+1 {
+				t.Errorf("Validate reported %d problems, want %d:\n%v", len(lines)-1, len(tt.want), err)
+			}
+
+			for _, want := range tt.want {
+				if !slices.ContainsFunc(lines, func(line string) bool { return strings.HasPrefix(line, want) }) {
+					t.Errorf("Validate: no problem starting with %q in:\n%v", want, err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package config
+
+import (
+	"log/slog"
+
+	xerrors "github.com/go-faster/errors"
+)
+
+const (
+	LogFormatJSON = "json"
+	LogFormatText = "text"
+)
+
+type LogConfig struct {
+	Level             string  `json:"level"`
+	Format            string  `json:"format"`
+	SuccessSampleRate float64 `json:"success_sample_rate"`
+}
+
+func (l LogConfig) SlogLevel() slog.Level {
+	var level slog.Level
+
+	_ = level.UnmarshalText([]byte(l.Level))
+
+	return level
+}
+
+func (l LogConfig) validate() []error {
+	var problems []error
+
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(l.Level)); err != nil {
+		problems = append(problems, xerrors.Errorf("log.level: unknown level %q (want debug, info, warn or error)", l.Level))
+	}
+
+	if l.Format != LogFormatJSON && l.Format != LogFormatText {
+		problems = append(problems, xerrors.Errorf("log.format: unknown format %q (want %q or %q)", l.Format, LogFormatJSON, LogFormatText))
+	}
+
+	if l.SuccessSampleRate < 0 || l.SuccessSampleRate > 1 {
+		problems = append(problems, xerrors.Errorf("log.success_sample_rate: must be within [0, 1], got %g", l.SuccessSampleRate))
+	}
+
+	return problems
+}
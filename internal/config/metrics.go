@@ -0,0 +1,40 @@
+package config
+
+import (
+	"slices"
+	"strings"
+
+	xerrors "github.com/go-faster/errors"
+)
+
+// apiPrefixes are paths owned by the API, including the unversioned v1
+// routes.
+var apiPrefixes = []string{"/v1", "/v2", "/users", "/tenants", "/groups"}
+
+// reservedPaths are the other routes of the service: the OpenAPI documents
+// and explorer, which are also served under each API prefix, and the health
+// probes.
+var reservedPaths = []string{"/openapi.yaml", "/openapi.json", "/docs", "/healthz", "/readyz"}
+
+type MetricsConfig struct {
+	Enabled bool   `json:"enabled"`
+	Path    string `json:"path"`
+}
+
+func (m MetricsConfig) validate() []error {
+	if !m.Enabled {
+		return nil
+	}
+
+	if !strings.HasPrefix(m.Path, "/") || m.Path == "/" || slices.ContainsFunc(apiPrefixes, func(prefix string) bool {
+		return strings.HasPrefix(m.Path, prefix)
+	}) {
+		return []error{xerrors.Errorf("metrics.path: must be an absolute path outside the API, got %q", m.Path)}
+	}
+
+	if slices.Contains(reservedPaths, m.Path) {
+		return []error{xerrors.Errorf("metrics.path: %q is already served by the service", m.Path)}
+	}
+
+	return nil
+}
@@ -0,0 +1,45 @@
+package config
+
+import xerrors "github.com/go-faster/errors"
+
+// minPasswordIterations keeps a configuration from disabling the work
+// factor of password hashes by accident.
+const minPasswordIterations = 10000
+
+// PasswordConfig controls password hashing and login lockout. Changing
+// Iterations upgrades stored hashes as their users log in. Each hash keeps a
+// CPU busy, so MaxConcurrentHashes bounds how much of the machine logins can
+// take; further logins wait.
+type PasswordConfig struct {
+	MinLength           int      `json:"min_length"`
+	Iterations          int      `json:"iterations"`
+	MaxConcurrentHashes int      `json:"max_concurrent_hashes"`
+	MaxFailures         int      `json:"max_failures"`
+	Lockout             Duration `json:"lockout"`
+}
+
+func (p PasswordConfig) validate() []error {
+	var problems []error
+
+	if p.MinLength < 1 {
+		problems = append(problems, xerrors.Errorf("password.min_length: must be positive, got %d", p.MinLength))
+	}
+
+	if p.Iterations < minPasswordIterations {
+		problems = append(problems, xerrors.Errorf("password.iterations: must be at least %d, got %d", minPasswordIterations, p.Iterations))
+	}
+
+	if p.MaxConcurrentHashes < 1 {
+		problems = append(problems, xerrors.Errorf("password.max_concurrent_hashes: must be at least 1, got %d", p.MaxConcurrentHashes))
+	}
+
+	if p.MaxFailures < 0 {
+		problems = append(problems, xerrors.Errorf("password.max_failures: must not be negative, got %d", p.MaxFailures))
+	}
+
+	if p.MaxFailures > 0 && p.Lockout <= 0 {
+		problems = append(problems, xerrors.Errorf("password.lockout: must be positive, got %s", p.Lockout))
+	}
+
+	return problems
+}
@@ -0,0 +1,48 @@
+package config
+
+import xerrors "github.com/go-faster/errors"
+
+type RateLimitConfig struct {
+	Enabled    bool                     `json:"enabled"`
+	Rate       float64                  `json:"rate"`
+	Burst      int                      `json:"burst"`
+	MaxClients int                      `json:"max_clients"`
+	Operations map[string]RateLimitRule `json:"operations,omitempty"`
+}
+
+type RateLimitRule struct {
+	Rate  float64 `json:"rate"`
+	Burst int     `json:"burst"`
+}
+
+func (r RateLimitConfig) validate() []error {
+	if !r.Enabled {
+		return nil
+	}
+
+	problems := validateRateLimitRule("rate_limit", RateLimitRule{Rate: r.Rate, Burst: r.Burst})
+
+	if r.MaxClients <= 0 {
+		problems = append(problems, xerrors.Errorf("rate_limit.max_clients: must be positive, got %d", r.MaxClients))
+	}
+
+	for operation, rule := range r.Operations {
+		problems = append(problems, validateRateLimitRule("rate_limit.operations."+operation, rule)...)
+	}
+
+	return problems
+}
+
+func validateRateLimitRule(field string, rule RateLimitRule) []error {
+	var problems []error
+
+	if rule.Rate <= 0 {
+		problems = append(problems, xerrors.Errorf("%s.rate: must be positive, got %g", field, rule.Rate))
+	}
+
+	if rule.Burst < 1 {
+		problems = append(problems, xerrors.Errorf("%s.burst: must be at least 1, got %d", field, rule.Burst))
+	}
+
+	return problems
+}
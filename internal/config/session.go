@@ -0,0 +1,41 @@
+package config
+
+import (
+	"time"
+
+	xerrors "github.com/go-faster/errors"
+)
+
+// minSessionSweepInterval keeps the sweeper from holding the storage lock
+// most of the time.
+const minSessionSweepInterval = time.Second
+
+// SessionConfig controls the sessions opened by logins. A session ends after
+// IdleTimeout without use or MaxLifetime after login, whichever comes first.
+// Session tokens authenticate as the user with Roles, which the access
+// policy should grant little beyond the user's own operations.
+type SessionConfig struct {
+	IdleTimeout   Duration `json:"idle_timeout"`
+	MaxLifetime   Duration `json:"max_lifetime"`
+	SweepInterval Duration `json:"sweep_interval"`
+	Roles         []string `json:"roles,omitempty"`
+}
+
+func (s SessionConfig) validate() []error {
+	var problems []error
+
+	if s.IdleTimeout <= 0 {
+		problems = append(problems, xerrors.Errorf("session.idle_timeout: must be positive, got %s", s.IdleTimeout))
+	}
+
+	if s.MaxLifetime <= 0 {
+		problems = append(problems, xerrors.Errorf("session.max_lifetime: must be positive, got %s", s.MaxLifetime))
+	}
+
+	if time.Duration(s.SweepInterval) < minSessionSweepInterval {
+		problems = append(problems, xerrors.Errorf("session.sweep_interval: must be at least %s, got %s",
+			minSessionSweepInterval, s.SweepInterval))
+	}
+
+	return problems
+}
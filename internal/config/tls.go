@@ -0,0 +1,60 @@
+package config
+
+import (
+	"strings"
+
+	xerrors "github.com/go-faster/errors"
+)
+
+const (
+	ClientAuthRequire  = "require"
+	ClientAuthOptional = "optional"
+)
+
+type TLSConfig struct {
+	CertFile     string `json:"cert_file,omitempty"`
+	KeyFile      string `json:"key_file,omitempty"`
+	ClientCAFile string `json:"client_ca_file,omitempty"`
+	// ClientAuth selects whether a verified client certificate is required
+	// or only checked when presented. It defaults to require once a client
+	// CA bundle is configured.
+	ClientAuth string `json:"client_auth,omitempty"`
+}
+
+func (t TLSConfig) Enabled() bool {
+	return t.CertFile != ""
+}
+
+// ClientAuthMode resolves the default of ClientAuth.
+func (t TLSConfig) ClientAuthMode() string {
+	if t.ClientAuth == "" && t.ClientCAFile != "" {
+		return ClientAuthRequire
+	}
+
+	return t.ClientAuth
+}
+
+func (t TLSConfig) validate() []error {
+	var problems []error
+
+	if (strings.TrimSpace(t.CertFile) == "") != (strings.TrimSpace(t.KeyFile) == "") {
+		problems = append(problems, xerrors.New("server.tls: cert_file and key_file must be set together"))
+	}
+
+	if t.ClientCAFile != "" && !t.Enabled() {
+		problems = append(problems, xerrors.New("server.tls.client_ca_file: requires server.tls.cert_file"))
+	}
+
+	switch t.ClientAuth {
+	case "":
+	case ClientAuthRequire, ClientAuthOptional:
+		if t.ClientCAFile == "" {
+			problems = append(problems, xerrors.New("server.tls.client_auth: requires server.tls.client_ca_file"))
+		}
+	default:
+		problems = append(problems, xerrors.Errorf("server.tls.client_auth: unknown mode %q (want %q or %q)",
+			t.ClientAuth, ClientAuthRequire, ClientAuthOptional))
+	}
+
+	return problems
+}
@@ -0,0 +1,40 @@
+package config
+
+import (
+	"strings"
+
+	xerrors "github.com/go-faster/errors"
+)
+
+const (
+	TracingExporterNone   = "none"
+	TracingExporterStdout = "stdout"
+	TracingExporterFile   = "file"
+)
+
+type TracingConfig struct {
+	Exporter    string  `json:"exporter"`
+	File        string  `json:"file,omitempty"`
+	SampleRatio float64 `json:"sample_ratio"`
+}
+
+func (t TracingConfig) validate() []error {
+	var problems []error
+
+	switch t.Exporter {
+	case TracingExporterNone, TracingExporterStdout:
+	case TracingExporterFile:
+		if strings.TrimSpace(t.File) == "" {
+			problems = append(problems, xerrors.Errorf("tracing.file: is required by the %q exporter", TracingExporterFile))
+		}
+	default:
+		problems = append(problems, xerrors.Errorf("tracing.exporter: unknown exporter %q (want %q, %q or %q)",
+			t.Exporter, TracingExporterNone, TracingExporterStdout, TracingExporterFile))
+	}
+
+	if t.SampleRatio < 0 || t.SampleRatio > 1 {
+		problems = append(problems, xerrors.Errorf("tracing.sample_ratio: must be within [0, 1], got %g", t.SampleRatio))
+	}
+
+	return problems
+}
@@ -0,0 +1,40 @@
+package domain
+
+import "regexp"
+
+type AttributeType string
+
+const (
+	AttributeString  AttributeType = "string"
+	AttributeInteger AttributeType = "integer"
+	AttributeNumber  AttributeType = "number"
+	AttributeBoolean AttributeType = "boolean"
+)
+
+var attributeNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,62}$`)
+
+// Attributes holds the custom attributes of a user by name. Values are
+// string, int64, float64 or bool, as the attribute's type says.
+type Attributes map[string]any
+
+// AttributeDefinition describes one custom attribute of a tenant's users.
+type AttributeDefinition struct {
+	Name     string
+	Type     AttributeType
+	Required bool
+	// Unique values are not shared by two users of the tenant.
+	Unique bool
+	// Enum lists the allowed values; empty allows any value of the type.
+	Enum []any
+	// Pattern is a regular expression that string values must match.
+	Pattern     string
+	Description string
+}
+
+// AttributeFilter selects users whose attributes equal every value, given in
+// text form such as "42" for an integer attribute.
+type AttributeFilter map[string]string
+
+func ValidAttributeName(name string) bool {
+	return attributeNamePattern.MatchString(name)
+}
@@ -0,0 +1,24 @@
+package domain
+
+import "time"
+
+// Credential is the password of a user with its login failure count.
+// PasswordHash is self-describing: it records the algorithm and parameters
+// it was made with, so hashes can be upgraded as users log in.
+type Credential struct {
+	PasswordHash string
+	// Failures counts failed logins since the last successful one.
+	Failures    int
+	LockedUntil time.Time
+}
+
+func (c Credential) Locked(now time.Time) bool {
+	return now.Before(c.LockedUntil)
+}
+
+// LockoutPolicy locks an account for Duration once MaxFailures logins in a
+// row have failed. Zero MaxFailures never locks.
+type LockoutPolicy struct {
+	MaxFailures int
+	Duration    time.Duration
+}
@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const maxEmailLength = 254
+
+// EmailVerification is sent to Email so that its owner can prove receiving
+// it by returning Token.
+type EmailVerification struct {
+	TenantID  string
+	UserID    uuid.UUID
+	Email     string
+	Token     string
+	ExpiresAt time.Time
+}
+
+// VerificationToken is a pending verification as stored: only the hash of
+// the token is kept, together with the address it was sent to, so a token
+// stops working once the user's address changes.
+type VerificationToken struct {
+	Hash      []byte
+	Email     string
+	ExpiresAt time.Time
+}
+
+// ValidEmail accepts a bare address such as "ann@example.com", without a
+// display name or angle brackets.
+func ValidEmail(email string) bool {
+	if len(email) > maxEmailLength {
+		return false
+	}
+
+	address, err := mail.ParseAddress(email)
+
+	return err == nil && address.Name == "" && address.Address == email
+}
+
+// EmailKey is the form in which addresses are compared; two addresses that
+// differ only in case belong to the same mailbox in practice.
+func EmailKey(email string) string {
+	return strings.ToLower(email)
+}
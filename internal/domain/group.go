@@ -0,0 +1,10 @@
+package domain
+
+import "github.com/google/uuid"
+
+// Group collects users of one tenant, such as the members of a team.
+type Group struct {
+	ID          uuid.UUID
+	Name        string
+	Description string
+}
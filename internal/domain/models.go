@@ -6,4 +6,10 @@ type User struct {
 	ID       uuid.UUID
 	Name     string
 	Username string
+	// Email is empty when the user has no address.
+	Email string
+	// EmailVerified is only set by consuming a verification token and is
+	// cleared whenever Email changes.
+	EmailVerified bool
+	Attributes    Attributes
 }
@@ -0,0 +1,15 @@
+package domain
+
+const AnyOperation = "*"
+
+type AccessPolicy struct {
+	Roles map[string]RolePermissions
+}
+
+// RolePermissions lists operations a role may invoke. SelfOperations are only
+// allowed when the target user is the caller, i.e. the user ID equals the
+// principal subject.
+type RolePermissions struct {
+	Operations     []string
+	SelfOperations []string
+}
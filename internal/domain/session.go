@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AuthMethodSession marks principals authenticated by a session token.
+const AuthMethodSession = "session"
+
+// Session is a login of a user. It ends at IdleExpiresAt, which every use
+// moves forward, or at ExpiresAt, which is fixed at login, whichever comes
+// first. Only the hash of its token is stored.
+type Session struct {
+	ID            uuid.UUID
+	TenantID      string
+	UserID        uuid.UUID
+	TokenHash     []byte
+	CreatedAt     time.Time
+	LastUsedAt    time.Time
+	IdleExpiresAt time.Time
+	ExpiresAt     time.Time
+}
+
+// Expiry is when the session ends unless it is used before.
+func (s Session) Expiry() time.Time {
+	if s.IdleExpiresAt.Before(s.ExpiresAt) {
+		return s.IdleExpiresAt
+	}
+
+	return s.ExpiresAt
+}
+
+func (s Session) Expired(now time.Time) bool {
+	return !now.Before(s.Expiry())
+}
+
+// Login is the outcome of a successful login. Token is known only here.
+type Login struct {
+	User    User
+	Session Session
+	Token   string
+}
@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"context"
+	"regexp"
+)
+
+// DefaultTenant holds every user while tenancy is disabled and the users of
+// data files written before tenancy existed.
+const DefaultTenant = "default"
+
+var tenantIDPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,62}$`)
+
+type Tenant struct {
+	ID   string
+	Name string
+}
+
+func ValidTenantID(id string) bool {
+	return tenantIDPattern.MatchString(id)
+}
+
+type tenantContextKey struct{}
+
+func ContextWithTenant(ctx context.Context, tenantID string) context.Context {
+	return context.WithValue(ctx, tenantContextKey{}, tenantID)
+}
+
+func TenantFromContext(ctx context.Context) (string, bool) {
+	tenantID, ok := ctx.Value(tenantContextKey{}).(string)
+
+	return tenantID, ok && tenantID != ""
+}
@@ -8,10 +8,16 @@ import (
 	"github.com/flexer2006/t-t-ogen-go/internal/domain"
 )
 
+// UserService validates attributes against the tenant's attribute schema.
+// UpdateUser replaces every attribute unless attributes is nil, and an empty
+// email removes the address. Setting an address sends a verification token
+// to it, which VerifyEmail accepts once.
 type UserService interface {
-	ListUsers(ctx context.Context) ([]domain.User, error)
-	CreateUser(ctx context.Context, name, username string) (domain.User, error)
+	ListUsers(ctx context.Context, filter domain.AttributeFilter) ([]domain.User, error)
+	CreateUser(ctx context.Context, name, username, email string, attributes domain.Attributes) (domain.User, error)
 	GetUser(ctx context.Context, id uuid.UUID) (domain.User, error)
-	UpdateUser(ctx context.Context, id uuid.UUID, name *string, username *string) (domain.User, error)
+	UpdateUser(ctx context.Context, id uuid.UUID, name *string, username *string, email *string, attributes domain.Attributes) (domain.User, error)
 	DeleteUser(ctx context.Context, id uuid.UUID) error
+	RequestEmailVerification(ctx context.Context, id uuid.UUID) error
+	VerifyEmail(ctx context.Context, id uuid.UUID, token string) (domain.User, error)
 }
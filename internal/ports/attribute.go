@@ -0,0 +1,43 @@
+package ports
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/flexer2006/t-t-ogen-go/internal/domain"
+)
+
+var (
+	ErrInvalidAttributes      = errors.New("invalid attributes")
+	ErrInvalidAttributeSchema = errors.New("invalid attribute schema")
+	ErrAttributeNotUnique     = errors.New("attribute value already taken")
+)
+
+// ValidationError lists every problem found in a request. It matches Err,
+// which says what was being validated.
+type ValidationError struct {
+	Err      error
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return e.Err.Error() + ": " + strings.Join(e.Problems, "; ")
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// AttributeSchemaRepository stores the attribute schema of every tenant. The
+// UserRepository of the same storage enforces its unique attributes, since
+// only the storage can check uniqueness and write in one step.
+type AttributeSchemaRepository interface {
+	GetAttributeSchema(ctx context.Context, tenantID string) ([]domain.AttributeDefinition, error)
+	PutAttributeSchema(ctx context.Context, tenantID string, schema []domain.AttributeDefinition) error
+}
+
+type AttributeService interface {
+	GetAttributeSchema(ctx context.Context) ([]domain.AttributeDefinition, error)
+	PutAttributeSchema(ctx context.Context, schema []domain.AttributeDefinition) ([]domain.AttributeDefinition, error)
+}
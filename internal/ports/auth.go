@@ -0,0 +1,22 @@
+package ports
+
+import (
+	"context"
+	"errors"
+
+	"github.com/flexer2006/t-t-ogen-go/internal/domain"
+)
+
+var (
+	ErrAPIKeyNotFound   = errors.New("api key not found")
+	ErrUnauthenticated  = errors.New("unauthenticated")
+	ErrPermissionDenied = errors.New("permission denied")
+)
+
+type APIKeyStore interface {
+	LookupAPIKey(ctx context.Context, hash string) (domain.APIKey, error)
+}
+
+type TokenVerifier interface {
+	VerifyToken(ctx context.Context, token string) (domain.Principal, error)
+}
@@ -0,0 +1,59 @@
+package ports
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/flexer2006/t-t-ogen-go/internal/domain"
+)
+
+var (
+	ErrInvalidPassword    = errors.New("invalid password")
+	ErrNoPassword         = errors.New("user has no password")
+	ErrInvalidCredentials = errors.New("invalid credentials")
+	ErrAccountLocked      = errors.New("account locked")
+)
+
+// LockedError reports a locked account and when it unlocks. It matches
+// ErrAccountLocked.
+type LockedError struct {
+	Until time.Time
+}
+
+func (e *LockedError) Error() string {
+	return ErrAccountLocked.Error() + " until " + e.Until.UTC().Format(time.RFC3339)
+}
+
+func (e *LockedError) Unwrap() error {
+	return ErrAccountLocked
+}
+
+// CredentialRepository stores the passwords of users, scoped to a tenant like
+// UserRepository. Deleting a user deletes its credential.
+type CredentialRepository interface {
+	GetUserByUsername(ctx context.Context, tenantID, username string) (domain.User, error)
+	// GetCredential fails with ErrNoPassword for a user without a password.
+	GetCredential(ctx context.Context, tenantID string, userID uuid.UUID) (domain.Credential, error)
+	// SetPassword replaces the password hash, clears failures and lockout,
+	// and ends the sessions of the user.
+	SetPassword(ctx context.Context, tenantID string, userID uuid.UUID, hash string) error
+	// RecordLoginFailure counts a failure, locks the account once policy says
+	// so, and returns the updated credential.
+	RecordLoginFailure(ctx context.Context, tenantID string, userID uuid.UUID, now time.Time, policy domain.LockoutPolicy) (domain.Credential, error)
+	// RecordLoginSuccess clears failures once a password verified against
+	// hash. It fails with ErrAccountLocked if the account locked while the
+	// password was checked, and with ErrInvalidCredentials if the password
+	// changed in the meantime. A non-empty rehash replaces the password hash.
+	RecordLoginSuccess(ctx context.Context, tenantID string, userID uuid.UUID, now time.Time, hash, rehash string) error
+}
+
+// CredentialService manages passwords. Login needs no principal; it reads
+// only the tenant from the context.
+type CredentialService interface {
+	SetPassword(ctx context.Context, userID uuid.UUID, password string) error
+	ChangePassword(ctx context.Context, userID uuid.UUID, current, password string) error
+	Login(ctx context.Context, username, password string) (domain.User, error)
+}
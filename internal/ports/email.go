@@ -0,0 +1,36 @@
+package ports
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/flexer2006/t-t-ogen-go/internal/domain"
+)
+
+var (
+	ErrInvalidEmail             = errors.New("invalid email address")
+	ErrEmailTaken               = errors.New("email address already taken")
+	ErrNoEmailToVerify          = errors.New("user has no unverified email address")
+	ErrInvalidVerificationToken = errors.New("invalid verification token")
+)
+
+// EmailVerificationRepository keeps at most one pending verification per
+// user, scoped to a tenant like UserRepository.
+type EmailVerificationRepository interface {
+	// PutEmailVerification replaces the pending verification of the user. It
+	// fails with ErrNoEmailToVerify unless token.Email is the user's current,
+	// unverified address.
+	PutEmailVerification(ctx context.Context, tenantID string, userID uuid.UUID, token domain.VerificationToken) error
+	// ConsumeEmailVerification marks the user's address verified if hash
+	// matches the pending verification and it has not expired at now. The
+	// verification is removed either way once it is used or expired.
+	ConsumeEmailVerification(ctx context.Context, tenantID string, userID uuid.UUID, hash []byte, now time.Time) (domain.User, error)
+}
+
+// Notifier delivers messages to users.
+type Notifier interface {
+	SendEmailVerification(ctx context.Context, verification domain.EmailVerification) error
+}
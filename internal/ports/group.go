@@ -0,0 +1,43 @@
+package ports
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+
+	"github.com/flexer2006/t-t-ogen-go/internal/domain"
+)
+
+var (
+	ErrGroupNotFound  = errors.New("group not found")
+	ErrGroupNameTaken = errors.New("group name already taken")
+	ErrNotGroupMember = errors.New("user is not a member of the group")
+)
+
+// GroupRepository scopes groups to a tenant like UserRepository does users.
+// Members must belong to the group's tenant, and deleting a user removes it
+// from every group.
+type GroupRepository interface {
+	ListGroups(ctx context.Context, tenantID string) ([]domain.Group, error)
+	CreateGroup(ctx context.Context, tenantID, name, description string) (domain.Group, error)
+	GetGroup(ctx context.Context, tenantID string, id uuid.UUID) (domain.Group, error)
+	UpdateGroup(ctx context.Context, tenantID string, id uuid.UUID, name *string, description *string) (domain.Group, error)
+	DeleteGroup(ctx context.Context, tenantID string, id uuid.UUID) error
+	ListGroupMembers(ctx context.Context, tenantID string, groupID uuid.UUID) ([]domain.User, error)
+	AddGroupMember(ctx context.Context, tenantID string, groupID, userID uuid.UUID) error
+	RemoveGroupMember(ctx context.Context, tenantID string, groupID, userID uuid.UUID) error
+	ListUserGroups(ctx context.Context, tenantID string, userID uuid.UUID) ([]domain.Group, error)
+}
+
+type GroupService interface {
+	ListGroups(ctx context.Context) ([]domain.Group, error)
+	CreateGroup(ctx context.Context, name, description string) (domain.Group, error)
+	GetGroup(ctx context.Context, id uuid.UUID) (domain.Group, error)
+	UpdateGroup(ctx context.Context, id uuid.UUID, name *string, description *string) (domain.Group, error)
+	DeleteGroup(ctx context.Context, id uuid.UUID) error
+	ListGroupMembers(ctx context.Context, groupID uuid.UUID) ([]domain.User, error)
+	AddGroupMember(ctx context.Context, groupID, userID uuid.UUID) error
+	RemoveGroupMember(ctx context.Context, groupID, userID uuid.UUID) error
+	ListUserGroups(ctx context.Context, userID uuid.UUID) ([]domain.Group, error)
+}
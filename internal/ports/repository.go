@@ -2,16 +2,32 @@ package ports
 
 import (
 	"context"
+	"errors"
 
 	"github.com/google/uuid"
 
 	"github.com/flexer2006/t-t-ogen-go/internal/domain"
 )
 
+// ErrStorageReadOnly is returned by writes while storage is being handed over
+// to another process.
+var ErrStorageReadOnly = errors.New("storage is read-only")
+
+// UserRepository scopes every operation to one tenant. Usernames, email
+// addresses and values of unique attributes are unique within a tenant, and
+// a user of another tenant is reported as not found. ListUsers returns the
+// users whose attributes equal every value of match; nil attributes leave
+// UpdateUser's attributes unchanged. Changing the email address clears
+// EmailVerified and drops the pending verification.
 type UserRepository interface {
-	ListUsers(ctx context.Context) ([]domain.User, error)
-	CreateUser(ctx context.Context, name, username string) (domain.User, error)
-	GetUser(ctx context.Context, id uuid.UUID) (domain.User, error)
-	UpdateUser(ctx context.Context, id uuid.UUID, name *string, username *string) (domain.User, error)
-	DeleteUser(ctx context.Context, id uuid.UUID) error
+	UserCounter
+	ListUsers(ctx context.Context, tenantID string, match domain.Attributes) ([]domain.User, error)
+	CreateUser(ctx context.Context, tenantID, name, username, email string, attributes domain.Attributes) (domain.User, error)
+	GetUser(ctx context.Context, tenantID string, id uuid.UUID) (domain.User, error)
+	UpdateUser(ctx context.Context, tenantID string, id uuid.UUID, name *string, username *string, email *string, attributes domain.Attributes) (domain.User, error)
+	DeleteUser(ctx context.Context, tenantID string, id uuid.UUID) error
+}
+
+type UserCounter interface {
+	CountUsers(ctx context.Context) (int, error)
 }
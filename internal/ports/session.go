@@ -0,0 +1,46 @@
+package ports
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/flexer2006/t-t-ogen-go/internal/domain"
+)
+
+var ErrSessionNotFound = errors.New("session not found")
+
+// SessionRepository stores sessions. Expired sessions count as missing until
+// DeleteExpiredSessions removes them. Deleting a user or setting its
+// password ends its sessions.
+type SessionRepository interface {
+	CreateSession(ctx context.Context, session domain.Session) error
+	// GetSessionByToken finds a session of any tenant by the hash of its
+	// token.
+	GetSessionByToken(ctx context.Context, tokenHash []byte, now time.Time) (domain.Session, error)
+	// TouchSession records a use of the session and moves its idle expiry.
+	TouchSession(ctx context.Context, id uuid.UUID, usedAt, idleExpiresAt time.Time) error
+	ListSessions(ctx context.Context, tenantID string, userID uuid.UUID, now time.Time) ([]domain.Session, error)
+	DeleteSession(ctx context.Context, tenantID string, userID, sessionID uuid.UUID) error
+	DeleteUserSessions(ctx context.Context, tenantID string, userID uuid.UUID) error
+	// DeleteExpiredSessions removes the sessions expired at now and returns
+	// how many there were.
+	DeleteExpiredSessions(ctx context.Context, now time.Time) (int, error)
+}
+
+// SessionAuthenticator resolves a session token to the principal of its
+// user.
+type SessionAuthenticator interface {
+	AuthenticateSession(ctx context.Context, token string) (domain.Principal, error)
+}
+
+// SessionService opens sessions on login and manages those of a user. Login
+// needs no principal; it reads only the tenant from the context.
+type SessionService interface {
+	Login(ctx context.Context, username, password string) (domain.Login, error)
+	ListSessions(ctx context.Context, userID uuid.UUID) ([]domain.Session, error)
+	RevokeSession(ctx context.Context, userID, sessionID uuid.UUID) error
+	RevokeSessions(ctx context.Context, userID uuid.UUID) error
+}
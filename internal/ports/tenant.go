@@ -0,0 +1,30 @@
+package ports
+
+import (
+	"context"
+	"errors"
+
+	"github.com/flexer2006/t-t-ogen-go/internal/domain"
+)
+
+var (
+	ErrTenantNotFound  = errors.New("tenant not found")
+	ErrTenantExists    = errors.New("tenant already exists")
+	ErrTenantNotEmpty  = errors.New("tenant still has users")
+	ErrTenantRequired  = errors.New("tenant is required")
+	ErrTenantProtected = errors.New("tenant cannot be deleted")
+)
+
+type TenantRepository interface {
+	ListTenants(ctx context.Context) ([]domain.Tenant, error)
+	CreateTenant(ctx context.Context, tenant domain.Tenant) (domain.Tenant, error)
+	GetTenant(ctx context.Context, id string) (domain.Tenant, error)
+	DeleteTenant(ctx context.Context, id string) error
+}
+
+type TenantService interface {
+	ListTenants(ctx context.Context) ([]domain.Tenant, error)
+	CreateTenant(ctx context.Context, tenant domain.Tenant) (domain.Tenant, error)
+	GetTenant(ctx context.Context, id string) (domain.Tenant, error)
+	DeleteTenant(ctx context.Context, id string) error
+}
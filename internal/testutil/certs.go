@@ -0,0 +1,114 @@
+package testutil
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"testing"
+	"time"
+)
+
+// CA issues certificates for tests. Its certificates are valid from an hour
+// before until an hour after they are issued by the real clock, since TLS
+// handshakes check them against it.
+type CA struct {
+	Certificate *x509.Certificate
+	key         *ecdsa.PrivateKey
+}
+
+func NewCA(t testing.TB) *CA {
+	t.Helper()
+
+	cert, key := createCertificate(t, &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test ca"},
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}, nil, nil)
+
+	return &CA{Certificate: cert, key: key}
+}
+
+// Pool returns a pool that trusts the CA.
+func (ca *CA) Pool() *x509.CertPool {
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.Certificate)
+
+	return pool
+}
+
+// Issue signs a leaf certificate for a new key. template needs a serial
+// number; the validity period and key usage are filled in.
+func (ca *CA) Issue(t testing.TB, template *x509.Certificate) (*x509.Certificate, *ecdsa.PrivateKey) {
+	t.Helper()
+
+	template.KeyUsage = x509.KeyUsageDigitalSignature
+
+	return createCertificate(t, template, ca.Certificate, ca.key)
+}
+
+// WriteCertificate writes cert to path as PEM.
+func WriteCertificate(t testing.TB, cert *x509.Certificate, path string) {
+	t.Helper()
+
+	writePEM(t, path, "CERTIFICATE", cert.Raw)
+}
+
+// WriteKeyPair writes cert and key to the PEM files that
+// tls.LoadX509KeyPair reads.
+func WriteKeyPair(t testing.TB, cert *x509.Certificate, key *ecdsa.PrivateKey, certFile, keyFile string) {
+	t.Helper()
+
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("marshal key: %v", err)
+	}
+
+	WriteCertificate(t, cert, certFile)
+	writePEM(t, keyFile, "EC PRIVATE KEY", der)
+}
+
+// createCertificate signs template with parentKey, or self-signs it when
+// parent is nil.
+func createCertificate(t testing.TB, template, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+
+	now := time.Now()
+	template.NotBefore = now.Add(-time.Hour)
+	template.NotAfter = now.Add(time.Hour)
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		t.Fatalf("create certificate: %v", err)
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("parse certificate: %v", err)
+	}
+
+	return cert, key
+}
+
+func writePEM(t testing.TB, path, blockType string, der []byte) {
+	t.Helper()
+
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600); err != nil {
+		t.Fatalf("write %s: %v", path, err)
+	}
+}
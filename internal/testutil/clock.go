@@ -0,0 +1,32 @@
+// Package testutil holds fixtures shared by the tests of several packages.
+package testutil
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock is a time source that moves only when told to, for code that reads
+// the time through a func() time.Time.
+type Clock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func NewClock(now time.Time) *Clock {
+	return &Clock{now: now}
+}
+
+func (c *Clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+func (c *Clock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+}
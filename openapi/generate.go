@@ -1,4 +1,21 @@
-// Package openapi provides helpers for generating API artifacts.
+// Package openapi holds the API contracts, embedded for serving at runtime,
+// and generates the servers and clients from them. spec.yaml describes the
+// current version; v1.yaml is frozen.
 package openapi
 
+import _ "embed"
+
 //go:generate go run github.com/ogen-go/ogen/cmd/ogen --target ../generated --package api --clean spec.yaml
+//go:generate go run github.com/ogen-go/ogen/cmd/ogen --target ../generated/v1 --package apiv1 --clean v1.yaml
+
+// Spec is the OpenAPI document of the current version, served under
+// /v2.
+//
+//go:embed spec.yaml
+var Spec []byte
+
+// SpecV1 is the deprecated version 1 document, served under /v1 and at the
+// root.
+//
+//go:embed v1.yaml
+var SpecV1 []byte
@@ -0,0 +1,205 @@
+package userclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	xerrors "github.com/go-faster/errors"
+	"github.com/google/uuid"
+	"github.com/ogen-go/ogen/validate"
+
+	api "github.com/flexer2006/t-t-ogen-go/generated"
+	clientadapter "github.com/flexer2006/t-t-ogen-go/internal/adapters/client"
+	"github.com/flexer2006/t-t-ogen-go/internal/adapters/telemetry"
+)
+
+// apiPrefix is the version of the service API this package speaks.
+const apiPrefix = "/v2"
+
+var errUnexpectedResponse = xerrors.New("userclient: unexpected response")
+
+// Client calls the user service. It is safe for concurrent use.
+type Client struct {
+	invoker api.Invoker
+	tenant  api.OptTenantID
+}
+
+// New creates a client for the service at DefaultBaseURL unless WithBaseURL
+// says otherwise.
+func New(opts ...Option) (*Client, error) {
+	o := options{
+		baseURL:    DefaultBaseURL,
+		httpClient: http.DefaultClient,
+		retry:      DefaultRetryPolicy,
+	}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	credentials := clientadapter.Credentials{
+		APIKey:            o.apiKey,
+		BearerToken:       o.bearerToken,
+		SessionToken:      o.sessionToken,
+		ClientCertificate: o.clientCert,
+	}
+
+	httpClient := &retryingClient{
+		next:   telemetry.NewPropagatingClient(clientadapter.NewDecompressingClient(o.httpClient)),
+		policy: o.retry,
+	}
+
+	invoker, err := api.NewClient(strings.TrimSuffix(o.baseURL, "/")+apiPrefix, credentials,
+		api.WithClient(httpClient),
+		api.WithTracerProvider(o.tracerProvider),
+		api.WithMeterProvider(o.meterProvider),
+	)
+	if err != nil {
+		return nil, xerrors.Wrap(err, "userclient.New")
+	}
+
+	client := &Client{invoker: invoker}
+
+	if o.tenant != "" {
+		client.tenant = api.NewOptTenantID(api.TenantID(o.tenant))
+	}
+
+	return client, nil
+}
+
+func (c *Client) ListUsers(ctx context.Context) ([]User, error) {
+	users, err := c.invoker.ListUsers(ctx, api.ListUsersParams{XTenantID: c.tenant})
+	if err != nil {
+		return nil, wrap(err, "userclient.Client.ListUsers")
+	}
+
+	result := make([]User, len(users))
+
+	for i, user := range users {
+		result[i] = fromAPIPartialUser(user)
+	}
+
+	return result, nil
+}
+
+// CreateUser returns an error matching ErrConflict if the username is taken.
+func (c *Client) CreateUser(ctx context.Context, user NewUser) (User, error) {
+	resp, err := c.invoker.CreateUser(ctx, &api.NewUser{
+		DisplayName: user.Name,
+		Username:    user.Username,
+	}, api.CreateUserParams{XTenantID: c.tenant})
+	if err != nil {
+		return User{}, wrap(err, "userclient.Client.CreateUser")
+	}
+
+	switch result := resp.(type) {
+	case *api.User:
+		return fromAPIUser(*result), nil
+	case *api.CreateUserConflict:
+		return User{}, xerrors.Wrap(&APIError{StatusCode: http.StatusConflict}, "userclient.Client.CreateUser")
+	default:
+		return User{}, xerrors.Wrapf(errUnexpectedResponse, "userclient.Client.CreateUser: %T", result)
+	}
+}
+
+// GetUser returns an error matching ErrNotFound if the user does not exist.
+func (c *Client) GetUser(ctx context.Context, id uuid.UUID) (User, error) {
+	resp, err := c.invoker.GetUser(ctx, api.GetUserParams{ID: id, XTenantID: c.tenant})
+	if err != nil {
+		return User{}, wrap(err, "userclient.Client.GetUser")
+	}
+
+	switch result := resp.(type) {
+	case *api.PartialUser:
+		return fromAPIPartialUser(*result), nil
+	case *api.GetUserNotFound:
+		return User{}, xerrors.Wrap(&APIError{StatusCode: http.StatusNotFound}, "userclient.Client.GetUser")
+	default:
+		return User{}, xerrors.Wrapf(errUnexpectedResponse, "userclient.Client.GetUser: %T", result)
+	}
+}
+
+// UpdateUser returns an error matching ErrNotFound if the user does not
+// exist and ErrConflict if the new username is taken.
+func (c *Client) UpdateUser(ctx context.Context, id uuid.UUID, update UserUpdate) (User, error) {
+	var payload api.UpdateUser
+
+	if update.Name != nil {
+		payload.DisplayName = api.NewOptString(*update.Name)
+	}
+
+	if update.Username != nil {
+		payload.Username = api.NewOptString(*update.Username)
+	}
+
+	resp, err := c.invoker.UpdateUser(ctx, &payload, api.UpdateUserParams{ID: id, XTenantID: c.tenant})
+	if err != nil {
+		return User{}, wrap(err, "userclient.Client.UpdateUser")
+	}
+
+	switch result := resp.(type) {
+	case *api.User:
+		return fromAPIUser(*result), nil
+	case *api.UpdateUserNotFound:
+		return User{}, xerrors.Wrap(&APIError{StatusCode: http.StatusNotFound}, "userclient.Client.UpdateUser")
+	case *api.UpdateUserConflict:
+		return User{}, xerrors.Wrap(&APIError{StatusCode: http.StatusConflict}, "userclient.Client.UpdateUser")
+	default:
+		return User{}, xerrors.Wrapf(errUnexpectedResponse, "userclient.Client.UpdateUser: %T", result)
+	}
+}
+
+// DeleteUser returns an error matching ErrNotFound if the user does not
+// exist.
+func (c *Client) DeleteUser(ctx context.Context, id uuid.UUID) error {
+	resp, err := c.invoker.DeleteUser(ctx, api.DeleteUserParams{ID: id, XTenantID: c.tenant})
+	if err != nil {
+		return wrap(err, "userclient.Client.DeleteUser")
+	}
+
+	switch result := resp.(type) {
+	case *api.DeleteUserNoContent:
+		return nil
+	case *api.DeleteUserNotFound:
+		return xerrors.Wrap(&APIError{StatusCode: http.StatusNotFound}, "userclient.Client.DeleteUser")
+	default:
+		return xerrors.Wrapf(errUnexpectedResponse, "userclient.Client.DeleteUser: %T", result)
+	}
+}
+
+// wrap turns undocumented status codes into *APIError, so callers never see
+// errors from the generated package.
+func wrap(err error, op string) error {
+	var status *validate.UnexpectedStatusCodeError
+	if errors.As(err, &status) {
+		apiErr := &APIError{StatusCode: status.StatusCode}
+
+		if status.Payload != nil {
+			apiErr.RetryAfter, _ = retryAfter(status.Payload)
+		}
+
+		return xerrors.Wrap(apiErr, op)
+	}
+
+	return xerrors.Wrap(err, op)
+}
+
+func fromAPIUser(user api.User) User {
+	return User{
+		ID:       user.GetID(),
+		Name:     user.GetDisplayName(),
+		Username: user.GetUsername(),
+	}
+}
+
+// fromAPIPartialUser converts a response that carries every property, since
+// the client does not request sparse fieldsets.
+func fromAPIPartialUser(user api.PartialUser) User {
+	return User{
+		ID:       user.ID.Or(uuid.Nil),
+		Name:     user.DisplayName.Or(""),
+		Username: user.Username.Or(""),
+	}
+}
@@ -0,0 +1,27 @@
+// Package userclient is the Go client for the user service.
+//
+// Compatibility: the package follows semantic versioning independently of
+// the generated api package and the service internals. Exported identifiers
+// are not removed or changed incompatibly within a major version; new
+// fields, options and methods may be added. Types from the generated package
+// never appear in this API, so regenerating the server does not break
+// callers.
+//
+// Coverage: the client wraps basic user CRUD and tenant administration only.
+// ListUsers returns every user without filtering or field selection, and
+// there are no methods for email verification, passwords and sessions,
+// custom attributes or groups; use the HTTP API directly for those.
+//
+//	client, err := userclient.New(
+//		userclient.WithBaseURL("https://users.example.com"),
+//		userclient.WithAPIKey(os.Getenv("USER_SERVICE_API_KEY")),
+//	)
+//	if err != nil {
+//		return err
+//	}
+//
+//	user, err := client.GetUser(ctx, id)
+//	if errors.Is(err, userclient.ErrNotFound) {
+//		...
+//	}
+package userclient
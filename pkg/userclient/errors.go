@@ -0,0 +1,46 @@
+package userclient
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	xerrors "github.com/go-faster/errors"
+)
+
+// Sentinel errors matched by errors.Is against errors returned by Client.
+var (
+	ErrNotFound     = xerrors.New("userclient: user not found")
+	ErrUnauthorized = xerrors.New("userclient: unauthorized")
+	ErrForbidden    = xerrors.New("userclient: forbidden")
+	ErrRateLimited  = xerrors.New("userclient: rate limited")
+	ErrConflict     = xerrors.New("userclient: conflict")
+)
+
+// APIError reports a response with an error status code.
+type APIError struct {
+	StatusCode int
+	// RetryAfter is the delay the service asked for, if any.
+	RetryAfter time.Duration
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("userclient: request failed with status %d %s", e.StatusCode, http.StatusText(e.StatusCode))
+}
+
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrConflict:
+		return e.StatusCode == http.StatusConflict
+	default:
+		return false
+	}
+}
@@ -0,0 +1,124 @@
+package userclient
+
+import (
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// DefaultBaseURL points at a service running locally with default settings.
+// Base URLs name the service root; the client adds the API version path.
+const DefaultBaseURL = "http://localhost:42873"
+
+// HTTPClient sends requests; *http.Client implements it.
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// RetryPolicy controls retries of idempotent requests (GET, PUT, DELETE)
+// after network errors and 429, 502, 503 or 504 responses. Creating a user
+// is never retried.
+type RetryPolicy struct {
+	// MaxAttempts includes the first attempt; 1 disables retries.
+	MaxAttempts int
+	// BaseDelay is doubled after every attempt, with jitter.
+	BaseDelay time.Duration
+	// MaxDelay caps the delay between attempts. A Retry-After longer than
+	// MaxDelay ends retrying.
+	MaxDelay time.Duration
+}
+
+// DefaultRetryPolicy is used unless WithRetryPolicy is given.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	BaseDelay:   100 * time.Millisecond,
+	MaxDelay:    2 * time.Second,
+}
+
+type Option func(*options)
+
+type options struct {
+	baseURL        string
+	httpClient     HTTPClient
+	apiKey         string
+	bearerToken    string
+	sessionToken   string
+	clientCert     bool
+	tenant         string
+	retry          RetryPolicy
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+}
+
+func WithBaseURL(baseURL string) Option {
+	return func(o *options) {
+		o.baseURL = baseURL
+	}
+}
+
+// WithHTTPClient replaces http.DefaultClient, for example to configure TLS
+// or timeouts.
+func WithHTTPClient(client HTTPClient) Option {
+	return func(o *options) {
+		o.httpClient = client
+	}
+}
+
+func WithAPIKey(key string) Option {
+	return func(o *options) {
+		o.apiKey = key
+	}
+}
+
+func WithBearerToken(token string) Option {
+	return func(o *options) {
+		o.bearerToken = token
+	}
+}
+
+// WithSessionToken authenticates with the token of a session opened by
+// logging in to the service.
+func WithSessionToken(token string) Option {
+	return func(o *options) {
+		o.sessionToken = token
+	}
+}
+
+// WithClientCertificateAuth authenticates with the TLS client certificate
+// that the HTTP client set by WithHTTPClient presents.
+func WithClientCertificateAuth() Option {
+	return func(o *options) {
+		o.clientCert = true
+	}
+}
+
+// WithTenant addresses user operations to tenant. Callers bound to a tenant
+// by their credentials may omit it; others need it once the service isolates
+// tenants.
+func WithTenant(tenant string) Option {
+	return func(o *options) {
+		o.tenant = tenant
+	}
+}
+
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(o *options) {
+		o.retry = policy
+	}
+}
+
+// WithTracerProvider overrides the global OpenTelemetry tracer provider.
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(o *options) {
+		o.tracerProvider = provider
+	}
+}
+
+// WithMeterProvider overrides the global OpenTelemetry meter provider.
+func WithMeterProvider(provider metric.MeterProvider) Option {
+	return func(o *options) {
+		o.meterProvider = provider
+	}
+}
@@ -0,0 +1,137 @@
+package userclient
+
+import (
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// retryingClient retries idempotent requests according to a RetryPolicy.
+type retryingClient struct {
+	next   HTTPClient
+	policy RetryPolicy
+}
+
+func (c *retryingClient) Do(req *http.Request) (*http.Response, error) {
+	if c.policy.MaxAttempts <= 1 || !idempotent(req.Method) {
+		return c.next.Do(req)
+	}
+
+	for attempt := 1; ; attempt++ {
+		resp, err := c.next.Do(req)
+
+		if attempt >= c.policy.MaxAttempts || !retryable(resp, err) || req.Context().Err() != nil {
+			return resp, err
+		}
+
+		delay := c.backoff(attempt)
+
+		if resp != nil {
+			if after, ok := retryAfter(resp); ok {
+				if after > c.policy.MaxDelay {
+					return resp, nil
+				}
+
+				delay = after
+			}
+		}
+
+		next, ok := rewind(req)
+		if !ok {
+			return resp, err
+		}
+
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(delay)
+
+		select {
+		case <-timer.C:
+		case <-req.Context().Done():
+			timer.Stop()
+
+			return nil, req.Context().Err()
+		}
+
+		req = next
+	}
+}
+
+// backoff doubles BaseDelay per attempt and picks a point in its upper half.
+func (c *retryingClient) backoff(attempt int) time.Duration {
+	delay := c.policy.BaseDelay << (attempt - 1)
+	if delay <= 0 || delay > c.policy.MaxDelay {
+		delay = c.policy.MaxDelay
+	}
+
+	if delay <= 1 {
+		return delay
+	}
+
+	return delay/2 + rand.N(delay/2)
+}
+
+func idempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete, http.MethodOptions:
+		return true
+	default:
+		return false
+	}
+}
+
+func retryable(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	raw := resp.Header.Get("Retry-After")
+	if raw == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(raw); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if at, err := http.ParseTime(raw); err == nil {
+		return max(time.Until(at), 0), true
+	}
+
+	return 0, false
+}
+
+// rewind prepares req for another attempt, which needs a fresh body.
+func rewind(req *http.Request) (*http.Request, bool) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return req, true
+	}
+
+	if req.GetBody == nil {
+		return nil, false
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, false
+	}
+
+	next := req.Clone(req.Context())
+	next.Body = body
+
+	return next, true
+}
@@ -0,0 +1,97 @@
+package userclient
+
+import (
+	"context"
+	"net/http"
+
+	xerrors "github.com/go-faster/errors"
+
+	api "github.com/flexer2006/t-t-ogen-go/generated"
+)
+
+// Tenant is an isolated set of users. Only callers not bound to a tenant may
+// administer tenants.
+type Tenant struct {
+	ID   string
+	Name string
+}
+
+func (c *Client) ListTenants(ctx context.Context) ([]Tenant, error) {
+	tenants, err := c.invoker.ListTenants(ctx)
+	if err != nil {
+		return nil, wrap(err, "userclient.Client.ListTenants")
+	}
+
+	result := make([]Tenant, len(tenants))
+
+	for i, tenant := range tenants {
+		result[i] = fromAPITenant(tenant)
+	}
+
+	return result, nil
+}
+
+// CreateTenant returns an error matching ErrConflict if the tenant exists.
+func (c *Client) CreateTenant(ctx context.Context, tenant Tenant) (Tenant, error) {
+	resp, err := c.invoker.CreateTenant(ctx, &api.Tenant{
+		ID:   api.TenantID(tenant.ID),
+		Name: tenant.Name,
+	})
+	if err != nil {
+		return Tenant{}, wrap(err, "userclient.Client.CreateTenant")
+	}
+
+	switch result := resp.(type) {
+	case *api.Tenant:
+		return fromAPITenant(*result), nil
+	case *api.CreateTenantConflict:
+		return Tenant{}, xerrors.Wrap(&APIError{StatusCode: http.StatusConflict}, "userclient.Client.CreateTenant")
+	default:
+		return Tenant{}, xerrors.Wrapf(errUnexpectedResponse, "userclient.Client.CreateTenant: %T", result)
+	}
+}
+
+// GetTenant returns an error matching ErrNotFound if the tenant does not
+// exist.
+func (c *Client) GetTenant(ctx context.Context, id string) (Tenant, error) {
+	resp, err := c.invoker.GetTenant(ctx, api.GetTenantParams{ID: api.TenantID(id)})
+	if err != nil {
+		return Tenant{}, wrap(err, "userclient.Client.GetTenant")
+	}
+
+	switch result := resp.(type) {
+	case *api.Tenant:
+		return fromAPITenant(*result), nil
+	case *api.GetTenantNotFound:
+		return Tenant{}, xerrors.Wrap(&APIError{StatusCode: http.StatusNotFound}, "userclient.Client.GetTenant")
+	default:
+		return Tenant{}, xerrors.Wrapf(errUnexpectedResponse, "userclient.Client.GetTenant: %T", result)
+	}
+}
+
+// DeleteTenant returns an error matching ErrNotFound if the tenant does not
+// exist and ErrConflict if it still has users or is the default tenant.
+func (c *Client) DeleteTenant(ctx context.Context, id string) error {
+	resp, err := c.invoker.DeleteTenant(ctx, api.DeleteTenantParams{ID: api.TenantID(id)})
+	if err != nil {
+		return wrap(err, "userclient.Client.DeleteTenant")
+	}
+
+	switch result := resp.(type) {
+	case *api.DeleteTenantNoContent:
+		return nil
+	case *api.DeleteTenantNotFound:
+		return xerrors.Wrap(&APIError{StatusCode: http.StatusNotFound}, "userclient.Client.DeleteTenant")
+	case *api.DeleteTenantConflict:
+		return xerrors.Wrap(&APIError{StatusCode: http.StatusConflict}, "userclient.Client.DeleteTenant")
+	default:
+		return xerrors.Wrapf(errUnexpectedResponse, "userclient.Client.DeleteTenant: %T", result)
+	}
+}
+
+func fromAPITenant(tenant api.Tenant) Tenant {
+	return Tenant{
+		ID:   string(tenant.ID),
+		Name: tenant.Name,
+	}
+}
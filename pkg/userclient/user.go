@@ -0,0 +1,21 @@
+package userclient
+
+import "github.com/google/uuid"
+
+type User struct {
+	ID       uuid.UUID
+	Name     string
+	Username string
+}
+
+// NewUser holds the fields of a user to create.
+type NewUser struct {
+	Name     string
+	Username string
+}
+
+// UserUpdate changes the non-nil fields of a user.
+type UserUpdate struct {
+	Name     *string
+	Username *string
+}